@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +42,7 @@ const (
 	AccountsLogName    = "Accounts"
 	LoaderLogName      = "Loader"
 	ProxyLogName       = "Proxy"
+	APILogName         = "API"
 )
 
 type App struct {
@@ -61,6 +64,7 @@ type App struct {
 	book          *address.Book
 	exportPath    string
 	proxy         *proxy.PathInterceptor
+	forkAccounts  *forkAccounts // set when forking a node
 
 	// XXX: move this
 	exported uint
@@ -184,6 +188,7 @@ func (ds *App) Setup(ctx context.Context, dirs ...string) (err error) {
 	if err != nil {
 		return fmt.Errorf("unable to generate balances: %w", err)
 	}
+	ds.forkAccounts = newForkAccounts(accountLogger, ds.cfg, balances)
 	ds.logger.Debug("balances loaded", "list", balances.List())
 
 	nodeLogger := ds.logger.WithGroup(NodeLogName)
@@ -195,8 +200,8 @@ func (ds *App) Setup(ctx context.Context, dirs ...string) (err error) {
 
 	address := resolveUnixOrTCPAddr(nodeCfg.TMConfig.RPC.ListenAddress)
 
-	// Setup lazy proxy
-	if ds.cfg.lazyLoader {
+	// Setup lazy proxy, also used to fund the accounts of a forked node
+	if ds.cfg.lazyLoader || ds.forkAccounts != nil {
 		proxyLogger := ds.logger.WithGroup(ProxyLogName)
 		ds.proxy, err = proxy.NewPathInterceptor(proxyLogger, address)
 		if err != nil {
@@ -211,7 +216,12 @@ func (ds *App) Setup(ctx context.Context, dirs ...string) (err error) {
 			"target_addr", ds.proxy.TargetAddress(),
 		)
 
-		proxyLogger.Info("lazy loading is enabled. packages will be loaded only upon a request via a query or transaction.", "loader", ds.loader.Name())
+		if ds.cfg.lazyLoader {
+			proxyLogger.Info("lazy loading is enabled. packages will be loaded only upon a request via a query or transaction.", "loader", ds.loader.Name())
+		}
+		if ds.forkAccounts != nil {
+			proxyLogger.Info("accounts will be funded with their forked balance upon a request via a query or transaction.")
+		}
 	} else {
 		nodeCfg.TMConfig.RPC.ListenAddress = fmt.Sprintf("%s://%s", address.Network(), address.String())
 	}
@@ -251,8 +261,17 @@ func (ds *App) setupHandlers(ctx context.Context) (http.Handler, error) {
 	remote := ds.devNode.GetRemoteAddress()
 
 	if ds.proxy != nil {
-		proxyLogger := ds.logger.WithGroup(ProxyLogName)
 		remote = ds.proxy.TargetAddress() // update remote address with proxy target address
+	}
+
+	if ds.proxy != nil && ds.forkAccounts != nil {
+		ds.proxy.HandleAddress(func(addrs ...crypto.Address) {
+			ds.forkAccounts.fund(ctx, ds.devNode, addrs...)
+		})
+	}
+
+	if ds.proxy != nil && ds.cfg.lazyLoader {
+		proxyLogger := ds.logger.WithGroup(ProxyLogName)
 
 		// Generate initial paths
 		initPaths := map[string]struct{}{}
@@ -357,6 +376,12 @@ func (ds *App) setupHandlers(ctx context.Context) (http.Handler, error) {
 				res.WriteHeader(http.StatusInternalServerError)
 			}
 		})
+
+		apiServer, err := setupAPIServer(ds.logger.WithGroup(APILogName), ds.cfg, ds)
+		if err != nil {
+			return nil, fmt.Errorf("unable to setup api server: %w", err)
+		}
+		mux.Handle("/_api/", http.StripPrefix("/_api", apiServer))
 	}
 
 	if !ds.cfg.noWatch {
@@ -442,7 +467,11 @@ func (ds *App) RunInteractive(ctx context.Context, term *rawterm.RawTerm) {
 				return
 			}
 
-			ds.handleKeyPress(ctx, key)
+			if key == rawterm.KeyColon {
+				ds.handlePrompt(ctx, term)
+			} else {
+				ds.handleKeyPress(ctx, key)
+			}
 			keyPressCh = listenForKeyPress(ds.logger.WithGroup(KeyPressLogName), term)
 		}
 	}
@@ -459,7 +488,15 @@ H           Help         - Display this message
 R           Reload       - Reload all packages to take change into account.
 Ctrl+S      Save State   - Save the current state
 Ctrl+R      Reset        - Reset application to it's initial/save state.
+:           Command      - Type one of the commands below, Enter to run it, Esc to cancel
 Ctrl+C      Exit         - Exit the application
+
+Commands:
+save <name>              - Save the current state as a named checkpoint
+restore <name>           - Restore a named checkpoint
+list                     - List the saved checkpoints
+advance <blocks>         - Move the block height forward, e.g. "advance 100"
+advance <duration>       - Move the block time forward, e.g. "advance 72h" or "advance 30d"
 `
 
 func (ds *App) handleKeyPress(ctx context.Context, key rawterm.KeyPress) {
@@ -480,11 +517,7 @@ func (ds *App) handleKeyPress(ctx context.Context, key rawterm.KeyPress) {
 
 	case rawterm.KeyCtrlR: // Reset
 		ds.logger.WithGroup(NodeLogName).Info("resetting node state...")
-		// Reset paths
-		ds.pathManager.Reset()
-		ds.devNode.SetPackagePaths(ds.paths...)
-		// Reset the node
-		if err = ds.devNode.Reset(ctx); err != nil {
+		if err = ds.resetNode(ctx); err != nil {
 			ds.logger.WithGroup(NodeLogName).Error("unable to reset node state", "err", err)
 		}
 
@@ -534,6 +567,111 @@ func (ds *App) handleKeyPress(ctx context.Context, key rawterm.KeyPress) {
 	}
 }
 
+// resetNode resets the package paths, and then the node state.
+func (ds *App) resetNode(ctx context.Context) error {
+	ds.pathManager.Reset()
+	ds.devNode.SetPackagePaths(ds.paths...)
+	return ds.devNode.Reset(ctx)
+}
+
+func (ds *App) handlePrompt(ctx context.Context, term *rawterm.RawTerm) {
+	line, err := term.ReadLine(":")
+	switch {
+	case errors.Is(err, rawterm.ErrLineCanceled):
+		return
+	case err != nil:
+		ds.logger.WithGroup(KeyPressLogName).Error("unable to read command", "err", err)
+		return
+	}
+
+	if err := ds.handleCommand(ctx, line); err != nil {
+		ds.logger.WithGroup(NodeLogName).Error("unable to run command", "command", line, "err", err)
+	}
+}
+
+func (ds *App) handleCommand(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	logger := ds.logger.WithGroup(NodeLogName)
+	switch cmd, args := fields[0], fields[1:]; cmd {
+	case "save": // Save a checkpoint
+		if len(args) != 1 {
+			return errors.New("usage: save <name>")
+		}
+
+		logger.Info("saving checkpoint...", "name", args[0])
+		return ds.devNode.SaveCheckpoint(ctx, args[0])
+
+	case "restore": // Restore a checkpoint
+		if len(args) != 1 {
+			return errors.New("usage: restore <name>")
+		}
+
+		logger.Info("restoring checkpoint...", "name", args[0])
+		return ds.devNode.RestoreCheckpoint(ctx, args[0])
+
+	case "list": // List checkpoints
+		checkpoints := ds.devNode.ListCheckpoints()
+		if len(checkpoints) == 0 {
+			logger.Info("no checkpoint saved")
+		}
+
+		for _, cp := range checkpoints {
+			logger.Info("checkpoint",
+				"name", cp.Name,
+				"txs", cp.Txs,
+				"height", cp.Height,
+				"saved", cp.SavedAt.Format(time.TimeOnly),
+			)
+		}
+
+	case "advance": // Move the block height and time forward
+		if len(args) == 0 {
+			return errors.New("usage: advance <blocks|duration>...")
+		}
+
+		var blocks int64
+		var d time.Duration
+		for _, arg := range args {
+			if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+				blocks += n
+				continue
+			}
+
+			dur, err := parseDuration(arg)
+			if err != nil {
+				return fmt.Errorf("invalid number of blocks or duration %q", arg)
+			}
+			d += dur
+		}
+
+		logger.Info("advancing...", "blocks", blocks, "time", d)
+		return ds.devNode.AdvanceBy(ctx, blocks, d)
+
+	default:
+		return fmt.Errorf("unknown command %q, press `H` for help", cmd)
+	}
+
+	return nil
+}
+
+// parseDuration parses a duration like time.ParseDuration, also accepting a
+// number of days with the "d" unit.
+func parseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseInt(days, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(s)
+}
+
 // XXX: packages modifier does not support glob yet
 func resolvePackagesModifier(cfg *AppConfig, bk *address.Book, qpaths []string) ([]gnodev.QueryPath, []string, error) {
 	modifiers := make([]gnodev.QueryPath, 0, len(qpaths))
@@ -30,6 +30,10 @@ type AppConfig struct {
 	// Resolver
 	resolvers varResolver
 
+	// Fork
+	fork       string
+	forkSource *forkSource
+
 	// Node Configuration
 	logFormat   string
 	lazyLoader  bool
@@ -40,6 +44,7 @@ type AppConfig struct {
 	chainId     string
 	chainDomain string
 	unsafeAPI   bool
+	apiToken    string
 	interactive bool
 	paths       string
 }
@@ -116,6 +121,13 @@ func (c *AppConfig) RegisterFlagsWith(fs *flag.FlagSet, defaultCfg AppConfig) {
 		"list of additional resolvers (`root`, `local`, or `remote`) in the form of <resolver>=<location> will be executed in the given order",
 	)
 
+	fs.StringVar(
+		&c.fork,
+		"fork",
+		defaultCfg.fork,
+		"fork the chain of the given node RPC address or tx-archive backup file, pulling its packages and their state on first use",
+	)
+
 	fs.StringVar(
 		&c.nodeRPCListenerAddr,
 		"node-rpc-listener",
@@ -203,7 +215,14 @@ func (c *AppConfig) RegisterFlagsWith(fs *flag.FlagSet, defaultCfg AppConfig) {
 		&c.unsafeAPI,
 		"unsafe-api",
 		defaultCfg.unsafeAPI,
-		"enable /reset and /reload endpoints which are not safe to expose publicly",
+		"enable /reset and /reload endpoints, and the /_api control API, which are not safe to expose publicly",
+	)
+
+	fs.StringVar(
+		&c.apiToken,
+		"api-token",
+		defaultCfg.apiToken,
+		"bearer token required by the /_api control API, generated on start if empty",
 	)
 
 	fs.StringVar(
@@ -233,5 +252,9 @@ func (c *AppConfig) validateConfigFlags() error {
 		return ErrConflictingFileArgs
 	}
 
+	if c.fork != "" && c.genesisFile != "" {
+		return ErrConflictingForkArgs
+	}
+
 	return nil
 }
@@ -17,7 +17,10 @@ import (
 
 const DefaultDomain = "gno.land"
 
-var ErrConflictingFileArgs = errors.New("cannot specify `balances-file` or `txs-file` along with `genesis-file`")
+var (
+	ErrConflictingFileArgs = errors.New("cannot specify `balances-file` or `txs-file` along with `genesis-file`")
+	ErrConflictingForkArgs = errors.New("cannot specify `fork` along with `genesis-file`")
+)
 
 type LocalAppConfig struct {
 	AppConfig
@@ -92,6 +95,12 @@ func execLocalApp(cfg *LocalAppConfig, args []string, cio commands.IO) error {
 		return fmt.Errorf("unable to guess current dir: %w", err)
 	}
 
+	if cfg.fork != "" {
+		if cfg.forkSource, err = setupFork(context.Background(), cfg.fork); err != nil {
+			return err
+		}
+	}
+
 	// If no resolvers is defined, use gno example as root resolver
 	var baseResolvers []packages.Resolver
 
@@ -108,7 +117,10 @@ func execLocalApp(cfg *LocalAppConfig, args []string, cio commands.IO) error {
 			return err
 		}
 		exampleRoot := filepath.Join(gnoroot, "examples")
+		baseResolvers = append(baseResolvers, cfg.forkResolvers()...) // forked packages take precedence over examples
 		baseResolvers = append(baseResolvers, packages.NewRootResolver(exampleRoot))
+	} else {
+		cfg.resolvers = append(cfg.resolvers, cfg.forkResolvers()...)
 	}
 
 	// Check if current directory is a valid gno package
@@ -67,6 +67,13 @@ func (c *StagingAppConfig) RegisterFlags(fs *flag.FlagSet) {
 }
 
 func execStagingCmd(cfg *StagingAppConfig, args []string, io commands.IO) error {
+	if cfg.fork != "" {
+		var err error
+		if cfg.forkSource, err = setupFork(context.Background(), cfg.fork); err != nil {
+			return err
+		}
+	}
+
 	// If no resolvers is defined, use gno example as root resolver
 	if len(cfg.AppConfig.resolvers) == 0 {
 		gnoroot, err := gnoenv.GuessRootDir()
@@ -75,7 +82,10 @@ func execStagingCmd(cfg *StagingAppConfig, args []string, io commands.IO) error
 		}
 
 		exampleRoot := filepath.Join(gnoroot, "examples")
+		cfg.AppConfig.resolvers = append(cfg.AppConfig.resolvers, cfg.forkResolvers()...) // forked packages take precedence over examples
 		cfg.AppConfig.resolvers = append(cfg.AppConfig.resolvers, packages.NewRootResolver(exampleRoot))
+	} else {
+		cfg.AppConfig.resolvers = append(cfg.AppConfig.resolvers, cfg.forkResolvers()...)
 	}
 
 	return runApp(&cfg.AppConfig, io, args...)
@@ -0,0 +1,280 @@
+// Package api implements the control API of gnodev: a local HTTP/JSON API
+// exposing the operations of the interactive mode, such as reloading or
+// resetting the node, to scripts and end-to-end test suites.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/contribs/gnodev/pkg/dev"
+	"github.com/gnolang/gno/contribs/gnodev/pkg/packages"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// maxBodySize is the maximum size of a request body.
+const maxBodySize = 1 << 20
+
+// Node is the dev node controlled by the API.
+type Node interface {
+	Reload(ctx context.Context) error
+	Reset(ctx context.Context) error
+	SaveCurrentState(ctx context.Context) error
+	ExportStateAsGenesis(ctx context.Context) (*bft.GenesisDoc, error)
+	MoveToNextTX(ctx context.Context) error
+	MoveToPreviousTX(ctx context.Context) error
+	AddAccounts(ctx context.Context, balances ...gnoland.Balance) error
+	ListPkgs() []packages.Package
+	SaveCheckpoint(ctx context.Context, name string) error
+	RestoreCheckpoint(ctx context.Context, name string) error
+	ListCheckpoints() []dev.Checkpoint
+	AdvanceBy(ctx context.Context, blocks int64, d time.Duration) error
+}
+
+// Server serves the control API. Every request must be authenticated with
+// the token of the server, as a bearer token of the Authorization header.
+//
+// Endpoints:
+//
+//	GET  /packages                     list the loaded packages
+//	GET  /checkpoints                  list the saved checkpoints
+//	GET  /export                       export the current state as a genesis doc
+//	POST /reload                       reload the packages, keeping the state
+//	POST /reset                        reset the node to its initial/saved state
+//	POST /save                         save the current state as the initial state
+//	POST /next                         go to the next tx
+//	POST /prev                         go to the previous tx
+//	POST /accounts                     fund accounts, e.g. [{"address": "g1...", "amount": "10ugnot"}]
+//	POST /checkpoints/{name}           save the current state as a named checkpoint
+//	POST /checkpoints/{name}/restore   restore a named checkpoint
+//	POST /advance                      move the block height and time forward, e.g. {"blocks": 10, "time": "72h"}
+type Server struct {
+	logger *slog.Logger
+	node   Node
+	token  string
+	mux    *http.ServeMux
+}
+
+func NewServer(logger *slog.Logger, node Node, token string) *Server {
+	s := &Server{
+		logger: logger,
+		node:   node,
+		token:  token,
+		mux:    http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /packages", s.handlePackages)
+	s.mux.HandleFunc("GET /checkpoints", s.handleCheckpoints)
+	s.mux.HandleFunc("GET /export", s.handleExport)
+	s.mux.HandleFunc("POST /reload", s.handleAction(node.Reload))
+	s.mux.HandleFunc("POST /reset", s.handleAction(node.Reset))
+	s.mux.HandleFunc("POST /save", s.handleAction(node.SaveCurrentState))
+	s.mux.HandleFunc("POST /next", s.handleAction(node.MoveToNextTX))
+	s.mux.HandleFunc("POST /prev", s.handleAction(node.MoveToPreviousTX))
+	s.mux.HandleFunc("POST /accounts", s.handleAccounts)
+	s.mux.HandleFunc("POST /checkpoints/{name}", s.handleCheckpoint(node.SaveCheckpoint))
+	s.mux.HandleFunc("POST /checkpoints/{name}/restore", s.handleCheckpoint(node.RestoreCheckpoint))
+	s.mux.HandleFunc("POST /advance", s.handleAdvance)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid or missing token"))
+		return
+	}
+
+	s.logger.Debug("request", "method", r.Method, "path", r.URL.Path)
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleAction returns a handler running the given action on the node.
+func (s *Server) handleAction(action func(ctx context.Context) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := action(r.Context()); err != nil {
+			s.writeNodeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, struct{}{})
+	}
+}
+
+// handleCheckpoint returns a handler running the given action on the node
+// with the checkpoint name of the request path.
+func (s *Server) handleCheckpoint(action func(ctx context.Context, name string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := action(r.Context(), r.PathValue("name")); err != nil {
+			s.writeNodeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, struct{}{})
+	}
+}
+
+// Package describes a loaded package.
+type Package struct {
+	Path     string `json:"path"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+func (s *Server) handlePackages(w http.ResponseWriter, r *http.Request) {
+	pkgs := s.node.ListPkgs()
+
+	res := make([]Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		res = append(res, Package{
+			Path:     pkg.Path,
+			Name:     pkg.Name,
+			Location: pkg.Location,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleCheckpoints(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.node.ListCheckpoints())
+}
+
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	doc, err := s.node.ExportStateAsGenesis(r.Context())
+	if err != nil {
+		s.writeNodeError(w, r, err)
+		return
+	}
+
+	// The app state of the genesis doc requires amino.
+	bz, err := amino.MarshalJSONIndent(doc, "", "  ")
+	if err != nil {
+		s.writeNodeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(bz)
+}
+
+// Account is an account to fund, with its amount in the format of
+// std.ParseCoins.
+type Account struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	var accounts []Account
+	if err := readJSON(r, &accounts); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	balances := make([]gnoland.Balance, 0, len(accounts))
+	for _, acc := range accounts {
+		addr, err := crypto.AddressFromBech32(acc.Address)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid address %q: %w", acc.Address, err))
+			return
+		}
+
+		amount, err := std.ParseCoins(acc.Amount)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid amount %q: %w", acc.Amount, err))
+			return
+		}
+
+		balances = append(balances, gnoland.Balance{Address: addr, Amount: amount})
+	}
+
+	if err := s.node.AddAccounts(r.Context(), balances...); err != nil {
+		s.writeNodeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// Advance moves the block height and time forward, with the time in the
+// format of time.ParseDuration.
+type Advance struct {
+	Blocks int64  `json:"blocks"`
+	Time   string `json:"time"`
+}
+
+func (s *Server) handleAdvance(w http.ResponseWriter, r *http.Request) {
+	var req Advance
+	if err := readJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var d time.Duration
+	if req.Time != "" {
+		var err error
+		if d, err = time.ParseDuration(req.Time); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid time %q: %w", req.Time, err))
+			return
+		}
+	}
+
+	if err := s.node.AdvanceBy(r.Context(), req.Blocks, d); err != nil {
+		s.writeNodeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// writeNodeError writes an error returned by the node, with a status code
+// matching its kind.
+func (s *Server) writeNodeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, dev.ErrCheckpointNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, dev.ErrEmptyCheckpointName),
+		errors.Is(err, dev.ErrInvalidAdvance),
+		errors.Is(err, dev.ErrEmptyState):
+		status = http.StatusBadRequest
+	default:
+		s.logger.Error("request failed", "method", r.Method, "path", r.URL.Path, "err", err)
+	}
+
+	writeError(w, status, err)
+}
+
+func readJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
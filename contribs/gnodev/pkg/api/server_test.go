@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/contribs/gnodev/pkg/dev"
+	"github.com/gnolang/gno/contribs/gnodev/pkg/packages"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret"
+
+type mockNode struct {
+	calls       []string
+	balances    []gnoland.Balance
+	blocks      int64
+	duration    time.Duration
+	checkpoints map[string]bool
+}
+
+func (m *mockNode) call(name string) error {
+	m.calls = append(m.calls, name)
+	return nil
+}
+
+func (m *mockNode) Reload(context.Context) error           { return m.call("reload") }
+func (m *mockNode) Reset(context.Context) error            { return m.call("reset") }
+func (m *mockNode) SaveCurrentState(context.Context) error { return m.call("save") }
+func (m *mockNode) MoveToNextTX(context.Context) error     { return m.call("next") }
+func (m *mockNode) MoveToPreviousTX(context.Context) error { return dev.ErrEmptyState }
+
+func (m *mockNode) ExportStateAsGenesis(context.Context) (*bft.GenesisDoc, error) {
+	return &bft.GenesisDoc{ChainID: "dev", AppState: gnoland.DefaultGenState()}, nil
+}
+
+func (m *mockNode) AddAccounts(_ context.Context, balances ...gnoland.Balance) error {
+	m.balances = append(m.balances, balances...)
+	return nil
+}
+
+func (m *mockNode) ListPkgs() []packages.Package {
+	return []packages.Package{
+		{MemPackage: std.MemPackage{Name: "foo", Path: "gno.land/r/dev/foo"}, Location: "/tmp/foo"},
+	}
+}
+
+func (m *mockNode) SaveCheckpoint(_ context.Context, name string) error {
+	m.checkpoints[name] = true
+	return nil
+}
+
+func (m *mockNode) RestoreCheckpoint(_ context.Context, name string) error {
+	if !m.checkpoints[name] {
+		return dev.ErrCheckpointNotFound
+	}
+	return m.call("restore " + name)
+}
+
+func (m *mockNode) ListCheckpoints() []dev.Checkpoint {
+	list := []dev.Checkpoint{}
+	for name := range m.checkpoints {
+		list = append(list, dev.Checkpoint{Name: name})
+	}
+	return list
+}
+
+func (m *mockNode) AdvanceBy(_ context.Context, blocks int64, d time.Duration) error {
+	m.blocks += blocks
+	m.duration += d
+	return nil
+}
+
+func newTestingServer(t *testing.T) (*mockNode, *httptest.Server) {
+	t.Helper()
+
+	node := &mockNode{checkpoints: map[string]bool{}}
+	ts := httptest.NewServer(NewServer(log.NewTestingLogger(t), node, testToken))
+	t.Cleanup(ts.Close)
+	return node, ts
+}
+
+func doRequest(t *testing.T, ts *httptest.Server, token, method, path, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	bz, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(bz)
+}
+
+func TestServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	node, ts := newTestingServer(t)
+
+	status, body := doRequest(t, ts, "", http.MethodPost, "/reset", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.JSONEq(t, `{"error": "invalid or missing token"}`, body)
+
+	status, _ = doRequest(t, ts, "wrong", http.MethodPost, "/reset", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Empty(t, node.calls)
+
+	// Without a token, every request is rejected.
+	ts = httptest.NewServer(NewServer(log.NewTestingLogger(t), node, ""))
+	defer ts.Close()
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/reset", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer ")
+	res, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Empty(t, node.calls)
+}
+
+func TestServer_Actions(t *testing.T) {
+	t.Parallel()
+
+	node, ts := newTestingServer(t)
+
+	for _, path := range []string{"/reload", "/reset", "/save", "/next"} {
+		status, body := doRequest(t, ts, testToken, http.MethodPost, path, "")
+		assert.Equal(t, http.StatusOK, status, path)
+		assert.JSONEq(t, `{}`, body, path)
+	}
+	assert.Equal(t, []string{"reload", "reset", "save", "next"}, node.calls)
+
+	status, body := doRequest(t, ts, testToken, http.MethodPost, "/prev", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"error": "empty state"}`, body)
+
+	// Actions require POST.
+	status, _ = doRequest(t, ts, testToken, http.MethodGet, "/reset", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestServer_Packages(t *testing.T) {
+	t.Parallel()
+
+	_, ts := newTestingServer(t)
+
+	status, body := doRequest(t, ts, testToken, http.MethodGet, "/packages", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[{"path": "gno.land/r/dev/foo", "name": "foo", "location": "/tmp/foo"}]`, body)
+}
+
+func TestServer_Export(t *testing.T) {
+	t.Parallel()
+
+	_, ts := newTestingServer(t)
+
+	status, body := doRequest(t, ts, testToken, http.MethodGet, "/export", "")
+	require.Equal(t, http.StatusOK, status)
+
+	doc, err := bft.GenesisDocFromJSON([]byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, "dev", doc.ChainID)
+	assert.IsType(t, gnoland.GnoGenesisState{}, doc.AppState)
+}
+
+func TestServer_Accounts(t *testing.T) {
+	t.Parallel()
+
+	node, ts := newTestingServer(t)
+
+	addr := crypto.AddressFromPreimage([]byte("account"))
+	status, _ := doRequest(t, ts, testToken, http.MethodPost, "/accounts",
+		`[{"address": "`+addr.String()+`", "amount": "42ugnot"}]`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []gnoland.Balance{
+		{Address: addr, Amount: std.NewCoins(std.NewCoin("ugnot", 42))},
+	}, node.balances)
+
+	status, _ = doRequest(t, ts, testToken, http.MethodPost, "/accounts", `[{"address": "g1invalid"}]`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = doRequest(t, ts, testToken, http.MethodPost, "/accounts", `{"address": "`+addr.String()+`"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Len(t, node.balances, 1)
+}
+
+func TestServer_Checkpoints(t *testing.T) {
+	t.Parallel()
+
+	node, ts := newTestingServer(t)
+
+	status, _ := doRequest(t, ts, testToken, http.MethodPost, "/checkpoints/before/restore", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = doRequest(t, ts, testToken, http.MethodPost, "/checkpoints/before", "")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, body := doRequest(t, ts, testToken, http.MethodGet, "/checkpoints", "")
+	assert.Equal(t, http.StatusOK, status)
+	var checkpoints []dev.Checkpoint
+	require.NoError(t, json.Unmarshal([]byte(body), &checkpoints))
+	require.Len(t, checkpoints, 1)
+	assert.Equal(t, "before", checkpoints[0].Name)
+
+	status, _ = doRequest(t, ts, testToken, http.MethodPost, "/checkpoints/before/restore", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"restore before"}, node.calls)
+}
+
+func TestServer_Advance(t *testing.T) {
+	t.Parallel()
+
+	node, ts := newTestingServer(t)
+
+	status, _ := doRequest(t, ts, testToken, http.MethodPost, "/advance", `{"blocks": 10, "time": "72h"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(10), node.blocks)
+	assert.Equal(t, 72*time.Hour, node.duration)
+
+	status, _ = doRequest(t, ts, testToken, http.MethodPost, "/advance", `{"time": "3 days"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, 72*time.Hour, node.duration)
+}
@@ -0,0 +1,46 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ForkHeight returns the latest height of a forked chain. Its packages and
+// balances are all queried at this height, so that they come from the same
+// state, even if they're loaded at different times.
+func ForkHeight(ctx context.Context, cl *client.RPCClient) (int64, error) {
+	status, err := cl.Status(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("client unable to get status: %w", err)
+	}
+
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// ForkBalance returns the balance of an account on a forked chain at the
+// given height, which can be used as the balance of the account on the node,
+// to start from the same funds.
+func ForkBalance(ctx context.Context, cl *client.RPCClient, addr crypto.Address, height int64) (std.Coins, error) {
+	qpath := "bank/balances/" + addr.String()
+
+	qres, err := cl.ABCIQueryWithOptions(ctx, qpath, nil, client.ABCIQueryOptions{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("client unable to query: %w", err)
+	}
+
+	if err := qres.Response.Error; err != nil {
+		return nil, fmt.Errorf("querying balance of %s error: %w", addr, err)
+	}
+
+	var coins std.Coins
+	if err := amino.UnmarshalJSON(qres.Response.Data, &coins); err != nil {
+		return nil, fmt.Errorf("unable to decode balance of %s: %w", addr, err)
+	}
+
+	return coins, nil
+}
@@ -0,0 +1,198 @@
+package dev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/contribs/gnodev/pkg/events"
+	"github.com/gnolang/gno/contribs/gnodev/pkg/packages"
+	"github.com/gnolang/gno/gno.land/pkg/integration"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newForkTestingPackages() (counterPkg, counterRealm *std.MemPackage) {
+	counterPkg = &std.MemPackage{
+		Name: "counter",
+		Path: "gno.land/p/dev/counter",
+		Files: []*std.MemFile{
+			{
+				Name: "counter.gno",
+				Body: `package counter
+
+type Counter struct{ N int }
+
+func New() *Counter { return &Counter{} }
+`,
+			},
+		},
+	}
+
+	counterRealm = &std.MemPackage{
+		Name: "counter",
+		Path: "gno.land/r/dev/counter",
+		Files: []*std.MemFile{
+			{
+				Name: "counter.gno",
+				Body: `package counter
+
+import (
+	"strconv"
+
+	"gno.land/p/dev/counter"
+)
+
+var c = counter.New()
+
+func Inc(cur realm) { c.N++ }
+
+func Render(_ string) string { return strconv.Itoa(c.N) }
+`,
+			},
+		},
+	}
+
+	for _, pkg := range []*std.MemPackage{counterPkg, counterRealm} {
+		pkg.SetFile("gnomod.toml", gnolang.GenGnoModLatest(pkg.Path))
+		pkg.Sort()
+	}
+
+	return counterPkg, counterRealm
+}
+
+// newTestingUpstream returns the RPC client of a node whose counter realm was
+// incremented twice, to be forked.
+func newTestingUpstream(t *testing.T) (*Node, *client.RPCClient) {
+	t.Helper()
+
+	counterPkg, counterRealm := newForkTestingPackages()
+	upstream, _ := newTestingDevNode(t, counterPkg, counterRealm)
+	for range 2 {
+		_, err := testingCallRealm(t, upstream, vm.MsgCall{PkgPath: counterRealm.Path, Func: "Inc"})
+		require.NoError(t, err)
+	}
+
+	cl, err := client.NewHTTPClient(upstream.GetRemoteAddress())
+	require.NoError(t, err)
+	return upstream, cl
+}
+
+// newTestingForkResolver returns the resolver of the packages of the
+// upstream node, at its latest height.
+func newTestingForkResolver(t *testing.T, cl *client.RPCClient) packages.Resolver {
+	t.Helper()
+
+	height, err := ForkHeight(context.Background(), cl)
+	require.NoError(t, err)
+	return packages.NewForkResolver("upstream", cl, height)
+}
+
+// newTestingForkNodeConfig returns the config of a node loading packages from
+// the given resolvers. Like in gnodev, the forked packages are cached, so
+// that their state is only pulled on first use: this also matters here, as
+// the RPC of the nodes of a same process are served by the latest one.
+func newTestingForkNodeConfig(resolvers ...packages.Resolver) *NodeConfig {
+	cfg := DefaultNodeConfig(gnoenv.RootDir(), "gno.land")
+	cfg.TMConfig = integration.DefaultTestingTMConfig(gnoenv.RootDir())
+	cfg.Loader = packages.NewLoader(packages.MiddlewareResolver(
+		packages.ChainResolvers(resolvers...),
+		packages.CacheMiddleware(func(pkg *packages.Package) bool {
+			return pkg.Kind == packages.PackageKindFork
+		}),
+		packages.FilterStdlibs))
+	return cfg
+}
+
+func TestNodeFork(t *testing.T) {
+	const rlmpath = "gno.land/r/dev/counter"
+
+	_, cl := newTestingUpstream(t)
+
+	// The realm is forked along with its state.
+	cfg := newTestingForkNodeConfig(newTestingForkResolver(t, cl))
+	node, emitter := newTestingDevNodeWithConfig(t, cfg, rlmpath)
+	for _, pkg := range node.ListPkgs() {
+		assert.EqualValues(t, packages.PackageKindFork, pkg.Kind)
+	}
+
+	render, err := testingRenderRealm(t, node, rlmpath)
+	require.NoError(t, err)
+	assert.Equal(t, "2", render)
+
+	// Transactions apply on top of the forked state, and are replayed on
+	// reload.
+	_, err = testingCallRealm(t, node, vm.MsgCall{PkgPath: rlmpath, Func: "Inc"})
+	require.NoError(t, err)
+	assert.Equal(t, events.EvtTxResult, emitter.NextEvent().Type())
+
+	require.NoError(t, node.Reload(context.Background()))
+	assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+	render, err = testingRenderRealm(t, node, rlmpath)
+	require.NoError(t, err)
+	assert.Equal(t, "3", render)
+
+	// Resetting the node goes back to the forked state.
+	require.NoError(t, node.Reset(context.Background()))
+	assert.Equal(t, events.EvtReset, emitter.NextEvent().Type())
+
+	render, err = testingRenderRealm(t, node, rlmpath)
+	require.NoError(t, err)
+	assert.Equal(t, "2", render)
+}
+
+func TestNodeFork_LocalImport(t *testing.T) {
+	const rlmpath = "gno.land/r/dev/counter"
+
+	_, cl := newTestingUpstream(t)
+
+	// The realm imports a local package, so it can't be restored before the
+	// local package is deployed: it is deployed instead, without its state.
+	counterPkg, _ := newForkTestingPackages()
+	cfg := newTestingForkNodeConfig(
+		packages.NewMockResolver(counterPkg),
+		newTestingForkResolver(t, cl),
+	)
+	node, _ := newTestingDevNodeWithConfig(t, cfg, rlmpath)
+
+	render, err := testingRenderRealm(t, node, rlmpath)
+	require.NoError(t, err)
+	assert.Equal(t, "0", render)
+}
+
+func TestForkBalance(t *testing.T) {
+	upstream, cl := newTestingUpstream(t)
+
+	height, err := ForkHeight(context.Background(), cl)
+	require.NoError(t, err)
+
+	deployer := crypto.MustAddressFromString(integration.DefaultAccount_Address)
+	forked, err := ForkBalance(context.Background(), cl, deployer, height)
+	require.NoError(t, err)
+	assert.False(t, forked.IsZero(), "deployer balance should be forked")
+
+	unknown := crypto.AddressFromPreimage([]byte("unknown"))
+	coins, err := ForkBalance(context.Background(), cl, unknown, height)
+	require.NoError(t, err)
+	assert.True(t, coins.IsZero())
+
+	// The balance is still the one of the fork height after a new
+	// transaction, which pays fees.
+	_, counterRealm := newForkTestingPackages()
+	_, err = testingCallRealm(t, upstream, vm.MsgCall{PkgPath: counterRealm.Path, Func: "Inc"})
+	require.NoError(t, err)
+
+	coins, err = ForkBalance(context.Background(), cl, deployer, height)
+	require.NoError(t, err)
+	assert.Equal(t, forked, coins)
+
+	latest, err := ForkBalance(context.Background(), cl, deployer, 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, forked, latest)
+}
@@ -3,6 +3,7 @@ package dev
 import (
 	"context"
 	"fmt"
+	"go/token"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -20,6 +21,7 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/integration"
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	tmcfg "github.com/gnolang/gno/tm2/pkg/bft/config"
@@ -137,6 +139,13 @@ type Node struct {
 	// state
 	initialState, state []gnoland.TxWithMetadata
 	currentStateIndex   int
+
+	// named checkpoints of the state, see SaveCheckpoint
+	checkpoints map[string]checkpoint
+
+	// shift of the block height and time seen by the realms, see AdvanceBy
+	heightShift int64
+	timeShift   time.Duration
 }
 
 var DefaultFee = std.NewFee(50000, std.MustParseCoin(ugnot.ValueString(1000000)))
@@ -161,6 +170,7 @@ func NewDevNode(ctx context.Context, cfg *NodeConfig, pkgpaths ...string) (*Node
 		currentStateIndex: len(cfg.InitialTxs),
 		paths:             pkgpaths,
 		pkgsModifier:      pkgsModifier,
+		checkpoints:       make(map[string]checkpoint),
 	}
 
 	// XXX: MOVE THIS, passing context here can be confusing
@@ -289,7 +299,8 @@ func (n *Node) getBlockTransactions(ctx context.Context, blockNum uint64) ([]gno
 		metaTxs = append(metaTxs, gnoland.TxWithMetadata{
 			Tx: tx,
 			Metadata: &gnoland.GnoTxMetadata{
-				Timestamp: b.BlockMeta.Header.Time.Unix(),
+				// The block store holds the real block time.
+				Timestamp: b.BlockMeta.Header.Time.Add(n.timeShift).Unix(),
 			},
 		})
 	}
@@ -327,14 +338,11 @@ func (n *Node) Reset(ctx context.Context) error {
 	}
 
 	// Append initialTxs
-	pkgsTxs := n.generateTxs(DefaultFee, pkgs)
-	txs := append(pkgsTxs, n.initialState...)
-
-	genesis := gnoland.DefaultGenState()
-	genesis.Balances = n.config.BalancesList
-	genesis.Txs = txs
+	genesis, pkgsTxs := n.genesisWithPackages(pkgs)
+	genesis.Txs = append(pkgsTxs, n.initialState...)
 
-	// Reset the node with the new genesis state.
+	// Reset the node with the new genesis state, and the real block height and time.
+	n.heightShift, n.timeShift = 0, 0
 	err = n.rebuildNode(ctx, genesis)
 	if err != nil {
 		return fmt.Errorf("unable to initialize a new node: %w", err)
@@ -368,6 +376,31 @@ func (n *Node) Reload(ctx context.Context) error {
 	return n.rebuildNodeFromState(ctx)
 }
 
+// AddAccounts adds the given balances to the genesis state, replacing the
+// ones of the accounts already funded, and reloads the node with its current
+// state.
+func (n *Node) AddAccounts(ctx context.Context, balances ...gnoland.Balance) error {
+	n.muNode.Lock()
+	defer n.muNode.Unlock()
+
+	bls := gnoland.NewBalances()
+	for _, balance := range n.config.BalancesList {
+		bls[balance.Address] = balance
+	}
+	for _, balance := range balances {
+		bls[balance.Address] = balance
+	}
+
+	oldBalances := n.config.BalancesList
+	n.config.BalancesList = bls.List()
+	if err := n.rebuildNodeFromState(ctx); err != nil {
+		n.config.BalancesList = oldBalances
+		return err
+	}
+
+	return nil
+}
+
 // SendTransaction executes a broadcast commit send
 // of the specified transaction to the chain
 func (n *Node) SendTransaction(tx *std.Tx) error {
@@ -405,7 +438,9 @@ func (n *Node) getBlockStoreState(ctx context.Context) ([]gnoland.TxWithMetadata
 	initialTxs := genesis.Txs[n.loadedPackages:] // ignore previously loaded packages
 	state := append([]gnoland.TxWithMetadata{}, initialTxs...)
 
-	lastBlock := n.getLatestBlockNumber()
+	// The results of the latest block of the block store may not be saved
+	// yet, so only fetch the blocks committed by the consensus.
+	lastBlock := uint64(n.Node.ConsensusState().GetLastHeight())
 	var blocnum uint64 = 1
 	for ; blocnum <= lastBlock; blocnum++ {
 		select {
@@ -425,6 +460,49 @@ func (n *Node) getBlockStoreState(ctx context.Context) ([]gnoland.TxWithMetadata
 	return state, nil
 }
 
+// genesisWithPackages returns a new genesis state with the node balances and
+// the given packages: the packages of a forked chain are restored along with
+// their state, while the other ones are deployed by the returned txs.
+func (n *Node) genesisWithPackages(pkgs []packages.Package) (gnoland.GnoGenesisState, []gnoland.TxWithMetadata) {
+	genesis := gnoland.DefaultGenState()
+	genesis.Balances = n.config.BalancesList
+
+	fset := token.NewFileSet()
+	restored := map[string]bool{}
+	deployed := make([]packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg.Kind != packages.PackageKindFork {
+			deployed = append(deployed, pkg)
+			continue
+		}
+
+		// The packages are restored before the genesis txs are run, so a
+		// forked package can only be restored if its imports are too.
+		// Packages are loaded after their imports.
+		imports, err := pkg.Imports(fset)
+		if err == nil {
+			for _, imp := range imports {
+				if !gno.IsStdlib(imp) && !restored[imp] {
+					err = fmt.Errorf("import %q is not forked", imp)
+					break
+				}
+			}
+		}
+		if err != nil {
+			n.logger.Warn("unable to restore forked package state, deploying it instead",
+				"path", pkg.Path, "err", err)
+			deployed = append(deployed, pkg)
+			continue
+		}
+
+		restored[pkg.Path] = true
+		genesis.VM.Packages = append(genesis.VM.Packages, &pkg.MemPackage)
+		genesis.VM.State = append(genesis.VM.State, pkg.State...)
+	}
+
+	return genesis, n.generateTxs(DefaultFee, deployed)
+}
+
 func (n *Node) generateTxs(fee std.Fee, pkgs []packages.Package) []gnoland.TxWithMetadata {
 	metatxs := make([]gnoland.TxWithMetadata, 0, len(pkgs))
 	for _, pkg := range pkgs {
@@ -489,9 +567,8 @@ func (n *Node) rebuildNodeFromState(ctx context.Context) error {
 			return fmt.Errorf("unable to load pkgs: %w", err)
 		}
 
-		genesis := gnoland.DefaultGenState()
-		genesis.Balances = n.config.BalancesList
-		genesis.Txs = n.generateTxs(DefaultFee, pkgs)
+		genesis, pkgsTxs := n.genesisWithPackages(pkgs)
+		genesis.Txs = pkgsTxs
 		return n.rebuildNode(ctx, genesis)
 	}
 
@@ -507,11 +584,7 @@ func (n *Node) rebuildNodeFromState(ctx context.Context) error {
 	}
 
 	// Create genesis with loaded pkgs + previous state
-	genesis := gnoland.DefaultGenState()
-	genesis.Balances = n.config.BalancesList
-
-	// Generate txs
-	pkgsTxs := n.generateTxs(DefaultFee, pkgs)
+	genesis, pkgsTxs := n.genesisWithPackages(pkgs)
 	genesis.Txs = append(pkgsTxs, state...)
 
 	// Reset the node with the new genesis state.
@@ -583,6 +656,9 @@ func (n *Node) rebuildNode(ctx context.Context, genesis gnoland.GnoGenesisState)
 	nodeConfig.Genesis.ConsensusParams.Block.MaxGas = n.config.MaxGasPerBlock
 	// Genesis verification is always false with Gnodev
 	nodeConfig.SkipGenesisSigVerification = true
+	if n.heightShift != 0 || n.timeShift != 0 {
+		nodeConfig.HeaderModifier = shiftHeader(n.heightShift, n.timeShift)
+	}
 
 	// recoverFromError handles panics and converts them to errors.
 	recoverFromError := func() {
@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/gnolang/gno/contribs/gnodev/pkg/events"
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 )
 
-var ErrEmptyState = errors.New("empty state")
+var (
+	ErrEmptyState          = errors.New("empty state")
+	ErrEmptyCheckpointName = errors.New("empty checkpoint name")
+	ErrCheckpointNotFound  = errors.New("checkpoint not found")
+)
 
 // Save the current state as initialState
 func (n *Node) SaveCurrentState(ctx context.Context) error {
@@ -83,13 +89,9 @@ func (n *Node) MoveBy(ctx context.Context, x int) error {
 		return nil
 	}
 
-	// Load genesis packages
-	pkgsTxs := n.generateTxs(DefaultFee, n.pkgs)
-
 	// Create genesis with loaded pkgs + previous state
 	newState := n.state[:newIndex]
-	genesis := gnoland.DefaultGenState()
-	genesis.Balances = n.config.BalancesList
+	genesis, pkgsTxs := n.genesisWithPackages(n.pkgs)
 	genesis.Txs = append(pkgsTxs, newState...)
 
 	// Reset the node with the new genesis state.
@@ -134,3 +136,102 @@ func (n *Node) ExportStateAsGenesis(ctx context.Context) (*bft.GenesisDoc, error
 
 	return &doc, nil
 }
+
+// Checkpoint describes a named checkpoint of the node state.
+type Checkpoint struct {
+	Name    string    `json:"name"`
+	Txs     int       `json:"txs"`      // number of transactions of the state
+	Height  int64     `json:"height"`   // block height seen by the realms
+	SavedAt time.Time `json:"saved_at"` // real time of the save
+}
+
+type checkpoint struct {
+	state     []gnoland.TxWithMetadata
+	height    int64
+	timeShift time.Duration
+	savedAt   time.Time
+}
+
+// SaveCheckpoint saves the current state, along with the block height and time
+// seen by the realms, as a checkpoint with the given name, which can be
+// restored later with RestoreCheckpoint. An existing checkpoint with the same
+// name is replaced.
+func (n *Node) SaveCheckpoint(ctx context.Context, name string) error {
+	if name == "" {
+		return ErrEmptyCheckpointName
+	}
+
+	n.muNode.Lock()
+	defer n.muNode.Unlock()
+
+	// Get current blockstore state
+	state, err := n.getState(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to save checkpoint: %w", err)
+	}
+
+	n.checkpoints[name] = checkpoint{
+		state:     state[:n.currentStateIndex],
+		height:    n.chainHeight(),
+		timeShift: n.timeShift,
+		savedAt:   time.Now(),
+	}
+
+	n.logger.Info("checkpoint saved", "name", name, "tx-index", n.currentStateIndex)
+	return nil
+}
+
+// RestoreCheckpoint rebuilds the node with the state, and the block height and
+// time shift, of the checkpoint with the given name. The currently loaded
+// packages are kept.
+func (n *Node) RestoreCheckpoint(ctx context.Context, name string) error {
+	n.muNode.Lock()
+	defer n.muNode.Unlock()
+
+	cp, ok := n.checkpoints[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrCheckpointNotFound, name)
+	}
+
+	heightShift, timeShift := n.heightShift, n.timeShift
+	n.setChainHeight(cp.height)
+	n.timeShift = cp.timeShift
+
+	// Create genesis with loaded pkgs + checkpoint state
+	genesis, pkgsTxs := n.genesisWithPackages(n.pkgs)
+	genesis.Txs = append(pkgsTxs, cp.state...)
+
+	// Reset the node with the new genesis state.
+	if err := n.rebuildNode(ctx, genesis); err != nil {
+		n.heightShift, n.timeShift = heightShift, timeShift
+		return fmt.Errorf("unable to rebuild node: %w", err)
+	}
+
+	n.logger.Info("checkpoint restored", "name", name, "tx-index", len(cp.state))
+
+	// Update node infos
+	n.state = nil
+	n.currentStateIndex = len(cp.state)
+	n.emitter.Emit(&events.Reload{})
+
+	return nil
+}
+
+// ListCheckpoints returns the saved checkpoints, sorted by name.
+func (n *Node) ListCheckpoints() []Checkpoint {
+	n.muNode.RLock()
+	defer n.muNode.RUnlock()
+
+	list := make([]Checkpoint, 0, len(n.checkpoints))
+	for name, cp := range n.checkpoints {
+		list = append(list, Checkpoint{
+			Name:    name,
+			Txs:     len(cp.state),
+			Height:  cp.height,
+			SavedAt: cp.savedAt,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -115,6 +115,69 @@ func TestSaveCurrentState(t *testing.T) {
 	require.Equal(t, render, "2") // Back to the original state
 }
 
+func TestNodeCheckpoints(t *testing.T) {
+	node, emitter := testingCounterRealm(t, 2)
+
+	ctx := testingContext(t)
+	err := node.SaveCheckpoint(ctx, "two")
+	require.NoError(t, err)
+
+	// Send a new tx
+	msg := vm.MsgCall{
+		PkgPath: testCounterRealm,
+		Func:    "Inc",
+		Args:    []string{"10"},
+	}
+
+	res, err := testingCallRealm(t, node, msg)
+	require.NoError(t, err)
+	require.NoError(t, res.CheckTx.Error)
+	require.NoError(t, res.DeliverTx.Error)
+	assert.Equal(t, events.EvtTxResult, emitter.NextEvent().Type())
+
+	testingWaitStateIndex(t, node, 3)
+	err = node.SaveCheckpoint(ctx, "twelve")
+	require.NoError(t, err)
+
+	checkpoints := node.ListCheckpoints()
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, "twelve", checkpoints[0].Name)
+	assert.Equal(t, 3, checkpoints[0].Txs)
+	assert.Equal(t, "two", checkpoints[1].Name)
+	assert.Equal(t, 2, checkpoints[1].Txs)
+
+	for _, tc := range []struct {
+		Name           string
+		ExpectedResult string
+	}{
+		{"two", "2"},
+		{"twelve", "12"},
+		{"two", "2"},
+	} {
+		err = node.RestoreCheckpoint(ctx, tc.Name)
+		require.NoError(t, err)
+		assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+		render, err := testingRenderRealm(t, node, testCounterRealm)
+		require.NoError(t, err)
+		require.Equal(t, tc.ExpectedResult, render)
+	}
+
+	// The restored state can be navigated.
+	err = node.MoveToPreviousTX(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+	render, err := testingRenderRealm(t, node, testCounterRealm)
+	require.NoError(t, err)
+	require.Equal(t, "1", render)
+
+	err = node.RestoreCheckpoint(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrCheckpointNotFound)
+	err = node.SaveCheckpoint(ctx, "")
+	assert.ErrorIs(t, err, ErrEmptyCheckpointName)
+}
+
 func TestExportState(t *testing.T) {
 	node, _ := testingCounterRealm(t, 3)
 
@@ -191,10 +254,23 @@ func Render(_ string) string { return strconv.Itoa(value) }
 	render, err = testingRenderRealm(t, node, testCounterRealm)
 	require.NoError(t, err)
 	require.Equal(t, render, strconv.Itoa(inc))
+	testingWaitStateIndex(t, node, inc)
 
 	return node, emitter
 }
 
+// testingWaitStateIndex waits for the node to track the transactions of its
+// latest blocks, which is done asynchronously.
+func testingWaitStateIndex(t *testing.T, node *Node, index int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		node.muNode.RLock()
+		defer node.muNode.RUnlock()
+		return node.currentStateIndex == index
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func testingContext(t *testing.T) context.Context {
 	t.Helper()
 
@@ -10,6 +10,7 @@ import (
 	"github.com/gnolang/gno/contribs/gnodev/pkg/events"
 	"github.com/gnolang/gno/contribs/gnodev/pkg/packages"
 	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gno.land/pkg/integration"
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
@@ -17,6 +18,7 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 	core_types "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	tm2events "github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
@@ -270,6 +272,27 @@ func Render(_ string) string { return str }
 	assert.Equal(t, mock.EvtNull, emitter.NextEvent().Type())
 }
 
+func TestNodeAddAccounts(t *testing.T) {
+	node, emitter := testingCounterRealm(t, 2)
+
+	addr := crypto.AddressFromPreimage([]byte("new account"))
+	coins := std.NewCoins(std.NewCoin(ugnot.Denom, 42))
+
+	err := node.AddAccounts(testingContext(t), gnoland.Balance{Address: addr, Amount: coins})
+	require.NoError(t, err)
+	assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+	cli := gnoclient.Client{RPCClient: node.Client()}
+	acc, _, err := cli.QueryAccount(addr)
+	require.NoError(t, err)
+	assert.Equal(t, coins, acc.GetCoins())
+
+	// The state is kept.
+	render, err := testingRenderRealm(t, node, testCounterRealm)
+	require.NoError(t, err)
+	require.Equal(t, "2", render)
+}
+
 func TestTxGasFailure(t *testing.T) {
 	fooPkg := std.MemPackage{
 		Name: "foo",
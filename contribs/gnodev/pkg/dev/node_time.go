@@ -0,0 +1,94 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/contribs/gnodev/pkg/events"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+var ErrInvalidAdvance = errors.New("cannot move the block height or time backward")
+
+// AdvanceBy moves the block height and time seen by the realms forward by the
+// given number of blocks and duration, without sending any transaction. The
+// node is rebuilt with its current state, and the next blocks start from the
+// new height and time.
+func (n *Node) AdvanceBy(ctx context.Context, blocks int64, d time.Duration) error {
+	n.muNode.Lock()
+	defer n.muNode.Unlock()
+
+	switch {
+	case blocks < 0 || d < 0:
+		return ErrInvalidAdvance
+	case blocks == 0 && d == 0:
+		return nil
+	}
+
+	// Fetch the state before updating the time shift, which is added to
+	// the timestamps of the current blocks.
+	state, err := n.getBlockStoreState(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get current state: %w", err)
+	}
+
+	height := n.chainHeight() + blocks
+	heightShift, timeShift := n.heightShift, n.timeShift
+	n.setChainHeight(height)
+	n.timeShift += d
+
+	genesis, pkgsTxs := n.genesisWithPackages(n.pkgs)
+	genesis.Txs = append(pkgsTxs, state...)
+
+	// Reset the node with the new genesis state.
+	if err = n.rebuildNode(ctx, genesis); err != nil {
+		n.heightShift, n.timeShift = heightShift, timeShift
+		return fmt.Errorf("unable to rebuild node: %w", err)
+	}
+
+	n.logger.Info("advancing to", "height", height, "time", time.Now().Add(n.timeShift).Format(time.RFC3339))
+
+	// Update node infos
+	n.state = nil
+	n.currentStateIndex = len(state)
+	n.emitter.Emit(&events.Reload{})
+
+	return nil
+}
+
+// AdvanceBlocks moves the block height seen by the realms forward by the
+// given number of blocks.
+func (n *Node) AdvanceBlocks(ctx context.Context, blocks int64) error {
+	return n.AdvanceBy(ctx, blocks, 0)
+}
+
+// AdvanceTime moves the block time seen by the realms forward by the given
+// duration.
+func (n *Node) AdvanceTime(ctx context.Context, d time.Duration) error {
+	return n.AdvanceBy(ctx, 0, d)
+}
+
+// chainHeight returns the latest block height seen by the realms.
+func (n *Node) chainHeight() int64 {
+	return int64(n.getLatestBlockNumber()) + n.heightShift
+}
+
+// setChainHeight sets the height shift so that the first block of the next
+// rebuilt node is seen at the given height.
+func (n *Node) setChainHeight(height int64) {
+	n.heightShift = max(height-1, 0)
+}
+
+// shiftHeader returns a header modifier adding the given shifts to the height
+// and time of the blocks.
+func shiftHeader(height int64, d time.Duration) func(abci.Header) abci.Header {
+	return func(header abci.Header) abci.Header {
+		h := *header.(*bft.Header) // copy header
+		h.Height += height
+		h.Time = h.Time.Add(d)
+		return &h
+	}
+}
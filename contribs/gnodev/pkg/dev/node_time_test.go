@@ -0,0 +1,151 @@
+package dev
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	mock "github.com/gnolang/gno/contribs/gnodev/internal/mock/emitter"
+	"github.com/gnolang/gno/contribs/gnodev/pkg/events"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClockRealm = "gno.land/r/dev/clock"
+
+func TestNodeAdvanceBy(t *testing.T) {
+	node, emitter := testingClockRealm(t)
+
+	height, now, _ := testingRenderClock(t, node)
+	assert.WithinDuration(t, time.Now(), now, 5*time.Second)
+
+	t.Run("advance blocks", func(t *testing.T) {
+		ctx := testingContext(t)
+		err := node.AdvanceBlocks(ctx, 100)
+		require.NoError(t, err)
+		assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+		newHeight, _, _ := testingRenderClock(t, node)
+		assert.GreaterOrEqual(t, newHeight, height+100)
+		height = newHeight
+	})
+
+	t.Run("advance time", func(t *testing.T) {
+		ctx := testingContext(t)
+		err := node.AdvanceTime(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+		newHeight, now, _ := testingRenderClock(t, node)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), now, 5*time.Second)
+		assert.GreaterOrEqual(t, newHeight, height) // the height is kept
+	})
+
+	t.Run("state is kept", func(t *testing.T) {
+		ctx := testingContext(t)
+		err := node.AdvanceBlocks(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+		_, _, ticks := testingRenderClock(t, node)
+		assert.Equal(t, 1, ticks)
+	})
+
+	t.Run("invalid advance", func(t *testing.T) {
+		ctx := testingContext(t)
+		err := node.AdvanceBy(ctx, -1, 0)
+		assert.ErrorIs(t, err, ErrInvalidAdvance)
+		err = node.AdvanceBy(ctx, 0, -time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidAdvance)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		ctx := testingContext(t)
+		err := node.SaveCheckpoint(ctx, "future")
+		require.NoError(t, err)
+
+		err = node.Reset(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, events.EvtReset, emitter.NextEvent().Type())
+
+		newHeight, now, _ := testingRenderClock(t, node)
+		assert.Less(t, newHeight, int64(100))
+		assert.WithinDuration(t, time.Now(), now, 5*time.Second)
+	})
+
+	t.Run("restore checkpoint", func(t *testing.T) {
+		ctx := testingContext(t)
+		err := node.RestoreCheckpoint(ctx, "future")
+		require.NoError(t, err)
+		assert.Equal(t, events.EvtReload, emitter.NextEvent().Type())
+
+		newHeight, now, ticks := testingRenderClock(t, node)
+		assert.GreaterOrEqual(t, newHeight, height+10)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), now, 5*time.Second)
+		assert.Equal(t, 1, ticks)
+	})
+}
+
+func testingClockRealm(t *testing.T) (*Node, *mock.ServerEmitter) {
+	t.Helper()
+
+	const clockFile = `
+package clock
+
+import (
+	"std"
+	"strconv"
+	"time"
+)
+
+var ticks int
+
+func Tick(cur realm) { ticks++ }
+
+func Render(_ string) string {
+	return strconv.Itoa(int(std.ChainHeight())) + " " + strconv.Itoa(int(time.Now().Unix())) + " " + strconv.Itoa(ticks)
+}
+`
+
+	clockPkg := std.MemPackage{
+		Name: "clock",
+		Path: testClockRealm,
+		Files: []*std.MemFile{
+			{Name: "clock.gno", Body: clockFile},
+		},
+	}
+
+	node, emitter := newTestingDevNode(t, &clockPkg)
+
+	res, err := testingCallRealm(t, node, vm.MsgCall{PkgPath: testClockRealm, Func: "Tick"})
+	require.NoError(t, err)
+	require.NoError(t, res.CheckTx.Error)
+	require.NoError(t, res.DeliverTx.Error)
+	assert.Equal(t, events.EvtTxResult, emitter.NextEvent().Type())
+
+	return node, emitter
+}
+
+// testingRenderClock returns the block height and time seen by the clock
+// realm, along with its number of ticks.
+func testingRenderClock(t *testing.T, node *Node) (int64, time.Time, int) {
+	t.Helper()
+
+	render, err := testingRenderRealm(t, node, testClockRealm)
+	require.NoError(t, err)
+
+	fields := strings.Fields(render)
+	require.Len(t, fields, 3, "invalid render %q", render)
+
+	height, err := strconv.ParseInt(fields[0], 10, 64)
+	require.NoError(t, err)
+	sec, err := strconv.ParseInt(fields[1], 10, 64)
+	require.NoError(t, err)
+	ticks, err := strconv.Atoi(fields[2])
+	require.NoError(t, err)
+
+	return height, time.Unix(sec, 0), ticks
+}
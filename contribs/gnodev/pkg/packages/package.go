@@ -6,8 +6,10 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -19,12 +21,17 @@ const (
 	PackageKindOther  = iota
 	PackageKindRemote = iota
 	PackageKindFS
+	PackageKindFork
 )
 
 type Package struct {
 	std.MemPackage
 	Kind     PackageKind
 	Location string
+
+	// State holds the raw store entries of a package pulled along with its
+	// state from a forked chain, see NewForkResolver.
+	State []vm.StoreEntry
 }
 
 func ReadPackageFromDir(fset *token.FileSet, path, dir string) (*Package, error) {
@@ -112,3 +119,29 @@ func isMemPackageEmpty(mempkg *std.MemPackage) bool {
 
 	return true
 }
+
+// Imports returns the sorted paths imported by the gno files of the package.
+func (pkg *Package) Imports(fset *token.FileSet) ([]string, error) {
+	var imports []string
+	for _, file := range pkg.Files {
+		if !isGnoFile(file.Name) {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file.Name, file.Body, parser.ImportsOnly)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse file %q: %w", file.Name, err)
+		}
+
+		for _, imp := range f.Imports {
+			if len(imp.Path.Value) <= 2 {
+				continue
+			}
+
+			imports = append(imports, imp.Path.Value[1:len(imp.Path.Value)-1])
+		}
+	}
+
+	slices.Sort(imports)
+	return slices.Compact(imports), nil
+}
@@ -0,0 +1,153 @@
+package packages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type forkResolver struct {
+	*client.RPCClient
+	name   string
+	height int64
+
+	// remote resolves the packages of the nodes without the vm/qstate query.
+	remote *remoteResolver
+}
+
+// NewForkResolver returns a resolver of the packages deployed on a forked
+// chain, along with their state at the given height, which is restored on
+// the dev node instead of deploying the package.
+//
+// The nodes which don't support the vm/qstate query only return the files of
+// the packages, which are then deployed from their source, without their
+// state, like with NewRemoteResolver.
+func NewForkResolver(name string, cl *client.RPCClient, height int64) Resolver {
+	return &forkResolver{
+		RPCClient: cl,
+		name:      name,
+		height:    height,
+		remote: &remoteResolver{
+			RPCClient: cl,
+			name:      name,
+			fset:      token.NewFileSet(),
+			height:    height,
+		},
+	}
+}
+
+func (res *forkResolver) Name() string {
+	return fmt.Sprintf("fork<%s>", res.name)
+}
+
+func (res *forkResolver) Resolve(fset *token.FileSet, path string) (*Package, error) {
+	const qpath = "vm/" + vm.QueryState
+
+	// The state is queried by pages, all at the height of the fork.
+	var (
+		ps   vm.PackageState
+		opts = client.ABCIQueryOptions{Height: res.height}
+		data = []byte(path)
+	)
+	for {
+		qres, err := res.RPCClient.ABCIQueryWithOptions(context.Background(), qpath, data, opts)
+		if err != nil {
+			return nil, fmt.Errorf("client unable to query: %w", err)
+		}
+
+		if err := qres.Response.Error; err != nil {
+			switch {
+			case errors.Is(err, vm.InvalidPkgPathError{}):
+				return nil, ErrResolverPackageNotFound
+			case errors.Is(err, std.UnknownRequestError{}) && ps.Package == nil:
+				return res.remote.Resolve(fset, path)
+			}
+
+			return nil, fmt.Errorf("querying %q state error: %w", path, err)
+		}
+
+		var page vm.PackageState
+		if err := amino.UnmarshalJSON(qres.Response.Data, &page); err != nil {
+			return nil, fmt.Errorf("unable to decode %q state: %w", path, err)
+		}
+
+		if ps.Package == nil {
+			ps.Package = page.Package
+		}
+		ps.State = append(ps.State, page.State...)
+		if page.Next == nil {
+			break
+		}
+
+		data = append([]byte(path+":"), page.Next...)
+	}
+
+	if ps.Package == nil {
+		return nil, fmt.Errorf("querying %q state error: missing package", path)
+	}
+
+	if err := validateMemPackage(fset, ps.Package); err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		MemPackage: *ps.Package,
+		Kind:       PackageKindFork,
+		Location:   path,
+		State:      ps.State,
+	}, nil
+}
+
+type archiveResolver struct {
+	name string
+	pkgs map[string]*std.MemPackage
+}
+
+// NewArchiveResolver returns a resolver of the packages deployed by the
+// given transactions of a chain backup, such as the ones of a tx-archive.
+func NewArchiveResolver(name string, txs []gnoland.TxWithMetadata) Resolver {
+	pkgs := map[string]*std.MemPackage{}
+	for _, tx := range txs {
+		for _, msg := range tx.Tx.Msgs {
+			addpkg, ok := msg.(vm.MsgAddPackage)
+			if !ok || addpkg.Package == nil {
+				continue
+			}
+
+			// A package can only be deployed once, later attempts fail.
+			if _, ok := pkgs[addpkg.Package.Path]; !ok {
+				pkgs[addpkg.Package.Path] = addpkg.Package
+			}
+		}
+	}
+
+	return &archiveResolver{name: name, pkgs: pkgs}
+}
+
+func (res *archiveResolver) Name() string {
+	return fmt.Sprintf("archive<%s>", res.name)
+}
+
+func (res *archiveResolver) Resolve(fset *token.FileSet, path string) (*Package, error) {
+	mempkg, ok := res.pkgs[path]
+	if !ok {
+		return nil, ErrResolverPackageNotFound
+	}
+
+	if err := validateMemPackage(fset, mempkg); err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		MemPackage: *mempkg,
+		Kind:       PackageKindOther,
+		Location:   res.name,
+	}, nil
+}
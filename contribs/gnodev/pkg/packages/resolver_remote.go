@@ -16,8 +16,9 @@ import (
 
 type remoteResolver struct {
 	*client.RPCClient
-	name string
-	fset *token.FileSet
+	name   string
+	fset   *token.FileSet
+	height int64 // height of the queries, 0 for the latest
 }
 
 func NewRemoteResolver(name string, cl *client.RPCClient) Resolver {
@@ -37,7 +38,8 @@ func (res *remoteResolver) Resolve(fset *token.FileSet, path string) (*Package,
 
 	// First query files
 	data := []byte(path)
-	qres, err := res.RPCClient.ABCIQuery(context.Background(), qpath, data)
+	opts := client.ABCIQueryOptions{Height: res.height}
+	qres, err := res.RPCClient.ABCIQueryWithOptions(context.Background(), qpath, data, opts)
 	if err != nil {
 		return nil, fmt.Errorf("client unable to query: %w", err)
 	}
@@ -58,7 +60,7 @@ func (res *remoteResolver) Resolve(fset *token.FileSet, path string) (*Package,
 	for _, filename := range files {
 		fname := string(filename)
 		fpath := gopath.Join(path, fname)
-		qres, err := res.RPCClient.ABCIQuery(context.Background(), qpath, []byte(fpath))
+		qres, err := res.RPCClient.ABCIQueryWithOptions(context.Background(), qpath, []byte(fpath), opts)
 		if err != nil {
 			return nil, fmt.Errorf("unable to query path")
 		}
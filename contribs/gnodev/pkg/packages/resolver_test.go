@@ -2,13 +2,16 @@ package packages
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"go/token"
 	"log/slog"
 	"path/filepath"
 	"testing"
 
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/gno.land/pkg/integration"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
@@ -264,6 +267,82 @@ func TestResolver_ResolveRemote(t *testing.T) {
 	})
 }
 
+func TestResolver_ResolveFork(t *testing.T) {
+	const targetPath = "gno.land/r/target/path"
+
+	mempkg := std.MemPackage{
+		Name: "foo",
+		Path: targetPath,
+		Files: []*std.MemFile{
+			{
+				Name: "foo.gno",
+				Body: `package foo; var count = 42; func Render(_ string) string { return "bar" }`,
+			},
+		},
+	}
+	mempkg.SetFile("gnomod.toml", gnolang.GenGnoModLatest(mempkg.Path))
+	mempkg.Sort()
+
+	rootdir := gnoenv.RootDir()
+	cfg := integration.TestingMinimalNodeConfig(rootdir)
+	logger := log.NewTestingLogger(t)
+
+	// Setup genesis state
+	privKey := secp256k1.GenPrivKey()
+	cfg.Genesis.AppState = integration.GenerateTestingGenesisState(privKey, mempkg)
+
+	_, address := integration.TestingInMemoryNode(t, logger, cfg)
+	cl, err := client.NewHTTPClient(address)
+	require.NoError(t, err)
+
+	status, err := cl.Status(context.Background(), nil)
+	require.NoError(t, err)
+
+	forkResolver := NewForkResolver(address, cl, status.SyncInfo.LatestBlockHeight)
+	t.Run("valid package", func(t *testing.T) {
+		pkg, err := forkResolver.Resolve(token.NewFileSet(), mempkg.Path)
+		require.NoError(t, err)
+		require.NotNil(t, pkg)
+		assert.Equal(t, mempkg.Files, pkg.Files)
+		assert.EqualValues(t, PackageKindFork, pkg.Kind)
+		assert.NotEmpty(t, pkg.State)
+	})
+
+	t.Run("invalid package", func(t *testing.T) {
+		pkg, err := forkResolver.Resolve(token.NewFileSet(), "gno.land/r/not/a/valid/package")
+		require.Nil(t, pkg)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrResolverPackageNotFound)
+	})
+}
+
+func TestResolver_ResolveArchive(t *testing.T) {
+	t.Parallel()
+
+	newAddPkgTx := func(body string) gnoland.TxWithMetadata {
+		mempkg := &std.MemPackage{
+			Name:  "foo",
+			Path:  "gno.land/r/dev/foo",
+			Files: []*std.MemFile{{Name: "foo.gno", Body: body}},
+		}
+		msg := vm.MsgAddPackage{Package: mempkg}
+		return gnoland.TxWithMetadata{Tx: std.Tx{Msgs: []std.Msg{msg}}}
+	}
+
+	// Only the first deployment of a package succeeds.
+	resolver := NewArchiveResolver("backup.jsonl", []gnoland.TxWithMetadata{
+		newAddPkgTx("package foo // first"),
+		newAddPkgTx("package foo // second"),
+	})
+
+	pkg, err := resolver.Resolve(token.NewFileSet(), "gno.land/r/dev/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "package foo // first", pkg.Files[0].Body)
+
+	_, err = resolver.Resolve(token.NewFileSet(), "gno.land/r/dev/bar")
+	require.ErrorIs(t, err, ErrResolverPackageNotFound)
+}
+
 func TestResolverRoot_Resolve(t *testing.T) {
 	t.Parallel()
 
@@ -20,18 +20,24 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 type PathHandler func(path ...string)
 
+// AddressHandler is called with the addresses of the accounts used by a
+// request: the signers of a transaction, or the queried account.
+type AddressHandler func(addrs ...crypto.Address)
+
 type PathInterceptor struct {
 	proxyAddr, targetAddr net.Addr
 
-	logger     *slog.Logger
-	listener   net.Listener
-	handlers   []PathHandler
-	muHandlers sync.RWMutex
+	logger       *slog.Logger
+	listener     net.Listener
+	handlers     []PathHandler
+	addrHandlers []AddressHandler
+	muHandlers   sync.RWMutex
 }
 
 // NewPathInterceptor creates a new path proxy interceptor.
@@ -70,6 +76,13 @@ func (proxy *PathInterceptor) HandlePath(fn PathHandler) {
 	proxy.handlers = append(proxy.handlers, fn)
 }
 
+// HandleAddress adds a new address handler to the interceptor.
+func (proxy *PathInterceptor) HandleAddress(fn AddressHandler) {
+	proxy.muHandlers.Lock()
+	defer proxy.muHandlers.Unlock()
+	proxy.addrHandlers = append(proxy.addrHandlers, fn)
+}
+
 // ProxyAddress returns the network address of the proxy.
 func (proxy *PathInterceptor) ProxyAddress() string {
 	return fmt.Sprintf("%s://%s", proxy.proxyAddr.Network(), proxy.proxyAddr.String())
@@ -248,25 +261,42 @@ func (upaths uniqPaths) addPackageDeps(pkg *std.MemPackage) {
 	}
 }
 
+type uniqAddrs map[crypto.Address]struct{}
+
+func (uaddrs uniqAddrs) list() []crypto.Address {
+	addrs := make([]crypto.Address, 0, len(uaddrs))
+	for addr := range uaddrs {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
 // handleRequest parses and processes the RPC request body.
 func (proxy *PathInterceptor) handleRequest(body []byte) error {
-	ps := make(uniqPaths)
-	if err := parseRPCRequest(body, ps); err != nil {
+	ps, as := make(uniqPaths), make(uniqAddrs)
+	if err := parseRPCRequest(body, ps, as); err != nil {
 		return fmt.Errorf("unable to parse RPC request: %w", err)
 	}
 
-	paths := ps.list()
-	if len(paths) == 0 {
-		return nil
-	}
-
-	proxy.logger.Debug("parsed request paths", "paths", paths)
-
 	proxy.muHandlers.RLock()
 	defer proxy.muHandlers.RUnlock()
 
-	for _, handle := range proxy.handlers {
-		handle(paths...)
+	// The accounts are handled first, so that they are funded before
+	// the node is reloaded with the new paths.
+	if addrs := as.list(); len(addrs) > 0 {
+		proxy.logger.Debug("parsed request addresses", "addrs", addrs)
+
+		for _, handle := range proxy.addrHandlers {
+			handle(addrs...)
+		}
+	}
+
+	if paths := ps.list(); len(paths) > 0 {
+		proxy.logger.Debug("parsed request paths", "paths", paths)
+
+		for _, handle := range proxy.handlers {
+			handle(paths...)
+		}
 	}
 
 	return nil
@@ -277,8 +307,9 @@ func (proxy *PathInterceptor) Close() error {
 	return proxy.listener.Close()
 }
 
-// parseRPCRequest unmarshals and processes RPC requests, returning paths.
-func parseRPCRequest(body []byte, upaths uniqPaths) error {
+// parseRPCRequest unmarshals and processes RPC requests, returning paths and
+// addresses.
+func parseRPCRequest(body []byte, upaths uniqPaths, uaddrs uniqAddrs) error {
 	var req rpctypes.RPCRequest
 	if err := json.Unmarshal(body, &req); err != nil {
 		return fmt.Errorf("unable to unmarshal RPC request: %w", err)
@@ -294,7 +325,7 @@ func parseRPCRequest(body []byte, upaths uniqPaths) error {
 			return fmt.Errorf("unable to unmarshal params: %w", err)
 		}
 
-		return handleQuery(squery.Path, squery.Data, upaths)
+		return handleQuery(squery.Path, squery.Data, upaths, uaddrs)
 
 	case "broadcast_tx_commit":
 		var stx struct {
@@ -304,19 +335,24 @@ func parseRPCRequest(body []byte, upaths uniqPaths) error {
 			return fmt.Errorf("unable to unmarshal params: %w", err)
 		}
 
-		return handleTx(stx.Tx, upaths)
+		return handleTx(stx.Tx, upaths, uaddrs)
 	}
 
 	return fmt.Errorf("unhandled method: %q", req.Method)
 }
 
-// handleTx processes the transaction and returns relevant paths.
-func handleTx(bz []byte, upaths uniqPaths) error {
+// handleTx processes the transaction and returns relevant paths and the
+// addresses of its signers.
+func handleTx(bz []byte, upaths uniqPaths, uaddrs uniqAddrs) error {
 	var tx std.Tx
 	if err := amino.Unmarshal(bz, &tx); err != nil {
 		return fmt.Errorf("unable to unmarshal tx: %w", err)
 	}
 
+	for _, signer := range tx.GetSigners() {
+		uaddrs[signer] = struct{}{}
+	}
+
 	for _, msg := range tx.Msgs {
 		switch msg := msg.(type) {
 		case vm.MsgAddPackage:
@@ -337,22 +373,34 @@ func handleTx(bz []byte, upaths uniqPaths) error {
 	return nil
 }
 
-// handleQuery processes the query and returns relevant paths.
-func handleQuery(path string, data []byte, upaths uniqPaths) error {
+// handleQuery processes the query and returns relevant paths and addresses.
+func handleQuery(path string, data []byte, upaths uniqPaths, uaddrs uniqAddrs) error {
 	switch path {
 	case ".app/simulate":
-		return handleTx(data, upaths)
+		return handleTx(data, upaths, uaddrs)
 
 	case "vm/qrender", "vm/qfile", "vm/qfuncs", "vm/qeval":
 		path, _, _ := strings.Cut(string(data), ":") // Cut arguments out
 		upaths.addPath(path)
 		return nil
 
-	default:
-		return fmt.Errorf("unhandled: %q", path)
+	}
+
+	// Account queries, e.g. "auth/accounts/<address>".
+	for _, prefix := range []string{"auth/accounts/", "bank/balances/"} {
+		if b32addr, ok := strings.CutPrefix(path, prefix); ok {
+			addr, err := crypto.AddressFromBech32(b32addr)
+			if err != nil {
+				return fmt.Errorf("invalid address %q: %w", b32addr, err)
+			}
+
+			uaddrs[addr] = struct{}{}
+			return nil
+		}
 	}
 
 	// XXX: handle more cases
+	return fmt.Errorf("unhandled: %q", path)
 }
 
 func cleanupPath(path string) string {
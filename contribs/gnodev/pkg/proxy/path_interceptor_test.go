@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"path"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -77,6 +79,23 @@ func Incr(cur realm) {
 		pathChan <- paths
 	})
 
+	var (
+		muAddrs sync.Mutex
+		addrs   = map[crypto.Address]bool{}
+	)
+	interceptor.HandleAddress(func(as ...crypto.Address) {
+		muAddrs.Lock()
+		defer muAddrs.Unlock()
+		for _, addr := range as {
+			addrs[addr] = true
+		}
+	})
+	hasAddr := func(addr crypto.Address) bool {
+		muAddrs.Lock()
+		defer muAddrs.Unlock()
+		return addrs[addr]
+	}
+
 	var seq uint64
 
 	t.Run("valid_vm_query", func(t *testing.T) {
@@ -154,6 +173,8 @@ func Incr(cur realm) {
 		default:
 			t.Fatal("paths not captured")
 		}
+
+		assert.True(t, hasAddr(creator), "signer not captured")
 	})
 
 	t.Run("add_pkg", func(t *testing.T) {
@@ -249,4 +270,18 @@ func Render(_ string) string { return foo.Render("bar") }`,
 		default:
 		}
 	})
+
+	t.Run("account_query", func(t *testing.T) {
+		other := secp256k1.GenPrivKey().PubKey().Address()
+
+		cli, err := client.NewHTTPClient(interceptor.TargetAddress())
+		require.NoError(t, err)
+		defer cli.Close()
+
+		res, err := cli.ABCIQuery(context.Background(), "bank/balances/"+other.String(), []byte{})
+		require.NoError(t, err)
+		require.NoError(t, res.Response.Error)
+
+		assert.True(t, hasAddr(other), "queried address not captured")
+	})
 }
@@ -19,6 +19,11 @@ const (
 	KeyCtrlS KeyPress = '\x13' // Ctrl+S
 	KeyCtrlT KeyPress = '\x14' // Ctrl+T
 
+	KeyEnter     KeyPress = '\r'   // Enter
+	KeyEscape    KeyPress = '\x1b' // Escape
+	KeyBackspace KeyPress = '\x7f' // Backspace
+	KeyColon     KeyPress = ':'
+
 	KeyA KeyPress = 'A'
 	KeyE KeyPress = 'E'
 	KeyH KeyPress = 'H'
@@ -58,6 +63,12 @@ func (k KeyPress) String() string {
 		return "Ctrl+S"
 	case KeyCtrlT:
 		return "Ctrl+T"
+	case KeyEnter:
+		return "Enter"
+	case KeyEscape:
+		return "Escape"
+	case KeyBackspace:
+		return "Backspace"
 	case KeyUp:
 		return "Up Arrow"
 	case KeyDown:
@@ -2,6 +2,7 @@ package rawterm
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,6 +13,8 @@ import (
 
 var CRLF = []byte{'\r', '\n'}
 
+var ErrLineCanceled = errors.New("line canceled")
+
 // rawTerminal wraps an io.Writer, converting \n to \r\n
 type RawTerm struct {
 	syncWriter sync.Mutex
@@ -81,6 +84,41 @@ func (rt *RawTerm) ReadKeyPress() (KeyPress, error) {
 	return KeyNone, fmt.Errorf("unknown key sequence: %v", buf[:n])
 }
 
+// ReadLine writes the given prompt and reads a line, echoing the typed
+// characters, until Enter is pressed. Escape and Ctrl+C cancel the line and
+// return ErrLineCanceled.
+func (rt *RawTerm) ReadLine(prompt string) (string, error) {
+	rt.Write([]byte(prompt))
+
+	var line []byte
+	buf := make([]byte, 64)
+	for {
+		n, err := rt.read(buf)
+		if err != nil {
+			return "", err
+		}
+
+		for _, c := range buf[:n] {
+			switch key := KeyPress(c); {
+			case key == KeyEnter || key == '\n':
+				rt.Write([]byte{'\n'})
+				return string(line), nil
+			case key == KeyEscape || key == KeyCtrlC:
+				rt.Write([]byte{'\n'})
+				return "", ErrLineCanceled
+			case key == KeyBackspace || key == '\b':
+				if len(line) > 0 {
+					line = line[:len(line)-1]
+					rt.Write([]byte("\b \b"))
+				}
+			case c >= 0x20 && c < 0x7f: // printable ASCII characters
+				line = append(line, c)
+				rt.Write([]byte{c})
+			}
+		}
+	}
+}
+
 // writeWithCRLF writes buf to w but replaces all occurrences of \n with \r\n.
 func writeWithCRLF(w io.Writer, buf []byte) (n int, err error) {
 	for len(buf) > 0 {
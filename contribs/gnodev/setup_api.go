@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+
+	"github.com/gnolang/gno/contribs/gnodev/pkg/api"
+	gnodev "github.com/gnolang/gno/contribs/gnodev/pkg/dev"
+)
+
+// apiNode is the node controlled by the control API, which resets the package
+// paths along with the node, like the interactive mode.
+type apiNode struct {
+	*gnodev.Node
+	app *App
+}
+
+func (n apiNode) Reset(ctx context.Context) error {
+	return n.app.resetNode(ctx)
+}
+
+// setupAPIServer initializes the control API server, with a random token if
+// none is configured.
+func setupAPIServer(logger *slog.Logger, cfg *AppConfig, app *App) (*api.Server, error) {
+	token := cfg.apiToken
+	if token == "" {
+		var bz [16]byte
+		if _, err := rand.Read(bz[:]); err != nil {
+			return nil, fmt.Errorf("unable to generate api token: %w", err)
+		}
+		token = hex.EncodeToString(bz[:])
+	}
+
+	logger.Info("control API enabled",
+		"url", fmt.Sprintf("http://%s/_api", cfg.webListenerAddr),
+		"token", token,
+	)
+
+	return api.NewServer(logger, apiNode{Node: app.devNode, app: app}, token), nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+
+	gnodev "github.com/gnolang/gno/contribs/gnodev/pkg/dev"
+	"github.com/gnolang/gno/contribs/gnodev/pkg/packages"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// forkSource is the chain forked with `-fork`, either through the RPC of one
+// of its nodes, or from a tx-archive backup of its transactions.
+type forkSource struct {
+	resolver packages.Resolver
+	client   *client.RPCClient        // set when forking a node
+	height   int64                    // height of the forked node
+	txs      []gnoland.TxWithMetadata // set when forking a backup
+}
+
+// setupFork sets up the fork of the chain at location, which is either the
+// path of a tx-archive backup file, or the RPC address of a node.
+func setupFork(ctx context.Context, location string) (*forkSource, error) {
+	if _, err := os.Stat(location); err == nil {
+		txs, err := gnoland.ReadGenesisTxs(ctx, location)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load fork backup %q: %w", location, err)
+		}
+
+		return &forkSource{
+			resolver: packages.NewArchiveResolver(location, txs),
+			txs:      txs,
+		}, nil
+	}
+
+	cl, err := client.NewHTTPClient(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fork remote %q: %w", location, err)
+	}
+
+	// Packages and balances are loaded on first use, all at the same height.
+	height, err := gnodev.ForkHeight(ctx, cl)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get fork remote %q height: %w", location, err)
+	}
+
+	return &forkSource{
+		resolver: packages.NewForkResolver(location, cl, height),
+		client:   cl,
+		height:   height,
+	}, nil
+}
+
+// forkResolvers returns the resolver of the forked chain, if any. It should be
+// chained before the examples, so that the forked version of a package is
+// used, but after the local packages, which can then replace forked ones.
+func (c *AppConfig) forkResolvers() []packages.Resolver {
+	if c.forkSource == nil {
+		return nil
+	}
+
+	return []packages.Resolver{c.forkSource.resolver}
+}
+
+// forkAccounts funds the accounts of the node with their balance on the
+// forked node, the first time they are used by a query or a transaction, so
+// that the balances of the whole forked chain aren't fetched at startup.
+type forkAccounts struct {
+	logger *slog.Logger
+	client *client.RPCClient
+	height int64
+
+	mu   sync.Mutex
+	seen map[crypto.Address]struct{} // accounts already funded or checked
+}
+
+// newForkAccounts returns the forkAccounts of the forked node, if any. The
+// given balances take precedence over the forked ones.
+func newForkAccounts(logger *slog.Logger, cfg *AppConfig, balances gnoland.Balances) *forkAccounts {
+	if cfg.forkSource == nil || cfg.forkSource.client == nil {
+		return nil
+	}
+
+	seen := make(map[crypto.Address]struct{}, len(balances))
+	for addr := range balances {
+		seen[addr] = struct{}{}
+	}
+
+	return &forkAccounts{
+		logger: logger,
+		client: cfg.forkSource.client,
+		height: cfg.forkSource.height,
+		seen:   seen,
+	}
+}
+
+// fund adds the forked balances of the accounts which weren't seen yet to the
+// node.
+func (fa *forkAccounts) fund(ctx context.Context, node *gnodev.Node, addrs ...crypto.Address) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+
+	var balances []gnoland.Balance
+	for _, addr := range addrs {
+		if _, ok := fa.seen[addr]; ok {
+			continue
+		}
+
+		coins, err := gnodev.ForkBalance(ctx, fa.client, addr, fa.height)
+		if err != nil {
+			// Not marked as seen, so that it's fetched again on next use.
+			fa.logger.Error("unable to fetch fork balance", "addr", addr, "error", err)
+			continue
+		}
+
+		fa.seen[addr] = struct{}{}
+		if coins.IsZero() {
+			continue
+		}
+
+		balances = append(balances, gnoland.Balance{Address: addr, Amount: coins})
+	}
+
+	if len(balances) == 0 {
+		return
+	}
+
+	if err := node.AddAccounts(ctx, balances...); err != nil {
+		fa.logger.Error("unable to add fork balances", "error", err)
+		for _, balance := range balances {
+			delete(fa.seen, balance.Address)
+		}
+		return
+	}
+
+	for _, balance := range balances {
+		fa.logger.Info("fork balance loaded", "addr", balance.Address, "amount", balance.Amount)
+	}
+}
+
+// forkTxs returns the transactions of the forked backup, to be replayed by the
+// node. The ones deploying packages are skipped, as the packages are loaded
+// like the other ones, so that they can be replaced by local packages.
+func forkTxs(cfg *AppConfig) []gnoland.TxWithMetadata {
+	if cfg.forkSource == nil {
+		return nil
+	}
+
+	return slices.DeleteFunc(slices.Clone(cfg.forkSource.txs), func(tx gnoland.TxWithMetadata) bool {
+		return slices.ContainsFunc(tx.Tx.Msgs, func(msg std.Msg) bool {
+			_, ok := msg.(vm.MsgAddPackage)
+			return ok
+		})
+	})
+}
@@ -79,7 +79,9 @@ func setupPackagesResolver(logger *slog.Logger, cfg *AppConfig, dirs ...string)
 	// Enrich resolver with middleware
 	return packages.MiddlewareResolver(resolver,
 		packages.CacheMiddleware(func(pkg *packages.Package) bool {
-			return pkg.Kind == packages.PackageKindRemote // Only cache remote package
+			// Only cache remote and forked packages, so that the state of a
+			// forked package is only pulled on first use.
+			return pkg.Kind == packages.PackageKindRemote || pkg.Kind == packages.PackageKindFork
 		}),
 		packages.FilterStdlibs,                    // Filter stdlib package from resolving
 		packages.PackageCheckerMiddleware(logger), // Pre-check syntax to avoid bothering the node reloading on invalid files
@@ -83,6 +83,13 @@ func setupDevNode(ctx context.Context, cfg *AppConfig, nodeConfig *gnodev.NodeCo
 		logger.Info("genesis file loaded", "path", cfg.genesisFile, "txs", len(stateTxs))
 	}
 
+	if txs := forkTxs(cfg); len(txs) > 0 { // Replay the forked backup first
+		nodeConfig.InitialTxs = append(txs, nodeConfig.InitialTxs...)
+		extractDependenciesFromTxs(nodeConfig, &paths)
+
+		logger.Info("fork backup loaded", "path", cfg.fork, "txs", len(txs))
+	}
+
 	if len(paths) > 0 {
 		logger.Info("packages", "paths", paths)
 	} else {
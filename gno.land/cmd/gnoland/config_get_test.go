@@ -785,6 +785,30 @@ func TestConfig_Get_RPC(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"max subscription clients",
+			"rpc.max_subscription_clients",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.MaxSubscriptionClients, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
+		{
+			"max subscriptions per client",
+			"rpc.max_subscriptions_per_client",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.MaxSubscriptionsPerClient, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
+		{
+			"subscription buffer size",
+			"rpc.subscription_buffer_size",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.SubscriptionBufferSize, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
 		{
 			"max header bytes",
 			"rpc.max_header_bytes",
@@ -850,6 +874,14 @@ func TestConfig_Get_Mempool(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"mempool type",
+			"mempool.type",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.Mempool.Type, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
 		{
 			"recheck flag",
 			"mempool.recheck",
@@ -906,6 +938,22 @@ func TestConfig_Get_Mempool(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"price bump",
+			"mempool.price_bump",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.Mempool.PriceBump, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
+		{
+			"max sender txs",
+			"mempool.max_sender_txs",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.Mempool.MaxSenderTxs, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
 	}
 
 	verifyGetTestTableCommon(t, testTable)
@@ -689,6 +689,36 @@ func TestConfig_Set_RPC(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxBodyBytes))
 			},
 		},
+		{
+			"max subscription clients updated",
+			[]string{
+				"rpc.max_subscription_clients",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionClients))
+			},
+		},
+		{
+			"max subscriptions per client updated",
+			[]string{
+				"rpc.max_subscriptions_per_client",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionsPerClient))
+			},
+		},
+		{
+			"subscription buffer size updated",
+			[]string{
+				"rpc.subscription_buffer_size",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.SubscriptionBufferSize))
+			},
+		},
 		{
 			"max header bytes updated",
 			[]string{
@@ -738,6 +768,16 @@ func TestConfig_Set_Mempool(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.Mempool.RootDir)
 			},
 		},
+		{
+			"mempool type updated",
+			[]string{
+				"mempool.type",
+				"priority",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
 		{
 			"recheck flag updated",
 			[]string{
@@ -804,6 +844,26 @@ func TestConfig_Set_Mempool(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.CacheSize))
 			},
 		},
+		{
+			"price bump updated",
+			[]string{
+				"mempool.price_bump",
+				"25",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.PriceBump))
+			},
+		},
+		{
+			"max sender txs updated",
+			[]string{
+				"mempool.max_sender_txs",
+				"16",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.MaxSenderTxs))
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+type exportCfg struct {
+	dataDir    string
+	outputPath string
+	height     int64
+
+	genesisFile                string
+	skipFailingGenesisTxs      bool
+	skipGenesisSigVerification bool
+}
+
+// newExportCmd creates the export command
+func newExportCmd(io commands.IO) *commands.Command {
+	cfg := &exportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "export",
+			ShortUsage: "export [flags]",
+			ShortHelp:  "exports the node state as a genesis app state",
+			LongHelp: "Exports the state of a stopped node, read from its data directory, " +
+				"as a genesis app state: the account balances, the params, and the deployed " +
+				"packages with their realm state. It can be used as the app_state of " +
+				"the genesis.json of a new chain. By default, the latest committed height is " +
+				"exported. The realm objects are kept in a store which isn't versioned, so " +
+				"a previous height is exported by replaying the blocks of the node, from " +
+				"its genesis.json up to --height, on a temporary database.",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execExport(cfg, io)
+		},
+	)
+}
+
+func (c *exportCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.StringVar(
+		&c.outputPath,
+		"output",
+		"",
+		"the output path for the exported app state (stdout if empty)",
+	)
+
+	fs.Int64Var(
+		&c.height,
+		"height",
+		0,
+		"the height to export, replaying the blocks up to it (latest height if 0)",
+	)
+
+	fs.StringVar(
+		&c.genesisFile,
+		"genesis",
+		"genesis.json",
+		"the path to the genesis.json, used with --height",
+	)
+
+	fs.BoolVar(
+		&c.skipFailingGenesisTxs,
+		"skip-failing-genesis-txs",
+		false,
+		"don't panic when replaying invalid genesis txs, used with --height",
+	)
+
+	fs.BoolVar(
+		&c.skipGenesisSigVerification,
+		"skip-genesis-sig-verification",
+		false,
+		"don't panic when replaying invalidly signed genesis txs, used with --height",
+	)
+}
+
+func execExport(cfg *exportCfg, io commands.IO) error {
+	if cfg.height < 0 {
+		return fmt.Errorf("invalid height %d", cfg.height)
+	}
+
+	dbDir := filepath.Join(cfg.dataDir, config.DefaultDBDir)
+	if !osm.DirExists(dbDir) {
+		return fmt.Errorf("no node database found at %q", dbDir)
+	}
+
+	var db dbm.DB
+	if cfg.height == 0 {
+		nodeDB, err := dbm.NewDB("gnolang", dbm.PebbleDBBackend, dbDir)
+		if err != nil {
+			return fmt.Errorf("unable to open the node database, %w", err)
+		}
+		defer nodeDB.Close()
+		db = nodeDB
+	} else {
+		tmpDir, err := os.MkdirTemp("", "gnoland-export")
+		if err != nil {
+			return fmt.Errorf("unable to create the replay directory, %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		replayDB, err := dbm.NewDB("gnolang", dbm.PebbleDBBackend, tmpDir)
+		if err != nil {
+			return fmt.Errorf("unable to open the replay database, %w", err)
+		}
+		defer replayDB.Close()
+
+		if err := replayNodeBlocks(cfg, replayDB); err != nil {
+			return err
+		}
+		db = replayDB
+	}
+
+	state, height, err := gnoland.ExportGenesisState(db, log.NewNoopLogger())
+	if err != nil {
+		return fmt.Errorf("unable to export the node state, %w", err)
+	}
+
+	bz, err := amino.MarshalJSONIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal the exported state, %w", err)
+	}
+
+	if cfg.outputPath == "" {
+		io.Println(string(bz))
+
+		return nil
+	}
+
+	if err := os.WriteFile(cfg.outputPath, bz, 0o644); err != nil {
+		return fmt.Errorf("unable to write the exported state, %w", err)
+	}
+
+	io.Printfln("Exported the state at height %d to %s", height, cfg.outputPath)
+
+	return nil
+}
+
+// replayNodeBlocks replays the blocks of the node in cfg.dataDir, up to
+// cfg.height, on db.
+func replayNodeBlocks(cfg *exportCfg, db dbm.DB) error {
+	nodeCfg, err := config.LoadConfig(cfg.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to load the node config, %w", err)
+	}
+
+	genDoc, err := bft.GenesisDocFromFile(cfg.genesisFile)
+	if err != nil {
+		return fmt.Errorf("unable to load the genesis.json, %w", err)
+	}
+
+	backend := dbm.BackendType(nodeCfg.DBBackend)
+	blockStoreDB, err := dbm.NewDB("blockstore", backend, nodeCfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the block store, %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := dbm.NewDB("state", backend, nodeCfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the state database, %w", err)
+	}
+	defer stateDB.Close()
+
+	if err := gnoland.ReplayBlocks(
+		db,
+		genDoc,
+		store.NewBlockStore(blockStoreDB),
+		stateDB,
+		cfg.height,
+		gnoland.GenesisAppConfig{
+			SkipFailingTxs:      cfg.skipFailingGenesisTxs,
+			SkipSigVerification: cfg.skipGenesisSigVerification,
+		},
+		log.NewNoopLogger(),
+	); err != nil {
+		return fmt.Errorf("unable to replay the blocks, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// initNodeDB initializes the database of a node in dataDir, with the given
+// balance, and commits the genesis.
+func initNodeDB(t *testing.T, dataDir string, balance gnoland.Balance) {
+	t.Helper()
+
+	db, err := dbm.NewDB("gnolang", dbm.PebbleDBBackend, filepath.Join(dataDir, config.DefaultDBDir))
+	require.NoError(t, err)
+	defer db.Close()
+
+	app, err := gnoland.NewAppWithOptions(gnoland.TestAppOptions(db))
+	require.NoError(t, err)
+
+	appState := gnoland.DefaultGenState()
+	appState.Balances = []gnoland.Balance{balance}
+	resp := app.InitChain(abci.RequestInitChain{
+		Time:     time.Now(),
+		ChainID:  "dev",
+		AppState: appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+	app.Commit()
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing database", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"export",
+			"--data-dir",
+			t.TempDir(),
+		}
+
+		assert.ErrorContains(t, cmd.ParseAndRun(context.Background(), args), "no node database found")
+	})
+
+	t.Run("invalid height", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"export",
+			"--data-dir",
+			t.TempDir(),
+			"--height",
+			"-1",
+		}
+
+		assert.ErrorContains(t, cmd.ParseAndRun(context.Background(), args), "invalid height -1")
+	})
+
+	t.Run("height without node config", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir := t.TempDir()
+		initNodeDB(t, dataDir, gnoland.Balance{
+			Address: crypto.AddressFromPreimage([]byte("test1")),
+			Amount:  std.MustParseCoins("1000ugnot"),
+		})
+
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"export",
+			"--data-dir",
+			dataDir,
+			"--height",
+			"1",
+		}
+
+		assert.ErrorContains(t, cmd.ParseAndRun(context.Background(), args), "unable to load the node config")
+	})
+
+	t.Run("valid export", func(t *testing.T) {
+		t.Parallel()
+
+		balance := gnoland.Balance{
+			Address: crypto.AddressFromPreimage([]byte("test1")),
+			Amount:  std.MustParseCoins("1000ugnot"),
+		}
+
+		dataDir := t.TempDir()
+		initNodeDB(t, dataDir, balance)
+
+		outputPath := filepath.Join(t.TempDir(), "app_state.json")
+
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"export",
+			"--data-dir",
+			dataDir,
+			"--output",
+			outputPath,
+		}
+
+		require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+		bz, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+
+		var state gnoland.GnoGenesisState
+		require.NoError(t, amino.UnmarshalJSON(bz, &state))
+
+		assert.Equal(t, []gnoland.Balance{balance}, state.Balances)
+		assert.Equal(t, gnoland.DefaultGenState().Auth.Params, state.Auth.Params)
+		assert.Empty(t, state.VM.Packages)
+	})
+}
@@ -38,6 +38,7 @@ func newRootCmd(io commands.IO) *commands.Command {
 		newStartCmd(io),
 		newSecretsCmd(io),
 		newConfigCmd(io),
+		newExportCmd(io),
 	)
 
 	return cmd
@@ -54,6 +54,7 @@ type webCfg struct {
 	noCache          bool
 	timeout          time.Duration
 	analytics        bool
+	history          bool
 	json             bool
 	html             bool
 	noStrict         bool
@@ -177,6 +178,13 @@ func (c *webCfg) RegisterFlags(fs *flag.FlagSet) {
 		"enable privacy-first analytics",
 	)
 
+	fs.BoolVar(
+		&c.history,
+		"with-history",
+		defaultWebOptions.history,
+		"enable the history view of the realms, requires the \"kv\" event store on the remote node",
+	)
+
 	fs.BoolVar(
 		&c.noStrict,
 		"no-strict",
@@ -232,6 +240,7 @@ func setupWeb(cfg *webCfg, _ []string, io commands.IO) (func() error, error) {
 		appcfg.RemoteHelp = appcfg.NodeRemote
 	}
 	appcfg.Analytics = cfg.analytics
+	appcfg.History = cfg.history
 	appcfg.UnsafeHTML = cfg.html
 	appcfg.FaucetURL = cfg.faucetURL
 
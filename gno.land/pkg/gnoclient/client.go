@@ -1,13 +1,25 @@
 package gnoclient
 
 import (
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 )
 
 // Client provides an interface for interacting with the blockchain.
+//
+// When LightClient is set, the accounts returned by QueryAccount are verified
+// with merkle proofs of the app state against the latest header verified by
+// the light client, so that the RPC node doesn't have to be trusted.
+//
+// The results of Render and QEval are computed by the node from the gno
+// store, which isn't covered by the app hash, and can't be verified.
+// RenderCrossChecked and QEvalCrossChecked only cross-check them with all the
+// Witnesses, and accept them if the RPC node and the witnesses agree.
 type Client struct {
-	Signer    Signer           // Signer for transaction authentication
-	RPCClient rpcclient.Client // RPC client for blockchain communication
+	Signer      Signer             // Signer for transaction authentication
+	RPCClient   rpcclient.Client   // RPC client for blockchain communication
+	LightClient *light.Client      // Light client verifying the accounts (optional)
+	Witnesses   []rpcclient.Client // RPC clients cross-checking the computed results (optional)
 }
 
 // validateSigner checks that the signer is correctly configured.
@@ -0,0 +1,152 @@
+package gnoclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var ErrSubscriptionsUnsupported = errors.New("RPCClient doesn't support subscriptions, use a WebSocket client")
+
+// EventFilter selects the Gno events to subscribe to. Empty fields match any
+// value.
+type EventFilter struct {
+	PkgPath string // Path of the realm emitting the events
+	Type    string // Type of the events
+}
+
+// query returns the subscription query of the txs emitting the events
+// matching f.
+func (f EventFilter) query() string {
+	conds := []string{"tm.event = 'Tx'"}
+	if f.PkgPath != "" {
+		conds = append(conds, fmt.Sprintf("event.pkg_path = '%s'", quoteQuery(f.PkgPath)))
+	}
+	if f.Type != "" {
+		conds = append(conds, fmt.Sprintf("event.type = '%s'", quoteQuery(f.Type)))
+	}
+	return strings.Join(conds, " AND ")
+}
+
+func (f EventFilter) matches(ev gnostd.GnoEvent) bool {
+	return (f.PkgPath == "" || ev.PkgPath == f.PkgPath) &&
+		(f.Type == "" || ev.Type == f.Type)
+}
+
+func quoteQuery(s string) string {
+	return strings.ReplaceAll(s, "'", `\'`)
+}
+
+// Event is a Gno event emitted by a committed transaction.
+type Event struct {
+	gnostd.GnoEvent
+	Height int64  // Height of the block including the transaction
+	TxHash []byte // Hash of the transaction
+}
+
+// SubscribeBlocks returns the new blocks, as they are committed, until ctx is
+// done. The RPCClient must be a WebSocket client.
+//
+// If the node cancels the subscription, e.g. because the blocks are not read
+// fast enough, the channel is closed before ctx is done.
+func (c *Client) SubscribeBlocks(ctx context.Context) (<-chan types.EventNewBlock, error) {
+	return subscribe(ctx, c, "tm.event = 'NewBlock'", func(ev ctypes.ResultEvent) []types.EventNewBlock {
+		block, ok := ev.Event.(types.EventNewBlock)
+		if !ok {
+			return nil
+		}
+		return []types.EventNewBlock{block}
+	})
+}
+
+// SubscribeTxs returns the results of the transactions, as they are
+// committed, until ctx is done. See SubscribeBlocks.
+func (c *Client) SubscribeTxs(ctx context.Context) (<-chan types.TxResult, error) {
+	return subscribe(ctx, c, "tm.event = 'Tx'", func(ev ctypes.ResultEvent) []types.TxResult {
+		tx, ok := ev.Event.(types.EventTx)
+		if !ok {
+			return nil
+		}
+		return []types.TxResult{tx.Result}
+	})
+}
+
+// SubscribeEvents returns the Gno events matching filter, as the transactions
+// emitting them are committed, until ctx is done. See SubscribeBlocks.
+func (c *Client) SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan Event, error) {
+	return subscribe(ctx, c, filter.query(), func(ev ctypes.ResultEvent) []Event {
+		tx, ok := ev.Event.(types.EventTx)
+		if !ok {
+			return nil
+		}
+
+		var events []Event
+		for _, abciEv := range tx.Result.Response.Events {
+			gnoEv, ok := abciEv.(gnostd.GnoEvent)
+			if !ok || !filter.matches(gnoEv) {
+				continue
+			}
+			events = append(events, Event{
+				GnoEvent: gnoEv,
+				Height:   tx.Result.Height,
+				TxHash:   tx.Result.Tx.Hash(),
+			})
+		}
+		return events
+	})
+}
+
+// subscribe subscribes to query, and sends the values extracted from its
+// events to the returned channel until ctx is done.
+func subscribe[T any](
+	ctx context.Context,
+	c *Client,
+	query string,
+	extract func(ctypes.ResultEvent) []T,
+) (<-chan T, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+	ec, ok := c.RPCClient.(rpcclient.EventsClient)
+	if !ok {
+		return nil, ErrSubscriptionsUnsupported
+	}
+
+	sub, err := ec.Subscribe(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "subscribe")
+	}
+
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				ec.Unsubscribe(context.Background(), query) //nolint:errcheck
+				return
+			case ev, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				for _, v := range extract(ev) {
+					select {
+					case ch <- v:
+					case <-ctx.Done():
+						ec.Unsubscribe(context.Background(), query) //nolint:errcheck
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
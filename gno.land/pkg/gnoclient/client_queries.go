@@ -9,6 +9,7 @@ import (
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -39,10 +40,14 @@ func (c *Client) Query(cfg QueryCfg) (*ctypes.ResultABCIQuery, error) {
 }
 
 // QueryAccount retrieves account information for a given address.
+// With a LightClient, the account is verified with a merkle proof.
 func (c *Client) QueryAccount(addr crypto.Address) (*std.BaseAccount, *ctypes.ResultABCIQuery, error) {
 	if err := c.validateRPCClient(); err != nil {
 		return nil, nil, err
 	}
+	if c.LightClient != nil {
+		return c.queryAccountVerified(addr)
+	}
 
 	path := fmt.Sprintf("auth/accounts/%s", crypto.AddressToBech32(addr))
 	data := []byte{}
@@ -82,10 +87,39 @@ func (c *Client) QueryAppVersion() (string, *ctypes.ResultABCIQuery, error) {
 	return version, qres, nil
 }
 
+// SuggestGasPrice retrieves the gas prices suggested by the node, from the fees
+// paid in the recent blocks. The denom may be empty, for the chain's default.
+func (c *Client) SuggestGasPrice(denom string) (auth.GasPriceSuggestion, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return auth.GasPriceSuggestion{}, err
+	}
+
+	path := "auth/" + auth.QuerySuggestedGasPrice
+
+	qres, err := c.RPCClient.ABCIQuery(context.Background(), path, []byte(denom))
+	if err != nil {
+		return auth.GasPriceSuggestion{}, errors.Wrap(err, "query gas price")
+	}
+	if qres.Response.Error != nil {
+		return auth.GasPriceSuggestion{}, errors.Wrapf(qres.Response.Error, "query gas price failed: log:%s", qres.Response.Log)
+	}
+
+	var suggestion auth.GasPriceSuggestion
+	if err := amino.UnmarshalJSON(qres.Response.Data, &suggestion); err != nil {
+		return auth.GasPriceSuggestion{}, err
+	}
+
+	return suggestion, nil
+}
+
 // Render calls the Render function for pkgPath with optional args. The pkgPath should
 // include the prefix like "gno.land/". This is similar to using a browser URL
 // <testnet>/<pkgPath>:<args> where <pkgPath> doesn't have the prefix like "gno.land/".
 func (c *Client) Render(pkgPath string, args string) (string, *ctypes.ResultABCIQuery, error) {
+	return c.render(pkgPath, args, c.query)
+}
+
+func (c *Client) render(pkgPath string, args string, query queryFunc) (string, *ctypes.ResultABCIQuery, error) {
 	if err := c.validateRPCClient(); err != nil {
 		return "", nil, err
 	}
@@ -93,7 +127,7 @@ func (c *Client) Render(pkgPath string, args string) (string, *ctypes.ResultABCI
 	path := "vm/qrender"
 	data := fmt.Appendf(nil, "%s:%s", pkgPath, args)
 
-	qres, err := c.RPCClient.ABCIQuery(context.Background(), path, data)
+	qres, err := query(path, data)
 	if err != nil {
 		return "", nil, errors.Wrap(err, "query render")
 	}
@@ -109,6 +143,10 @@ func (c *Client) Render(pkgPath string, args string) (string, *ctypes.ResultABCI
 // "GetBoardIDFromName(\"testboard\")". The return value is a typed expression like
 // "(1 gno.land/r/archive/boards.BoardID)\n(true bool)".
 func (c *Client) QEval(pkgPath string, expression string) (string, *ctypes.ResultABCIQuery, error) {
+	return c.qeval(pkgPath, expression, c.query)
+}
+
+func (c *Client) qeval(pkgPath string, expression string, query queryFunc) (string, *ctypes.ResultABCIQuery, error) {
 	if err := c.validateRPCClient(); err != nil {
 		return "", nil, err
 	}
@@ -116,7 +154,7 @@ func (c *Client) QEval(pkgPath string, expression string) (string, *ctypes.Resul
 	path := "vm/qeval"
 	data := fmt.Appendf(nil, "%s.%s", pkgPath, expression)
 
-	qres, err := c.RPCClient.ABCIQuery(context.Background(), path, data)
+	qres, err := query(path, data)
 	if err != nil {
 		return "", nil, errors.Wrap(err, "query qeval")
 	}
@@ -127,6 +165,14 @@ func (c *Client) QEval(pkgPath string, expression string) (string, *ctypes.Resul
 	return string(qres.Response.Data), qres, nil
 }
 
+// queryFunc performs the ABCI query of path with data.
+type queryFunc func(path string, data []byte) (*ctypes.ResultABCIQuery, error)
+
+// query performs the ABCI query of path with data on the RPC node.
+func (c *Client) query(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+	return c.RPCClient.ABCIQuery(context.Background(), path, data)
+}
+
 // Block gets the latest block at height, if any
 // Height must be larger than 0
 func (c *Client) Block(height int64) (*ctypes.ResultBlock, error) {
@@ -3,6 +3,7 @@ package gnoclient
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -17,6 +18,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -248,8 +250,8 @@ func TestCallErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -440,8 +442,8 @@ func TestClient_Send_Errors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -816,8 +818,8 @@ func TestRunErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -1015,8 +1017,8 @@ func TestAddPackageErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -1253,8 +1255,8 @@ func TestBlockErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			height:        1,
 			expectedError: ErrMissingRPCClient,
@@ -1262,8 +1264,8 @@ func TestBlockErrors(t *testing.T) {
 		{
 			name: "Invalid height",
 			client: Client{
-				&mockSigner{},
-				&mockRPCClient{},
+				Signer:    &mockSigner{},
+				RPCClient: &mockRPCClient{},
 			},
 			height:        0,
 			expectedError: ErrInvalidBlockHeight,
@@ -1294,8 +1296,8 @@ func TestBlockResultErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			height:        1,
 			expectedError: ErrMissingRPCClient,
@@ -1303,8 +1305,8 @@ func TestBlockResultErrors(t *testing.T) {
 		{
 			name: "Invalid height",
 			client: Client{
-				&mockSigner{},
-				&mockRPCClient{},
+				Signer:    &mockSigner{},
+				RPCClient: &mockRPCClient{},
 			},
 			height:        0,
 			expectedError: ErrInvalidBlockHeight,
@@ -1334,8 +1336,8 @@ func TestLatestBlockHeightErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			expectedError: ErrMissingRPCClient,
 		},
@@ -1566,3 +1568,102 @@ func TestClient_EstimateGas(t *testing.T) {
 		assert.Equal(t, gasUsed, estimate)
 	})
 }
+
+func TestClient_EstimateFee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing RPC client", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&Client{}).SuggestGasPrice("")
+		assert.ErrorIs(t, err, ErrMissingRPCClient)
+
+		_, err = (&Client{}).EstimateFee(&std.Tx{})
+		assert.ErrorIs(t, err, ErrMissingRPCClient)
+	})
+
+	t.Run("gas price query error", func(t *testing.T) {
+		t.Parallel()
+
+		c := &Client{
+			RPCClient: &mockRPCClient{
+				abciQuery: func(_ context.Context, _ string, _ []byte) (*ctypes.ResultABCIQuery, error) {
+					return &ctypes.ResultABCIQuery{
+						Response: abci.ResponseQuery{
+							ResponseBase: abci.ResponseBase{Error: abciErrors.UnknownError{}},
+						},
+					}, nil
+				},
+			},
+		}
+
+		_, err := c.SuggestGasPrice("")
+		assert.ErrorIs(t, err, abciErrors.UnknownError{})
+	})
+
+	t.Run("valid fee estimate", func(t *testing.T) {
+		t.Parallel()
+
+		suggestion := auth.GasPriceSuggestion{
+			Low:     std.GasPrice{Gas: 1000, Price: std.NewCoin(ugnot.Denom, 1)},
+			Average: std.GasPrice{Gas: 1000, Price: std.NewCoin(ugnot.Denom, 2)},
+			High:    std.GasPrice{Gas: 1000, Price: std.NewCoin(ugnot.Denom, 3)},
+			Samples: 3,
+		}
+		encodedResp, err := amino.Marshal(abci.ResponseDeliverTx{GasUsed: 100_000})
+		require.NoError(t, err)
+
+		c := &Client{
+			RPCClient: &mockRPCClient{
+				abciQuery: func(_ context.Context, path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+					switch path {
+					case simulatePath:
+						return &ctypes.ResultABCIQuery{
+							Response: abci.ResponseQuery{Value: encodedResp},
+						}, nil
+					case "auth/gas_price":
+						assert.Equal(t, ugnot.Denom, string(data))
+						return &ctypes.ResultABCIQuery{
+							Response: abci.ResponseQuery{
+								ResponseBase: abci.ResponseBase{Data: amino.MustMarshalJSON(suggestion)},
+							},
+						}, nil
+					default:
+						return nil, fmt.Errorf("unexpected path %q", path)
+					}
+				},
+			},
+		}
+
+		got, err := c.SuggestGasPrice(ugnot.Denom)
+		require.NoError(t, err)
+		assert.Equal(t, suggestion, got)
+
+		// The gas wanted has a 10% margin, paid at the average price.
+		fee, err := c.EstimateFee(&std.Tx{Fee: std.NewFee(0, std.NewCoin(ugnot.Denom, 0))})
+		require.NoError(t, err)
+		assert.Equal(t, std.NewFee(110_000, std.NewCoin(ugnot.Denom, 220)), fee)
+	})
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Client{}).SubscribeBlocks(context.Background())
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+
+	// HTTP clients can't subscribe
+	client := &Client{RPCClient: &mockRPCClient{}}
+	_, err = client.SubscribeEvents(context.Background(), EventFilter{})
+	assert.ErrorIs(t, err, ErrSubscriptionsUnsupported)
+}
+
+func TestEventFilterQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tm.event = 'Tx'", EventFilter{}.query())
+	assert.Equal(t,
+		`tm.event = 'Tx' AND event.pkg_path = 'gno.land/r/demo/foo' AND event.type = 'It\'s'`,
+		EventFilter{PkgPath: "gno.land/r/demo/foo", Type: "It's"}.query(),
+	)
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -29,6 +31,19 @@ type BaseTxCfg struct {
 	AccountNumber  uint64 // Account number
 	SequenceNumber uint64 // Sequence number
 	Memo           string // Memo
+
+	// FeeGranter, if set, pays the gas fee from the fee allowance it granted
+	// to the signer.
+	FeeGranter crypto.Address
+}
+
+// fee returns the fee of the transaction, paying gasFee.
+func (cfg BaseTxCfg) fee(gasFee std.Coin) std.Fee {
+	fee := std.NewFee(cfg.GasWanted, gasFee)
+	if !cfg.FeeGranter.IsZero() {
+		fee.Granter = cfg.FeeGranter.Bech32()
+	}
+	return fee
 }
 
 // Call executes one or more MsgCall calls on the blockchain
@@ -75,7 +90,7 @@ func NewCallTx(cfg BaseTxCfg, msgs ...vm.MsgCall) (*std.Tx, error) {
 	// Pack transaction
 	return &std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
+		Fee:        cfg.fee(gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}, nil
@@ -125,7 +140,7 @@ func NewRunTx(cfg BaseTxCfg, msgs ...vm.MsgRun) (*std.Tx, error) {
 	// Pack transaction
 	return &std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
+		Fee:        cfg.fee(gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}, nil
@@ -175,7 +190,7 @@ func NewSendTx(cfg BaseTxCfg, msgs ...bank.MsgSend) (*std.Tx, error) {
 	// Pack transaction
 	return &std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
+		Fee:        cfg.fee(gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}, nil
@@ -225,7 +240,7 @@ func NewAddPackageTx(cfg BaseTxCfg, msgs ...vm.MsgAddPackage) (*std.Tx, error) {
 	// Pack transaction
 	return &std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
+		Fee:        cfg.fee(gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}, nil
@@ -300,6 +315,7 @@ func (c *Client) BroadcastTxCommit(signedTx *std.Tx) (*ctypes.ResultBroadcastTxC
 
 // EstimateGas returns the least amount of gas required
 // for the transaction to go through on the chain (minimum gas wanted).
+// The fee granter of the transaction is kept.
 // The estimation process assumes the transaction is properly signed
 func (c *Client) EstimateGas(tx *std.Tx) (int64, error) {
 	// Make sure the RPC client is set
@@ -339,3 +355,27 @@ func (c *Client) EstimateGas(tx *std.Tx) (int64, error) {
 	// for executing the transaction
 	return deliverTx.GasUsed, nil
 }
+
+// EstimateFee returns a complete fee for the transaction: its gas wanted is
+// estimated by simulating it, with a margin, and is paid at the average gas
+// price suggested by the node. The gas price denomination is the one of the
+// transaction's gas fee, if set, and its fee granter is kept.
+// The estimation process assumes the transaction is properly signed
+func (c *Client) EstimateFee(tx *std.Tx) (std.Fee, error) {
+	gasUsed, err := c.EstimateGas(tx)
+	if err != nil {
+		return std.Fee{}, err
+	}
+
+	suggestion, err := c.SuggestGasPrice(tx.Fee.GasFee.Denom)
+	if err != nil {
+		return std.Fee{}, err
+	}
+
+	fee, err := auth.EstimateFee(gasUsed, suggestion.Average)
+	if err != nil {
+		return std.Fee{}, err
+	}
+	fee.Granter = tx.Fee.Granter
+	return fee, nil
+}
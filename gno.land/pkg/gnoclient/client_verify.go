@@ -0,0 +1,141 @@
+package gnoclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	// Registers the account type of gno.land, to decode the proven accounts.
+	_ "github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// mainStoreName is the name of the store holding the accounts.
+const mainStoreName = "main"
+
+var (
+	ErrMissingWitnesses = errors.New("missing witness RPC clients")
+	ErrWitnessMismatch  = errors.New("witness returned a different result")
+	ErrInvalidProof     = errors.New("invalid query proof")
+)
+
+// verifiedHeight returns the height of the state to query, which is the one
+// before the latest header verified by the light client, as the app hash of
+// the state at height H is stored in the header of the block H+1.
+func (c *Client) verifiedHeight(ctx context.Context) (int64, *light.VerifiedHeader, error) {
+	header, err := c.LightClient.VerifyLatest(ctx)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "verify latest header")
+	}
+	height := header.Height - 1
+	if height <= 1 {
+		// Proofs are not available for the genesis state.
+		return 0, nil, fmt.Errorf("no verifiable state at height %d", height)
+	}
+	return height, header, nil
+}
+
+// queryAccountVerified retrieves the account at addr, along with a proof of
+// its value, verified against the latest header verified by the light client.
+func (c *Client) queryAccountVerified(addr crypto.Address) (*std.BaseAccount, *ctypes.ResultABCIQuery, error) {
+	ctx := context.Background()
+
+	height, header, err := c.verifiedHeight(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := auth.AddressStoreKey(addr)
+	qres, err := c.RPCClient.ABCIQueryWithOptions(ctx, ".store/"+mainStoreName+"/key", key, rpcclient.ABCIQueryOptions{
+		Height: height,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query account")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrapf(qres.Response.Error, "query account failed: log:%s", qres.Response.Log)
+	}
+	if qres.Response.Height != height {
+		return nil, nil, fmt.Errorf("%w: expected height %d, got %d", ErrInvalidProof, height, qres.Response.Height)
+	}
+
+	value := qres.Response.Value
+	if len(value) == 0 {
+		if err := light.VerifyAbsence(qres.Response.Proof, header.AppHash, mainStoreName, key); err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+		return nil, nil, std.ErrUnknownAddress("unknown address: " + crypto.AddressToBech32(addr))
+	}
+	if err := light.VerifyValue(qres.Response.Proof, header.AppHash, mainStoreName, key, value); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	var acc std.Account
+	if err := amino.UnmarshalAny(value, &acc); err != nil {
+		return nil, nil, errors.Wrap(err, "decode account")
+	}
+
+	return std.NewBaseAccount(
+		acc.GetAddress(),
+		acc.GetCoins(),
+		acc.GetPubKey(),
+		acc.GetAccountNumber(),
+		acc.GetSequence(),
+	), qres, nil
+}
+
+// RenderCrossChecked is like Render, but the result is cross-checked with
+// the Witnesses, and rejected unless they all return the same result.
+//
+// The result is NOT verified: it is computed from the gno store, which isn't
+// covered by the app hash, so no proof of it can be checked against a header
+// verified by the LightClient. It can only be trusted as much as the RPC node
+// and the witnesses together, and may also be rejected if they are not at the
+// same height.
+func (c *Client) RenderCrossChecked(pkgPath string, args string) (string, *ctypes.ResultABCIQuery, error) {
+	return c.render(pkgPath, args, c.queryCrossChecked)
+}
+
+// QEvalCrossChecked is like QEval, but the result is cross-checked with the
+// Witnesses, and rejected unless they all return the same result. As with
+// RenderCrossChecked, the result is NOT verified.
+func (c *Client) QEvalCrossChecked(pkgPath string, expression string) (string, *ctypes.ResultABCIQuery, error) {
+	return c.qeval(pkgPath, expression, c.queryCrossChecked)
+}
+
+// queryCrossChecked performs the ABCI query of a computed result on the RPC
+// node and on every witness, and returns an error unless they all return the
+// same result.
+func (c *Client) queryCrossChecked(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+	if len(c.Witnesses) == 0 {
+		return nil, ErrMissingWitnesses
+	}
+
+	ctx := context.Background()
+
+	qres, err := c.RPCClient.ABCIQuery(ctx, path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, witness := range c.Witnesses {
+		wres, err := witness.ABCIQuery(ctx, path, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "query witness %d", i)
+		}
+		if !bytes.Equal(wres.Response.Data, qres.Response.Data) ||
+			fmt.Sprint(wres.Response.Error) != fmt.Sprint(qres.Response.Error) {
+			return nil, fmt.Errorf("%w: witness %d", ErrWitnessMismatch, i)
+		}
+	}
+
+	return qres, nil
+}
@@ -1,8 +1,11 @@
 package gnoclient
 
 import (
+	"context"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,7 +16,10 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/log"
@@ -749,3 +755,169 @@ func loadpkgs(t *testing.T, rootdir string, paths ...string) []gnoland.TxWithMet
 	require.NoError(t, err)
 	return meta
 }
+
+func TestSubscribeEvents_Integration(t *testing.T) {
+	// Set up in-memory node
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient, over WebSocket
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewWSClient(strings.Replace(remoteAddr, "tcp://", "ws://", 1) + "/websocket")
+	require.NoError(t, err)
+	defer rpcClient.Close()
+
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelFn()
+
+	events, err := client.SubscribeEvents(ctx, EventFilter{Type: "Ping"})
+	require.NoError(t, err)
+	txs, err := client.SubscribeTxs(ctx)
+	require.NoError(t, err)
+
+	// Make Tx config
+	baseCfg := BaseTxCfg{
+		GasFee:         ugnot.ValueString(2100000),
+		GasWanted:      21000000,
+		AccountNumber:  0,
+		SequenceNumber: 0,
+		Memo:           "",
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	msg := vm.MsgRun{
+		Caller: caller.GetAddress(),
+		Package: &std.MemPackage{
+			Name: "main",
+			Files: []*std.MemFile{
+				{
+					Name: "main.gno",
+					Body: `package main
+import "std"
+func main() {
+	std.Emit("Pong", "n", "0")
+	std.Emit("Ping", "n", "1")
+}`,
+				},
+			},
+		},
+	}
+
+	res, err := client.Run(baseCfg, msg)
+	require.NoError(t, err)
+
+	select {
+	case tx := <-txs:
+		assert.Equal(t, res.Height, tx.Height)
+		assert.Len(t, tx.Response.Events, 2)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the tx")
+	}
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, "Ping", ev.Type)
+		assert.Equal(t, res.Height, ev.Height)
+		assert.Equal(t, res.Hash, ev.TxHash)
+		require.Len(t, ev.Attributes, 1)
+		assert.Equal(t, "1", ev.Attributes[0].Value)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the event")
+	}
+}
+
+func TestVerifiedQueries_Integration(t *testing.T) {
+	// Setup packages
+	rootdir := gnoenv.RootDir()
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	meta := loadpkgs(t, rootdir, "gno.land/r/tests/vm/deep/very/deep")
+	state := config.Genesis.AppState.(gnoland.GnoGenesisState)
+	state.Txs = append(state.Txs, meta...)
+	config.Genesis.AppState = state
+	config.TMConfig.Consensus.CreateEmptyBlocks = true
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClients
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+	witness, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	// Proofs require a few blocks
+	require.Eventually(t, func() bool {
+		status, err := rpcClient.Status(context.Background(), nil)
+		return err == nil && status.SyncInfo.LatestBlockHeight >= 4
+	}, 30*time.Second, 50*time.Millisecond)
+
+	// Trust the first block
+	trustHeight := int64(1)
+	commit, err := rpcClient.Commit(context.Background(), &trustHeight)
+	require.NoError(t, err)
+	lightClient, err := light.NewClient(config.Genesis.ChainID, light.TrustOptions{
+		Period: time.Hour,
+		Height: trustHeight,
+		Hash:   commit.SignedHeader.Hash(),
+	}, rpcClient)
+	require.NoError(t, err)
+
+	// Setup Client
+	client := Client{
+		Signer:      signer,
+		RPCClient:   rpcClient,
+		LightClient: lightClient,
+		Witnesses:   []rpcclient.Client{witness},
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	// The account is verified with a merkle proof
+	account, qres, err := client.QueryAccount(caller.GetAddress())
+	require.NoError(t, err)
+	assert.Equal(t, caller.GetAddress(), account.GetAddress())
+	assert.False(t, account.GetCoins().IsZero())
+	assert.NotNil(t, qres.Response.Proof)
+
+	unknown := crypto.AddressFromPreimage([]byte("unknown"))
+	_, _, err = client.QueryAccount(unknown)
+	assert.ErrorContains(t, err, "unknown address")
+
+	// Computed results are cross-checked with the witnesses
+	res, _, err := client.RenderCrossChecked("gno.land/r/tests/vm/deep/very/deep", "")
+	require.NoError(t, err)
+	assert.Equal(t, "it works!", res)
+
+	res, _, err = client.QEvalCrossChecked("gno.land/r/tests/vm/deep/very/deep", `Render("test")`)
+	require.NoError(t, err)
+	assert.Equal(t, "(\"hi test\" string)", res)
+
+	client.Witnesses = []rpcclient.Client{witness, &mockRPCClient{
+		abciQuery: func(_ context.Context, _ string, _ []byte) (*ctypes.ResultABCIQuery, error) {
+			return &ctypes.ResultABCIQuery{
+				Response: abci.ResponseQuery{ResponseBase: abci.ResponseBase{Data: []byte("forged")}},
+			}, nil
+		},
+	}}
+	_, _, err = client.RenderCrossChecked("gno.land/r/tests/vm/deep/very/deep", "")
+	assert.ErrorIs(t, err, ErrWitnessMismatch)
+
+	// Without cross-checking, the result of the RPC node is returned as is
+	res, _, err = client.Render("gno.land/r/tests/vm/deep/very/deep", "")
+	require.NoError(t, err)
+	assert.Equal(t, "it works!", res)
+
+	client.Witnesses = nil
+	_, _, err = client.QEvalCrossChecked("gno.land/r/tests/vm/deep/very/deep", `Render("test")`)
+	assert.ErrorIs(t, err, ErrMissingWitnesses)
+}
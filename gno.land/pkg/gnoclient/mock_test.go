@@ -121,6 +121,8 @@ type (
 	mockUnconfirmedTxs       func(ctx context.Context, limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	mockNumUnconfirmedTxs    func(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	mockTx                   func(ctx context.Context, hash []byte) (*ctypes.ResultTx, error)
+	mockTxSearch             func(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	mockBlockSearch          func(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
 )
 
 type mockRPCClient struct {
@@ -145,6 +147,8 @@ type mockRPCClient struct {
 	unconfirmedTxs       mockUnconfirmedTxs
 	numUnconfirmedTxs    mockNumUnconfirmedTxs
 	tx                   mockTx
+	txSearch             mockTxSearch
+	blockSearch          mockBlockSearch
 }
 
 func (m *mockRPCClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
@@ -294,3 +298,19 @@ func (m *mockRPCClient) Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx,
 
 	return nil, nil
 }
+
+func (m *mockRPCClient) TxSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	if m.txSearch != nil {
+		return m.txSearch(ctx, query, page, perPage, orderBy)
+	}
+
+	return nil, nil
+}
+
+func (m *mockRPCClient) BlockSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	if m.blockSearch != nil {
+		return m.blockSearch(ctx, query, page, perPage, orderBy)
+	}
+
+	return nil, nil
+}
@@ -44,6 +44,7 @@ type AppOptions struct {
 	InitChainerConfig                             // options related to InitChainer
 	MinGasPrices               string             // optional
 	PruneStrategy              types.PruneStrategy
+	HeaderModifier             sdk.HeaderModifier // optional, modifies the block headers seen by the app (development only)
 }
 
 // TestAppOptions provides a "ready" default [AppOptions] for use with
@@ -76,15 +77,69 @@ func (c AppOptions) validate() error {
 	return nil
 }
 
+// txFeeContextKey is the context key of the fee of the current tx, set by the
+// ante handler.
+type txFeeContextKey struct{}
+
+// appKeepers are the store keys and the keepers of the gno.land application.
+type appKeepers struct {
+	mainKey store.StoreKey
+	baseKey store.StoreKey
+
+	prmk  params.ParamsKeeper
+	acck  auth.AccountKeeper
+	bankk bank.BankKeeper
+	gpk   auth.GasPriceKeeper
+	vmk   *vm.VMKeeper
+}
+
+// newAppKeepers constructs the keepers of the gno.land application.
+func newAppKeepers() appKeepers {
+	mainKey := store.NewStoreKey("main")
+	baseKey := store.NewStoreKey("base")
+
+	prmk := params.NewParamsKeeper(mainKey)
+	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
+	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
+	gpk := auth.NewGasPriceKeeper(mainKey)
+	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
+
+	prmk.Register(auth.ModuleName, acck)
+	prmk.Register(bank.ModuleName, bankk)
+	prmk.Register(vm.ModuleName, vmk)
+
+	return appKeepers{
+		mainKey: mainKey,
+		baseKey: baseKey,
+		prmk:    prmk,
+		acck:    acck,
+		bankk:   bankk,
+		gpk:     gpk,
+		vmk:     vmk,
+	}
+}
+
+// storeMounter is implemented by [sdk.BaseApp] and [store.CommitMultiStore].
+type storeMounter interface {
+	MountStoreWithDB(key store.StoreKey, cons store.CommitStoreConstructor, db dbm.DB)
+}
+
+// mountStores mounts the stores of the application on sm, backed by db.
+func (k appKeepers) mountStores(sm storeMounter, db dbm.DB) {
+	sm.MountStoreWithDB(k.mainKey, iavl.StoreConstructor, db)
+	sm.MountStoreWithDB(k.baseKey, dbadapter.StoreConstructor, db)
+}
+
 // NewAppWithOptions creates the gno.land application with specified options.
 func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
 
-	// Capabilities keys.
-	mainKey := store.NewStoreKey("main")
-	baseKey := store.NewStoreKey("base")
+	// Capabilities keys and keepers.
+	keepers := newAppKeepers()
+	prmk, acck, bankk, gpk, vmk := keepers.prmk, keepers.acck, keepers.bankk, keepers.gpk, keepers.vmk
+	vmk.Output = cfg.VMOutput
 
 	//  set sdk app options
 	var appOpts []func(*sdk.BaseApp)
@@ -95,25 +150,11 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	appOpts = append(appOpts, sdk.SetPruningOptions(cfg.PruneStrategy.Options()))
 
 	// Create BaseApp.
-	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey, appOpts...)
+	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, keepers.baseKey, keepers.mainKey, appOpts...)
 	baseApp.SetAppVersion("dev")
 
 	// Set mounts for BaseApp's MultiStore.
-	baseApp.MountStoreWithDB(mainKey, iavl.StoreConstructor, cfg.DB)
-	baseApp.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, cfg.DB)
-
-	// Construct keepers.
-
-	prmk := params.NewParamsKeeper(mainKey)
-	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
-	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
-	gpk := auth.NewGasPriceKeeper(mainKey)
-	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
-	vmk.Output = cfg.VMOutput
-
-	prmk.Register(auth.ModuleName, acck)
-	prmk.Register(bank.ModuleName, bankk)
-	prmk.Register(vm.ModuleName, vmk)
+	keepers.mountStores(baseApp, cfg.DB)
 
 	// Set InitChainer
 	icc := cfg.InitChainerConfig
@@ -157,6 +198,10 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 
 			// Continue on with default auth ante handler.
 			newCtx, res, abort = authAnteHandler(ctx, tx, simulate)
+			if !abort {
+				// Keep the fee, recorded once the tx succeeds.
+				newCtx = newCtx.WithValue(txFeeContextKey{}, tx.Fee)
+			}
 			return
 		},
 	)
@@ -172,9 +217,18 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	baseApp.SetEndTxHook(func(ctx sdk.Context, result sdk.Result) {
 		if result.IsOK() {
 			vmk.CommitGnoTransactionStore(ctx)
+
+			// Track the fees of the successful txs, to suggest gas prices.
+			if fee, ok := ctx.Value(txFeeContextKey{}).(std.Fee); ok {
+				gpk.RecordTxFee(ctx, fee)
+			}
 		}
 	})
 
+	if cfg.HeaderModifier != nil {
+		baseApp.SetHeaderModifier(cfg.HeaderModifier)
+	}
+
 	// Set up the event collector
 	c := newCollector[validatorUpdate](
 		cfg.EventSwitch,      // global event switch filled by the node
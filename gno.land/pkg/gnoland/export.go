@@ -0,0 +1,151 @@
+package gnoland
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// ExportGenesisState exports the state of the gno.land application stored in
+// db, at its latest committed height, as a [GnoGenesisState] which can be
+// used as the app state of a new chain. It returns the exported height.
+//
+// The exported state holds the balances of all accounts, the auth, bank and
+// vm params, and the deployed packages with their realm state; it has no
+// genesis transactions. The node using db must be stopped.
+func ExportGenesisState(db dbm.DB, logger *slog.Logger) (GnoGenesisState, int64, error) {
+	keepers := newAppKeepers()
+	ms := store.NewCommitMultiStore(db)
+	keepers.mountStores(ms, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		return GnoGenesisState{}, 0, fmt.Errorf("unable to load the latest state, %w", err)
+	}
+	height := ms.LastCommitID().Version
+	if height == 0 {
+		return GnoGenesisState{}, 0, fmt.Errorf("no committed state to export")
+	}
+	acck, bankk, vmk := keepers.acck, keepers.bankk, keepers.vmk
+
+	// The chain ID is not used by the keepers.
+	header := &bft.Header{ChainID: "export", Height: height}
+	ctx := sdk.NewContext(sdk.RunTxModeCheck, ms.MultiCacheWrap(), header, logger)
+
+	state := GnoGenesisState{
+		Auth: acck.ExportGenesis(ctx),
+		Bank: bankk.ExportGenesis(ctx),
+		VM:   vmk.ExportGenesis(ctx),
+	}
+	acck.IterateAccounts(ctx, func(acc std.Account) bool {
+		if coins := acc.GetCoins(); !coins.IsZero() {
+			state.Balances = append(state.Balances, Balance{
+				Address: acc.GetAddress(),
+				Amount:  coins,
+			})
+		}
+		return false
+	})
+
+	return state, height, nil
+}
+
+// ReplayBlocks replays the chain described by genDoc, from its genesis up to
+// height, on a new gno.land application backed by db, which must be empty.
+// The state of db can then be exported with [ExportGenesisState].
+//
+// The blocks are read from blockStore, and the validator sets they were
+// committed with from stateDB, which are the databases of a stopped node.
+// The app hash resulting from each block is checked against the one recorded
+// by the node.
+func ReplayBlocks(
+	db dbm.DB,
+	genDoc *bft.GenesisDoc,
+	blockStore sm.BlockStoreRPC,
+	stateDB dbm.DB,
+	height int64,
+	genesisCfg GenesisAppConfig,
+	logger *slog.Logger,
+) error {
+	if latest := blockStore.Height(); height < 1 || height > latest {
+		return fmt.Errorf("height %d is not in the block store range [1, %d]", height, latest)
+	}
+
+	cfg := &AppOptions{
+		DB:          db,
+		Logger:      logger,
+		EventSwitch: events.NewEventSwitch(),
+		InitChainerConfig: InitChainerConfig{
+			GenesisTxResultHandler: PanicOnFailingTxResultHandler,
+			StdlibDir:              filepath.Join(gnoenv.RootDir(), "gnovm", "stdlibs"),
+		},
+		SkipGenesisSigVerification: genesisCfg.SkipSigVerification,
+	}
+	if genesisCfg.SkipFailingTxs {
+		cfg.GenesisTxResultHandler = NoopGenesisTxResultHandler
+	}
+	app, err := NewAppWithOptions(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create the app, %w", err)
+	}
+
+	proxyApp := appconn.NewAppConns(proxy.NewLocalClientCreator(app))
+	if err := proxyApp.Start(); err != nil {
+		return fmt.Errorf("unable to start the app connections, %w", err)
+	}
+	defer proxyApp.Stop()
+
+	// Same request as the one of the consensus handshake.
+	validators := make([]*bft.Validator, len(genDoc.Validators))
+	for i, val := range genDoc.Validators {
+		validators[i] = bft.NewValidator(val.PubKey, val.Power)
+	}
+	csParams := genDoc.ConsensusParams
+	if _, err := proxyApp.Consensus().InitChainSync(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainID:         genDoc.ChainID,
+		ConsensusParams: &csParams,
+		Validators:      bft.NewValidatorSet(validators).ABCIValidatorUpdates(),
+		AppState:        genDoc.AppState,
+	}); err != nil {
+		return fmt.Errorf("unable to init the chain, %w", err)
+	}
+
+	for h := int64(1); h <= height; h++ {
+		block := blockStore.LoadBlock(h)
+		if block == nil {
+			return fmt.Errorf("block %d not found in the block store", h)
+		}
+		appHash, err := sm.ExecCommitBlock(proxyApp.Consensus(), block, logger, stateDB)
+		if err != nil {
+			return fmt.Errorf("unable to replay block %d, %w", h, err)
+		}
+
+		// The app hash of a block is in the header of the next one, or in the
+		// state for the latest block.
+		var expected []byte
+		if meta := blockStore.LoadBlockMeta(h + 1); meta != nil {
+			expected = meta.Header.AppHash
+		} else if state := sm.LoadState(stateDB); state.LastBlockHeight == h {
+			expected = state.AppHash
+		} else {
+			continue
+		}
+		if !bytes.Equal(appHash, expected) {
+			return fmt.Errorf("app hash mismatch at height %d: expected %X, got %X", h, expected, appHash)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+package gnoland
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestExportGenesisState(t *testing.T) {
+	t.Parallel()
+
+	const pkgPath = "gno.land/r/demo/export"
+	addr := crypto.AddressFromPreimage([]byte("test1"))
+	fee := std.Fee{GasWanted: 1e7, GasFee: std.Coin{Amount: 1e6, Denom: "ugnot"}}
+
+	newApp := func(db dbm.DB, appState GnoGenesisState) *sdk.BaseApp {
+		t.Helper()
+
+		app, err := NewAppWithOptions(TestAppOptions(db))
+		require.NoError(t, err)
+		bapp := app.(*sdk.BaseApp)
+
+		resp := bapp.InitChain(abci.RequestInitChain{
+			Time:    time.Now(),
+			ChainID: "dev",
+			ConsensusParams: &abci.ConsensusParams{
+				Block: defaultBlockParams(),
+			},
+			AppState: appState,
+		})
+		require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+		bapp.Commit()
+		return bapp
+	}
+	call := func(arg string) TxWithMetadata {
+		return TxWithMetadata{
+			Tx: std.Tx{
+				Msgs:       []std.Msg{vm.NewMsgCall(addr, nil, pkgPath, "Add", []string{arg})},
+				Fee:        fee,
+				Signatures: []std.Signature{{}}, // one empty signature
+			},
+		}
+	}
+	render := func(bapp *sdk.BaseApp) string {
+		t.Helper()
+
+		resp := bapp.Query(abci.RequestQuery{
+			Path: "vm/qrender",
+			Data: []byte(pkgPath + ":"),
+		})
+		require.True(t, resp.IsOK(), "Query response: %v", resp)
+		return string(resp.Data)
+	}
+
+	// Deploy a realm, and update its state, in the genesis transactions.
+	appState := DefaultGenState()
+	appState.Balances = []Balance{
+		{Address: addr, Amount: std.Coins{{Amount: 1e15, Denom: "ugnot"}}},
+	}
+	appState.Txs = []TxWithMetadata{
+		{
+			Tx: std.Tx{
+				Msgs: []std.Msg{vm.NewMsgAddPackage(addr, pkgPath, []*std.MemFile{
+					{
+						Name: "export.gno",
+						Body: `package export
+
+var (
+	list []string
+	last = &list
+)
+
+func Add(cur realm, s string) { list = append(list, s) }
+
+func Render(string) string {
+	res := ""
+	for _, s := range *last {
+		res += s
+	}
+	return res
+}`,
+					},
+					{
+						Name: "gnomod.toml",
+						Body: gnolang.GenGnoModLatest(pkgPath),
+					},
+				})},
+				Fee:        fee,
+				Signatures: []std.Signature{{}}, // one empty signature
+			},
+		},
+		call("a"),
+		call("b"),
+	}
+	appState.VM.RealmParams = []params.Param{
+		params.NewParam(pkgPath+":foo_string", "hello"),
+		params.NewParam(pkgPath+":foo_int64", int64(-42)),
+		params.NewParam(pkgPath+":foo_bool", true),
+		params.NewParam(pkgPath+":foo_strings", []string{"some", "strings"}),
+		params.NewParam(pkgPath+":foo_uint64", uint64(42)),
+		params.NewParam(pkgPath+":foo_bytes", []byte("bytes")),
+	}
+
+	db := memdb.NewMemDB()
+	bapp := newApp(db, appState)
+	require.Equal(t, "ab", render(bapp))
+
+	// Export the state, and check it survives amino JSON.
+	exported, height, err := ExportGenesisState(db, log.NewNoopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), height)
+
+	bz, err := amino.MarshalJSON(exported)
+	require.NoError(t, err)
+	var state GnoGenesisState
+	require.NoError(t, amino.UnmarshalJSON(bz, &state))
+
+	// The fee collector and storage deposits also have balances.
+	var addrs []crypto.Address
+	for _, bal := range state.Balances {
+		addrs = append(addrs, bal.Address)
+	}
+	assert.Contains(t, addrs, addr)
+	assert.Empty(t, state.Txs)
+	assert.Equal(t, appState.Auth.Params, state.Auth.Params)
+	assert.Equal(t, appState.VM.Params, state.VM.Params)
+	assert.Empty(t, state.VM.RealmParams)
+	assert.Len(t, state.VM.RawRealmParams, len(appState.VM.RealmParams))
+	require.Len(t, state.VM.Packages, 1)
+	assert.Equal(t, pkgPath, state.VM.Packages[0].Path)
+	assert.NotEmpty(t, state.VM.State)
+
+	// A new chain, started from the exported state, has the same realm state,
+	// which can still be updated.
+	state.Txs = []TxWithMetadata{call("c")}
+	bapp2 := newApp(memdb.NewMemDB(), state)
+	assert.Equal(t, "abc", render(bapp2))
+
+	for _, tc := range []struct{ key, value string }{
+		{"foo_string", `"hello"`},
+		{"foo_int64", `"-42"`},
+		{"foo_bool", `true`},
+		{"foo_strings", `["some","strings"]`},
+		{"foo_uint64", `"42"`},
+		{"foo_bytes", `"Ynl0ZXM="`},
+	} {
+		resp := bapp2.Query(abci.RequestQuery{Path: "params/vm:" + pkgPath + ":" + tc.key})
+		require.True(t, resp.IsOK())
+		assert.Equal(t, tc.value, string(resp.Data))
+	}
+}
+
+func TestReplayBlocks(t *testing.T) {
+	t.Parallel()
+
+	const chainID = "dev"
+	var (
+		key    = secp256k1.GenPrivKey()
+		valKey = ed25519.GenPrivKey()
+		from   = key.PubKey().Address()
+		to     = crypto.AddressFromPreimage([]byte("to"))
+	)
+
+	appState := DefaultGenState()
+	appState.Balances = []Balance{
+		{Address: from, Amount: std.MustParseCoins("1000000000ugnot")},
+	}
+	genDoc := &bft.GenesisDoc{
+		GenesisTime: time.Now().Truncate(time.Second),
+		ChainID:     chainID,
+		ConsensusParams: abci.ConsensusParams{
+			Block: defaultBlockParams(),
+		},
+		Validators: []bft.GenesisValidator{
+			{Address: valKey.PubKey().Address(), PubKey: valKey.PubKey(), Power: 10, Name: "val"},
+		},
+		AppState: appState,
+	}
+
+	// Commit 3 blocks, the last 2 sending 1000ugnot each, on a node-like
+	// setup: the app, the block store, and the state database.
+	var (
+		blockStore = store.NewBlockStore(memdb.NewMemDB())
+		stateDB    = memdb.NewMemDB()
+		appDB      = memdb.NewMemDB()
+	)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	sm.SaveState(stateDB, state)
+
+	app, err := NewAppWithOptions(TestAppOptions(appDB))
+	require.NoError(t, err)
+	proxyApp := appconn.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	csParams := genDoc.ConsensusParams
+	_, err = proxyApp.Consensus().InitChainSync(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainID:         chainID,
+		ConsensusParams: &csParams,
+		Validators:      state.Validators.ABCIValidatorUpdates(),
+		AppState:        genDoc.AppState,
+	})
+	require.NoError(t, err)
+
+	var (
+		appHash    []byte
+		lastCommit = &bft.Commit{}
+	)
+	for h := int64(1); h <= 3; h++ {
+		// The accounts can only be queried once the genesis is committed, so
+		// the first block is empty.
+		var txs []bft.Tx
+		if h > 1 {
+			resp := app.Query(abci.RequestQuery{Path: "auth/accounts/" + from.String()})
+			require.True(t, resp.IsOK(), "Query response: %v", resp)
+			var acc GnoAccount
+			require.NoError(t, amino.UnmarshalJSON(resp.Data, &acc))
+
+			tx := std.Tx{
+				Msgs: []std.Msg{bank.MsgSend{
+					FromAddress: from,
+					ToAddress:   to,
+					Amount:      std.MustParseCoins("1000ugnot"),
+				}},
+				Fee: std.Fee{GasWanted: 1e6, GasFee: std.MustParseCoin("1000ugnot")},
+			}
+			signBytes, err := tx.GetSignBytes(chainID, acc.GetAccountNumber(), acc.GetSequence())
+			require.NoError(t, err)
+			sig, err := key.Sign(signBytes)
+			require.NoError(t, err)
+			tx.Signatures = []std.Signature{{PubKey: key.PubKey(), Signature: sig}}
+			txs = append(txs, amino.MustMarshal(tx))
+		}
+
+		block := bft.MakeBlock(h, txs, lastCommit)
+		block.ChainID = chainID
+		block.Time = genDoc.GenesisTime.Add(time.Duration(h) * time.Second)
+		block.AppHash = appHash
+		parts := block.MakePartSet(bft.BlockPartSizeBytes)
+
+		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.NewNoopLogger(), stateDB)
+		require.NoError(t, err)
+
+		blockID := bft.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		lastCommit = bft.NewCommit(blockID, []*bft.CommitSig{{
+			Type:             bft.PrecommitType,
+			Height:           h,
+			BlockID:          blockID,
+			ValidatorAddress: valKey.PubKey().Address(),
+		}})
+		blockStore.SaveBlock(block, parts, lastCommit)
+
+		state.LastBlockHeight = h
+		state.AppHash = appHash
+		sm.SaveState(stateDB, state)
+	}
+
+	balanceOf := func(state GnoGenesisState, addr crypto.Address) std.Coins {
+		for _, bal := range state.Balances {
+			if bal.Address == addr {
+				return bal.Amount
+			}
+		}
+		return nil
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		t.Parallel()
+
+		for height, sent := range map[int64]std.Coins{
+			1: nil,
+			2: std.MustParseCoins("1000ugnot"),
+			3: std.MustParseCoins("2000ugnot"),
+		} {
+			db := memdb.NewMemDB()
+			require.NoError(t, ReplayBlocks(db, genDoc, blockStore, stateDB, height, GenesisAppConfig{}, log.NewNoopLogger()))
+
+			exported, exportedHeight, err := ExportGenesisState(db, log.NewNoopLogger())
+			require.NoError(t, err)
+			assert.Equal(t, height, exportedHeight)
+			assert.Equal(t, sent, balanceOf(exported, to))
+		}
+	})
+
+	t.Run("height out of range", func(t *testing.T) {
+		t.Parallel()
+
+		err := ReplayBlocks(memdb.NewMemDB(), genDoc, blockStore, stateDB, 4, GenesisAppConfig{}, log.NewNoopLogger())
+		assert.ErrorContains(t, err, "height 4 is not in the block store range [1, 3]")
+	})
+
+	t.Run("other genesis", func(t *testing.T) {
+		t.Parallel()
+
+		otherState := DefaultGenState()
+		otherState.Balances = []Balance{
+			{Address: from, Amount: std.MustParseCoins("2000000000ugnot")},
+		}
+		otherDoc := *genDoc
+		otherDoc.AppState = otherState
+
+		err := ReplayBlocks(memdb.NewMemDB(), &otherDoc, blockStore, stateDB, 2, GenesisAppConfig{}, log.NewNoopLogger())
+		assert.ErrorContains(t, err, "app hash mismatch at height 1")
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
 )
 
 type InMemoryNodeConfig struct {
@@ -27,6 +28,7 @@ type InMemoryNodeConfig struct {
 	DB                         db.DB     // will be initialized if nil
 	VMOutput                   io.Writer // optional
 	SkipGenesisSigVerification bool
+	HeaderModifier             sdk.HeaderModifier // optional, modifies the block headers seen by the app
 
 	// If StdlibDir not set, then it's filepath.Join(TMConfig.RootDir, "gnovm", "stdlibs")
 	InitChainerConfig
@@ -113,6 +115,7 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 		InitChainerConfig:          cfg.InitChainerConfig,
 		VMOutput:                   cfg.VMOutput,
 		SkipGenesisSigVerification: cfg.SkipGenesisSigVerification,
+		HeaderModifier:             cfg.HeaderModifier,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing new app: %w", err)
@@ -56,6 +56,12 @@ type AppConfig struct {
 	Aliases map[string]AliasTarget
 	// RenderConfig defines the default configuration for rendering realms and source files.
 	RenderConfig RenderConfig
+	// History enables the history view of the realms, backed by the
+	// transaction indexer of the node.
+	History bool
+	// HistoryClient, if specified, enables the history view of the realms
+	// backed by this client instead of the node.
+	HistoryClient HistoryClient
 }
 
 // NewDefaultAppConfig returns a new default AppConfig. The default sets
@@ -118,6 +124,12 @@ func NewRouter(logger *slog.Logger, cfg *AppConfig) (http.Handler, error) {
 	}
 	renderer := NewHTMLRenderer(logger, rcfg)
 
+	// Setup history client
+	historycli := cfg.HistoryClient
+	if historycli == nil && cfg.History {
+		historycli = NewRPCHistoryClient(logger, rpcclient, cfg.Domain)
+	}
+
 	// Configure HTTPHandler
 	if cfg.Aliases == nil {
 		cfg.Aliases = make(map[string]AliasTarget) // Sanitize Aliases cfg
@@ -127,6 +139,7 @@ func NewRouter(logger *slog.Logger, cfg *AppConfig) (http.Handler, error) {
 		Meta:          staticMeta,
 		Renderer:      renderer,
 		Aliases:       cfg.Aliases,
+		HistoryClient: historycli,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create web handler: %w", err)
@@ -46,6 +46,7 @@ func TestRoutes(t *testing.T) {
 			{"/r/gnoland/blog/admin.gno", ok, ">func<"},
 			{"/r/gnoland/blog$help&func=Render", ok, "Render(path)"},
 			{"/r/gnoland/blog$help&func=Render&path=foo/bar", ok, `value="foo/bar"`},
+			{"/r/gnoland/blog$history", notFound, "history unavailable"}, // history is disabled by default
 			// {"/r/gnoland/blog$help&func=NonExisting", ok, "NonExisting not found"}, // XXX(TODO)
 			{"/r/gnoland/users/v1:archive", ok, "Address"},
 			{"/r/gnoland/users/v1", ok, "registry"},
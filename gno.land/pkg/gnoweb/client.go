@@ -13,11 +13,14 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 var (
 	ErrClientPackageNotFound   = errors.New("package not found")
 	ErrClientFileNotFound      = errors.New("file not found")
+	ErrClientAccountNotFound   = errors.New("account not found")
 	ErrClientRenderNotDeclared = errors.New("render function not declared")
 	ErrClientBadRequest        = errors.New("bad request")
 	ErrClientTimeout           = errors.New("RPC node request timeout")
@@ -48,6 +51,10 @@ type ClientAdapter interface {
 	// Doc retrieves the JSON doc suitable for printing from a
 	// specified package path.
 	Doc(ctx context.Context, path string) (*doc.JSONDocumentation, error)
+
+	// Account retrieves the account of the given address, used to
+	// fill the account number and sequence of the transactions.
+	Account(ctx context.Context, addr crypto.Address) (*std.BaseAccount, error)
 }
 
 type rpcClient struct {
@@ -171,6 +178,29 @@ func (c *rpcClient) Doc(ctx context.Context, pkgPath string) (*doc.JSONDocumenta
 	return jdoc, nil
 }
 
+// Account retrieves the account of the given address by querying
+// the RPC client.
+func (c *rpcClient) Account(ctx context.Context, addr crypto.Address) (*std.BaseAccount, error) {
+	const qpath = "auth/accounts/"
+
+	// XXX: Consider moving this into gnoclient
+	res, err := c.query(ctx, qpath+addr.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query account: %w", err)
+	}
+
+	if len(res) == 0 || string(res) == "null" {
+		return nil, ErrClientAccountNotFound
+	}
+
+	var qret struct{ BaseAccount std.BaseAccount }
+	if err := amino.UnmarshalJSON(res, &qret); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal account: %w", err)
+	}
+
+	return &qret.BaseAccount, nil
+}
+
 // query sends a query to the RPC client and returns the response
 // data.
 func (c *rpcClient) query(ctx context.Context, qpath string, data []byte) ([]byte, error) {
@@ -7,7 +7,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 // MockPackage represents a mock package with files and function signatures for testing.
@@ -16,14 +19,19 @@ type MockPackage struct {
 	Domain    string
 	Files     map[string]string // filename -> body
 	Functions []*doc.JSONFunc
+	History   []components.HistoryTx // most recent first
 }
 
 // MockClient is a mock implementation of the ClientAdapter interface for testing.
 type MockClient struct {
-	Packages map[string]*MockPackage // path -> package
+	Packages map[string]*MockPackage             // path -> package
+	Accounts map[crypto.Address]*std.BaseAccount // address -> account
 }
 
-var _ ClientAdapter = (*MockClient)(nil)
+var (
+	_ ClientAdapter = (*MockClient)(nil)
+	_ HistoryClient = (*MockClient)(nil)
+)
 
 // NewMockClient creates a new MockClient from one or more MockPackages.
 func NewMockClient(pkgs ...*MockPackage) *MockClient {
@@ -138,6 +146,39 @@ func (m *MockClient) Doc(ctx context.Context, path string) (*doc.JSONDocumentati
 	return &doc.JSONDocumentation{Funcs: pkg.Functions}, nil
 }
 
+// Account retrieves the account of the given address.
+func (m *MockClient) Account(ctx context.Context, addr crypto.Address) (*std.BaseAccount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context error: %w", err)
+	}
+
+	acc, exists := m.Accounts[addr]
+	if !exists {
+		return nil, ErrClientAccountNotFound
+	}
+	return acc, nil
+}
+
+// History lists a page of the transactions of a specified package path.
+func (m *MockClient) History(ctx context.Context, path string, page, perPage int) ([]components.HistoryTx, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, fmt.Errorf("context error: %w", err)
+	}
+
+	pkg, exists := m.Packages[path]
+	if !exists {
+		return nil, false, nil // no transaction
+	}
+
+	total := len(pkg.History)
+	start := (page - 1) * perPage
+	if start > 0 && start >= total {
+		return nil, false, ErrHistoryPageNotFound
+	}
+	end := min(start+perPage, total)
+	return pkg.History[start:end], end < total, nil
+}
+
 // Helper: check if package has a Render(string) string function.
 func pkgHasRender(pkg *MockPackage) bool {
 	if len(pkg.Functions) == 0 {
@@ -26,6 +26,9 @@ type HeaderData struct {
 	ChainId    string
 	Remote     string
 	Mode       ViewMode
+
+	// History enables the link to the history view of realms.
+	History bool
 }
 
 func StaticHeaderGeneralLinks() []HeaderLink {
@@ -75,11 +78,27 @@ func StaticHeaderDevLinks(u weburl.GnoURL, mode ViewMode) []HeaderLink {
 	}
 }
 
+func historyHeaderLink(u weburl.GnoURL) HeaderLink {
+	historyURL := u
+	historyURL.WebQuery = url.Values{"history": {""}}
+
+	return HeaderLink{
+		Label:    "History",
+		URL:      historyURL.EncodeWebURL(),
+		Icon:     "ico-history",
+		IsActive: isActive(u.WebQuery, "History"),
+	}
+}
+
 func EnrichHeaderData(data HeaderData, mode ViewMode) HeaderData {
 	data.RealmPath = data.RealmURL.EncodeURL()
 	data.Links.Dev = StaticHeaderDevLinks(data.RealmURL, mode)
 	data.Links.General = nil
 
+	if data.History && (mode.IsRealm() || mode.IsHome()) {
+		data.Links.Dev = append(data.Links.Dev, historyHeaderLink(data.RealmURL))
+	}
+
 	if mode.ShouldShowGeneralLinks() {
 		data.Links.General = StaticHeaderGeneralLinks()
 	}
@@ -90,11 +109,13 @@ func EnrichHeaderData(data HeaderData, mode ViewMode) HeaderData {
 func isActive(webQuery url.Values, label string) bool {
 	switch label {
 	case "Content":
-		return !webQuery.Has("source") && !webQuery.Has("help")
+		return !webQuery.Has("source") && !webQuery.Has("help") && !webQuery.Has("history")
 	case "Source":
 		return webQuery.Has("source")
 	case "Actions":
 		return webQuery.Has("help")
+	case "History":
+		return webQuery.Has("history")
 	default:
 		return false
 	}
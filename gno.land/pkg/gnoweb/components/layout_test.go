@@ -149,6 +149,22 @@ func TestIsActive(t *testing.T) {
 			label:    "Actions",
 			expected: true,
 		},
+		{
+			name: "Content inactive when history present",
+			query: url.Values{
+				"history": []string{""},
+			},
+			label:    "Content",
+			expected: false,
+		},
+		{
+			name: "History active when history present",
+			query: url.Values{
+				"history": []string{""},
+			},
+			label:    "History",
+			expected: true,
+		},
 		{
 			name:     "Unknown label returns false",
 			query:    url.Values{},
@@ -223,6 +239,27 @@ func TestEnrichHeaderData_WithRealmMode(t *testing.T) {
 	assert.Len(t, enriched.Links.Dev, 3, "expected Content, Source, and Actions links")
 }
 
+func TestEnrichHeaderData_WithHistory(t *testing.T) {
+	t.Parallel()
+
+	data := HeaderData{
+		RealmURL: weburl.GnoURL{
+			Path: "/r/test/pkg",
+		},
+		History: true,
+	}
+
+	// Test realm mode
+	enriched := EnrichHeaderData(data, ViewModeRealm)
+	assert.Len(t, enriched.Links.Dev, 4, "expected Content, Source, Actions, and History links")
+	assert.Equal(t, "History", enriched.Links.Dev[3].Label)
+	assert.Equal(t, "/r/test/pkg$history", enriched.Links.Dev[3].URL)
+
+	// Test package mode
+	enriched = EnrichHeaderData(data, ViewModePackage)
+	assert.Len(t, enriched.Links.Dev, 2, "expected Content and Source links only")
+}
+
 func TestEnrichHeaderData_WithExplorerMode(t *testing.T) {
 	t.Parallel()
 
@@ -51,6 +51,10 @@ func registerHelpFuncs(funcs template.FuncMap) {
 		return data.SelectedArgs[param.Name], nil
 	}
 
+	funcs["buildTxPath"] = func(data HelpData) string {
+		return strings.TrimPrefix(data.PkgPath, data.Domain)
+	}
+
 	funcs["buildHelpURL"] = func(data HelpData, fn *doc.JSONFunc) string {
 		pkgPath := strings.TrimPrefix(data.PkgPath, data.Domain)
 		url := pkgPath + "$help&func=" + fn.Name
@@ -0,0 +1,73 @@
+package components
+
+import "strconv"
+
+const HistoryViewType ViewType = "history-view"
+
+// HistoryEventAttr is an attribute of an event.
+type HistoryEventAttr struct {
+	Key   string
+	Value string
+}
+
+// HistoryEvent is an event emitted by a transaction, such as the Gno events
+// emitted with std.Emit.
+type HistoryEvent struct {
+	Type    string
+	PkgPath string // realm which emitted the event, if any
+	Attrs   []HistoryEventAttr
+}
+
+// HistoryMsg is a message of a transaction targeting the realm.
+type HistoryMsg struct {
+	Type string // message type, e.g. "exec" or "add_package"
+	Func string // called function, for "exec" messages
+	Args []string
+	Send string
+}
+
+// HistoryTx is a transaction of the history of a realm.
+type HistoryTx struct {
+	Hash    string
+	Height  int64
+	Caller  string
+	Msgs    []HistoryMsg
+	Events  []HistoryEvent
+	Result  string // values returned by the call, if any
+	Error   string // error of a failed transaction
+	GasUsed int64
+}
+
+// HistoryData contains data for the history view.
+type HistoryData struct {
+	RealmName string
+	PkgPath   string
+	Txs       []HistoryTx
+	More      bool // more transactions follow the page
+	Page      int
+	PerPage   int
+}
+
+type historyViewParams struct {
+	HistoryData
+	PrevURL string
+	NextURL string
+}
+
+// HistoryView creates a new history view component, listing the transactions
+// of a realm, the most recent first.
+func HistoryView(data HistoryData) *View {
+	params := historyViewParams{HistoryData: data}
+
+	pageURL := func(page int) string {
+		return data.PkgPath + "$history&page=" + strconv.Itoa(page)
+	}
+	if data.Page > 1 {
+		params.PrevURL = pageURL(data.Page - 1)
+	}
+	if data.More {
+		params.NextURL = pageURL(data.Page + 1)
+	}
+
+	return NewTemplateView(HistoryViewType, "renderHistory", params)
+}
@@ -194,6 +194,47 @@ func TestHelpView(t *testing.T) {
 	assert.NoError(t, view.Render(io.Discard))
 }
 
+func TestHistoryView(t *testing.T) {
+	data := HistoryData{
+		RealmName: "TestRealm",
+		PkgPath:   "/r/test/realm",
+		Txs: []HistoryTx{
+			{
+				Hash:   "hash1",
+				Height: 10,
+				Caller: "g1caller",
+				Msgs:   []HistoryMsg{{Type: "exec", Func: "Transfer", Args: []string{"g1dest", "10"}}},
+				Events: []HistoryEvent{{Type: "Transfer", PkgPath: "gno.land/r/test/realm", Attrs: []HistoryEventAttr{{Key: "to", Value: "g1dest"}}}},
+			},
+			{Hash: "hash2", Height: 9, Error: "insufficient funds"},
+		},
+		More:    true,
+		Page:    2,
+		PerPage: 2,
+	}
+
+	view := HistoryView(data)
+
+	assert.NotNil(t, view, "expected view to be non-nil")
+
+	templateComponent, ok := view.Component.(*TemplateComponent)
+	assert.True(t, ok, "expected TemplateComponent type in view.Component")
+
+	params, ok := templateComponent.data.(historyViewParams)
+	assert.True(t, ok, "expected historyViewParams type in component data")
+
+	assert.Equal(t, "/r/test/realm$history&page=1", params.PrevURL)
+	assert.Equal(t, "/r/test/realm$history&page=3", params.NextURL)
+
+	assert.NoError(t, view.Render(io.Discard))
+
+	// Last page
+	data.Page, data.More = 3, false
+	lastParams, ok := HistoryView(data).Component.(*TemplateComponent).data.(historyViewParams)
+	assert.True(t, ok, "expected historyViewParams type in component data")
+	assert.Empty(t, lastParams.NextURL)
+}
+
 func TestDirectoryView(t *testing.T) {
 	pkgPath := "example/path"
 	files := []string{"file1.gno", "file2.gno"}
@@ -29,6 +29,10 @@
 			selector: ".js-breadcrumb",
 			path: "/public/js/breadcrumb.js",
 		},
+		wallet: {
+			selector: "[data-tx-func]",
+			path: "/public/js/wallet.js",
+		},
 	};
 
 	const loadModuleIfExists = async ({
@@ -0,0 +1,176 @@
+// Wallet bridge: hands the transactions of the help page functions and of the
+// `<gno-form func="...">` forms to a browser wallet.
+//
+// A wallet registers itself on `window.gnoweb`, either directly if gnoweb is
+// already loaded, or once the `gnoweb:ready` event is dispatched:
+//
+//	const wallet = {
+//		name: "My Wallet",
+//		getAddress: async () => "g1...",
+//		signAndBroadcast: async (req) => ({ hash: "..." }),
+//	};
+//	if (window.gnoweb) window.gnoweb.registerWallet(wallet);
+//	else window.addEventListener("gnoweb:ready", () => window.gnoweb.registerWallet(wallet));
+//
+// On submission, gnoweb fetches the unsigned transaction from the `$tx`
+// endpoint of the realm, with the address of the wallet as the caller, and
+// passes the response to `signAndBroadcast` as is.
+
+export interface TxRequest {
+	chain_id: string;
+	remote: string;
+	tx: unknown; // unsigned std.Tx, in amino JSON
+	sign_bytes?: string; // std.SignDoc in sorted amino JSON, in base64; set with a caller
+}
+
+export interface TxResult {
+	hash: string;
+}
+
+export interface Wallet {
+	name: string;
+	getAddress(): Promise<string>;
+	signAndBroadcast(req: TxRequest): Promise<TxResult>;
+}
+
+declare global {
+	interface Window {
+		gnoweb?: { registerWallet(wallet: Wallet): void };
+	}
+}
+
+class TxBridge {
+	private wallet: Wallet | null = null;
+	private sources: HTMLElement[];
+
+	private static SELECTORS = {
+		source: "[data-tx-func]",
+		walletPanel: "[data-role='tx-wallet']",
+		walletName: "[data-role='tx-wallet-name']",
+		sign: "[data-role='tx-sign']",
+		status: "[data-role='tx-status']",
+		paramInput: "[data-role='help-param-input']",
+		sendInput: "[data-role='help-send-input']",
+		formSend: "input[type='hidden'][name='.send']",
+	};
+
+	// Names of the function parameters, the only form fields passed on.
+	private static PARAM_NAME = /^[A-Za-z_][A-Za-z0-9_]*$/;
+
+	constructor() {
+		this.sources = Array.from(
+			document.querySelectorAll<HTMLElement>(TxBridge.SELECTORS.source),
+		);
+
+		this.bindEvents();
+
+		window.gnoweb = {
+			registerWallet: (wallet: Wallet) => this.registerWallet(wallet),
+		};
+		window.dispatchEvent(new CustomEvent("gnoweb:ready"));
+	}
+
+	private registerWallet(wallet: Wallet): void {
+		this.wallet = wallet;
+
+		this.sources.forEach((source) => {
+			source
+				.querySelectorAll<HTMLElement>(TxBridge.SELECTORS.walletPanel)
+				.forEach((panel) => panel.classList.remove("hidden"));
+			source
+				.querySelectorAll<HTMLElement>(TxBridge.SELECTORS.walletName)
+				.forEach((name) => {
+					name.textContent = wallet.name;
+				});
+		});
+	}
+
+	private bindEvents(): void {
+		this.sources.forEach((source) => {
+			if (source instanceof HTMLFormElement) {
+				// Without wallet, the form falls back on the help page.
+				source.addEventListener("submit", (e) => {
+					if (!this.wallet) return;
+					e.preventDefault();
+					this.submit(source, TxBridge.formArgs(source));
+				});
+				return;
+			}
+
+			source
+				.querySelector<HTMLElement>(TxBridge.SELECTORS.sign)
+				?.addEventListener("click", () =>
+					this.submit(source, TxBridge.helpArgs(source)),
+				);
+		});
+	}
+
+	// formArgs returns the function arguments of a form. The fields which
+	// can't be parameters, such as the `.gas-fee` or `.memo` queries of the
+	// transaction, are dropped, and the coins to send are only taken from
+	// the `send` attribute of the form, not from its inputs.
+	private static formArgs(form: HTMLFormElement): URLSearchParams {
+		const args = new URLSearchParams();
+		new FormData(form).forEach((value, key) => {
+			if (typeof value === "string" && key !== "func" && TxBridge.PARAM_NAME.test(key)) {
+				args.append(key, value);
+			}
+		});
+
+		const send = form.querySelector<HTMLInputElement>(TxBridge.SELECTORS.formSend);
+		if (send?.value) args.set(".send", send.value);
+		return args;
+	}
+
+	private static helpArgs(el: HTMLElement): URLSearchParams {
+		const args = new URLSearchParams();
+		el.querySelectorAll<HTMLInputElement>(
+			TxBridge.SELECTORS.paramInput,
+		).forEach((input) => {
+			if (input.dataset.param) args.set(input.dataset.param, input.value.trim());
+		});
+
+		const send = el.querySelector<HTMLInputElement>(TxBridge.SELECTORS.sendInput);
+		if (send?.checked && send.dataset.send) args.set(".send", send.dataset.send);
+		return args;
+	}
+
+	private async submit(source: HTMLElement, args: URLSearchParams): Promise<void> {
+		const wallet = this.wallet;
+		if (!wallet) return;
+
+		const { txPath, txFunc } = source.dataset;
+		if (!txPath || !txFunc) {
+			console.warn("TxBridge: transaction path or function is missing.");
+			return;
+		}
+
+		const setStatus = (msg: string) => {
+			let status = source.querySelector<HTMLElement>(TxBridge.SELECTORS.status);
+			if (!status) {
+				status = document.createElement("output");
+				status.dataset.role = "tx-status";
+				source.append(status);
+			}
+			status.textContent = msg;
+		};
+
+		try {
+			args.set("func", txFunc);
+			args.set(".caller", await wallet.getAddress());
+
+			setStatus("Building transaction...");
+			const res = await fetch(`${txPath}$tx&${args.toString()}`);
+			const body = await res.json();
+			if (!res.ok) throw new Error(body.error ?? res.statusText);
+
+			setStatus(`Waiting for ${wallet.name}...`);
+			const { hash } = await wallet.signAndBroadcast(body as TxRequest);
+			setStatus(`Transaction sent: ${hash}`);
+		} catch (err) {
+			setStatus(`Transaction failed: ${err instanceof Error ? err.message : err}`);
+		}
+	}
+}
+
+export default () => new TxBridge();
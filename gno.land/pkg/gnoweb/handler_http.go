@@ -5,12 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"go/token"
 	"log/slog"
 	"net/http"
 	"path"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -51,6 +51,8 @@ type HTTPHandlerConfig struct {
 	Renderer      Renderer
 	Aliases       map[string]AliasTarget
 	Timeout       time.Duration
+	// HistoryClient, if set, enables the history view of the realms.
+	HistoryClient HistoryClient
 }
 
 // validate checks if the HTTPHandlerConfig is valid.
@@ -74,6 +76,7 @@ type HTTPHandler struct {
 	Client   ClientAdapter
 	Renderer Renderer
 	Aliases  map[string]AliasTarget
+	History  HistoryClient
 }
 
 // NewHTTPHandler creates a new HTTPHandler.
@@ -87,6 +90,7 @@ func NewHTTPHandler(logger *slog.Logger, cfg *HTTPHandlerConfig) (*HTTPHandler,
 		Static:   cfg.Meta,
 		Renderer: cfg.Renderer,
 		Aliases:  cfg.Aliases,
+		History:  cfg.HistoryClient,
 		Logger:   logger,
 	}, nil
 }
@@ -148,6 +152,12 @@ func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Handle transaction request, served as JSON for the wallets.
+	if gnourl.WebQuery.Has("tx") {
+		h.ServeTx(r.Context(), gnourl, w)
+		return
+	}
+
 	// Set the header mode based on the URL type and context
 	switch {
 	case r.RequestURI == "/": // is home path
@@ -194,8 +204,30 @@ func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Use form data as query
-	gnourl.Query = r.PostForm
+	if gnourl.WebQuery.Has("help") {
+		// Use form data as the arguments of the help page function,
+		// e.g. for a call form submitted without any wallet. As in the
+		// `$tx` endpoint, only the parameters of the function, and the
+		// caller and coins to send, are kept: the form can't set any
+		// other web query.
+		fn, err := h.callableFunc(r.Context(), gnourl)
+		if err != nil {
+			h.Logger.Warn("unable to get the function of the form", "path", gnourl.EncodeWebURL(), "error", err)
+		} else {
+			keys := []string{".caller", ".send"}
+			for _, param := range fn.Params {
+				keys = append(keys, param.Name)
+			}
+			for _, key := range keys {
+				if values, ok := r.PostForm[key]; ok {
+					gnourl.WebQuery[key] = values
+				}
+			}
+		}
+	} else {
+		// Use form data as query
+		gnourl.Query = r.PostForm
+	}
 
 	// Redirect to the new URL
 	http.Redirect(w, r, gnourl.EncodeWebURL(), http.StatusSeeOther)
@@ -225,6 +257,7 @@ func (h *HTTPHandler) prepareIndexBodyView(r *http.Request, indexData *component
 		ChainId:    h.Static.ChainId,
 		Remote:     h.Static.RemoteHelp,
 		Mode:       indexData.Mode,
+		History:    h.History != nil,
 	}
 
 	switch {
@@ -262,6 +295,11 @@ func (h *HTTPHandler) GetPackageView(ctx context.Context, gnourl *weburl.GnoURL,
 		return h.GetHelpView(ctx, gnourl)
 	}
 
+	// Handle History page
+	if gnourl.WebQuery.Has("history") && gnourl.IsRealm() {
+		return h.GetHistoryView(ctx, gnourl)
+	}
+
 	// Handle Source page
 	if gnourl.WebQuery.Has("source") || gnourl.IsFile() {
 		return h.GetSourceView(ctx, gnourl)
@@ -315,6 +353,44 @@ func (h *HTTPHandler) GetRealmView(ctx context.Context, gnourl *weburl.GnoURL, i
 	})
 }
 
+// GetHistoryView renders the page of the transactions of a realm given by the
+// `page` web query.
+func (h *HTTPHandler) GetHistoryView(ctx context.Context, gnourl *weburl.GnoURL) (int, *components.View) {
+	if h.History == nil {
+		return http.StatusNotFound, components.StatusErrorComponent("history unavailable")
+	}
+
+	page := 1
+	if p := gnourl.WebQuery.Get("page"); p != "" {
+		var err error
+		if page, err = strconv.Atoi(p); err != nil || page < 1 {
+			return http.StatusBadRequest, components.StatusErrorComponent("invalid page")
+		}
+	}
+
+	txs, more, err := h.History.History(ctx, gnourl.Path, page, DefaultHistoryPerPage)
+	switch {
+	case err == nil: // ok
+	case errors.Is(err, ErrHistoryUnavailable):
+		h.Logger.Warn("history unavailable", "error", err, "path", gnourl.EncodeURL())
+		return http.StatusServiceUnavailable, components.StatusErrorComponent(ErrHistoryUnavailable.Error())
+	case errors.Is(err, ErrHistoryPageNotFound):
+		return http.StatusNotFound, components.StatusErrorComponent(ErrHistoryPageNotFound.Error())
+	default:
+		h.Logger.Error("unable to fetch history", "error", err, "path", gnourl.EncodeURL())
+		return GetClientErrorStatusPage(gnourl, err)
+	}
+
+	return http.StatusOK, components.HistoryView(components.HistoryData{
+		RealmName: path.Base(gnourl.Path),
+		PkgPath:   gnourl.Path,
+		Txs:       txs,
+		More:      more,
+		Page:      page,
+		PerPage:   DefaultHistoryPerPage,
+	})
+}
+
 // buildContributions returns the sorted list of contributions (packages and realms) for a user.
 func (h *HTTPHandler) buildContributions(ctx context.Context, username string) ([]components.UserContribution, int, error) {
 	prefix := "@" + username
@@ -421,18 +497,7 @@ func (h *HTTPHandler) GetHelpView(ctx context.Context, gnourl *weburl.GnoURL) (i
 	}
 
 	// Get public non-method funcs
-	fsigs := []*doc.JSONFunc{}
-	for _, fun := range jdoc.Funcs {
-		if !(fun.Type == "" && token.IsExported(fun.Name)) {
-			continue
-		}
-
-		if len(fun.Params) >= 1 && fun.Params[0].Type == "realm" {
-			// Don't make an entry field for "cur realm". The signature will still show it.
-			fun.Params = fun.Params[1:]
-		}
-		fsigs = append(fsigs, fun)
-	}
+	fsigs := callableFuncs(jdoc)
 
 	// Get selected function
 	selArgs := make(map[string]string)
@@ -15,9 +15,12 @@ import (
 	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb"
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
 	md "github.com/gnolang/gno/gno.land/pkg/gnoweb/markdown"
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -39,6 +42,7 @@ type stubClient struct {
 	docFunc       func(ctx context.Context, path string) (*doc.JSONDocumentation, error)
 	listFilesFunc func(ctx context.Context, path string) ([]string, error)
 	listPathsFunc func(ctx context.Context, prefix string, limit int) ([]string, error)
+	accountFunc   func(ctx context.Context, addr crypto.Address) (*std.BaseAccount, error)
 }
 
 func (s *stubClient) Realm(ctx context.Context, path, args string) ([]byte, error) {
@@ -76,6 +80,13 @@ func (s *stubClient) ListPaths(ctx context.Context, prefix string, limit int) ([
 	return nil, errors.New("stubClient: ListPaths not implemented")
 }
 
+func (s *stubClient) Account(ctx context.Context, addr crypto.Address) (*std.BaseAccount, error) {
+	if s.accountFunc != nil {
+		return s.accountFunc(ctx, addr)
+	}
+	return nil, errors.New("stubClient: Account not implemented")
+}
+
 type rawRenderer struct{}
 
 func (rawRenderer) RenderRealm(w io.Writer, u *weburl.GnoURL, src []byte) (md.Toc, error) {
@@ -216,6 +227,147 @@ func TestHTTPHandler_NoRender(t *testing.T) {
 	assert.Contains(t, rr.Body.String(), "render.gno", "rendered body should contain the file list (render.gno)")
 }
 
+// historyClientFunc is a gnoweb.HistoryClient calling itself.
+type historyClientFunc func(ctx context.Context, path string, page, perPage int) ([]components.HistoryTx, bool, error)
+
+func (f historyClientFunc) History(ctx context.Context, path string, page, perPage int) ([]components.HistoryTx, bool, error) {
+	return f(ctx, path, page, perPage)
+}
+
+// TestHTTPHandler_GetHistoryView tests the history view of realms.
+func TestHTTPHandler_GetHistoryView(t *testing.T) {
+	t.Parallel()
+
+	history := make([]components.HistoryTx, gnoweb.DefaultHistoryPerPage+1)
+	for i := range history {
+		history[i] = components.HistoryTx{Hash: fmt.Sprintf("tx-hash-%d", i), Height: int64(100 - i)}
+	}
+	history[0].Caller = "g1caller"
+	history[0].Msgs = []components.HistoryMsg{{Type: "exec", Func: "Transfer", Args: []string{"g1dest", "10"}}}
+	history[0].Events = []components.HistoryEvent{{
+		Type:  "Transferred",
+		Attrs: []components.HistoryEventAttr{{Key: "to", Value: "g1dest"}},
+	}}
+
+	mockPackage := &gnoweb.MockPackage{
+		Domain:  "example.com",
+		Path:    "/r/mock/path",
+		History: history,
+	}
+	client := gnoweb.NewMockClient(mockPackage)
+
+	logger := slog.New(slog.NewTextHandler(&testingLogger{t}, &slog.HandlerOptions{}))
+	newHandler := func(t *testing.T, history gnoweb.HistoryClient) *gnoweb.HTTPHandler {
+		t.Helper()
+
+		config := newTestHandlerConfig(t, client)
+		config.HistoryClient = history
+		handler, err := gnoweb.NewHTTPHandler(logger, config)
+		require.NoError(t, err)
+		return handler
+	}
+
+	cases := []struct {
+		Name     string
+		History  gnoweb.HistoryClient
+		Path     string
+		Status   int
+		Contain  []string
+		NotExist []string
+	}{
+		{
+			Name:    "first page",
+			History: client,
+			Path:    "/r/mock/path$history",
+			Status:  http.StatusOK,
+			Contain: []string{
+				"tx-hash-0", "g1caller", "Transfer", "Transferred", "g1dest",
+				"/r/mock/path$history&amp;page=2", // next page
+			},
+			NotExist: []string{"tx-hash-20"},
+		},
+		{
+			Name:     "last page",
+			History:  client,
+			Path:     "/r/mock/path$history&page=2",
+			Status:   http.StatusOK,
+			Contain:  []string{"tx-hash-20", "/r/mock/path$history&amp;page=1"},
+			NotExist: []string{"tx-hash-0\"", "page=3"},
+		},
+		{
+			Name:    "no transaction",
+			History: client,
+			Path:    "/r/mock/other$history",
+			Status:  http.StatusOK,
+			Contain: []string{"No transaction found"},
+		},
+		{
+			Name:    "page not found",
+			History: client,
+			Path:    "/r/mock/path$history&page=3",
+			Status:  http.StatusNotFound,
+			Contain: []string{"history page not found"},
+		},
+		{
+			Name:    "invalid page",
+			History: client,
+			Path:    "/r/mock/path$history&page=foo",
+			Status:  http.StatusBadRequest,
+			Contain: []string{"invalid page"},
+		},
+		{
+			Name:    "disabled",
+			Path:    "/r/mock/path$history",
+			Status:  http.StatusNotFound,
+			Contain: []string{"history unavailable"},
+		},
+		{
+			Name: "indexing disabled",
+			History: historyClientFunc(func(context.Context, string, int, int) ([]components.HistoryTx, bool, error) {
+				return nil, false, fmt.Errorf("%w: indexing is disabled", gnoweb.ErrHistoryUnavailable)
+			}),
+			Path:    "/r/mock/path$history",
+			Status:  http.StatusServiceUnavailable,
+			Contain: []string{"history unavailable"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, tc.Path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			newHandler(t, tc.History).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.Status, rr.Code)
+			for _, s := range tc.Contain {
+				assert.Contains(t, rr.Body.String(), s)
+			}
+			for _, s := range tc.NotExist {
+				assert.NotContains(t, rr.Body.String(), s)
+			}
+		})
+	}
+
+	t.Run("header link", func(t *testing.T) {
+		t.Parallel()
+
+		for _, history := range []gnoweb.HistoryClient{nil, client} {
+			req, err := http.NewRequest(http.MethodGet, "/r/mock/path$source", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			newHandler(t, history).ServeHTTP(rr, req)
+
+			// The link to the history is only shown if enabled.
+			assert.Equal(t, history != nil, strings.Contains(rr.Body.String(), `href="/r/mock/path$history"`))
+		}
+	})
+}
+
 // TestHTTPHandler_GetSourceDownload tests the source file download functionality
 func TestHTTPHandler_GetSourceDownload(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,177 @@
+package gnoweb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	gopath "path"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// DefaultHistoryPerPage is the number of transactions per page of the history
+// view.
+const DefaultHistoryPerPage = 20
+
+var (
+	ErrHistoryUnavailable  = errors.New("history unavailable")
+	ErrHistoryPageNotFound = errors.New("history page not found")
+)
+
+// HistoryClient fetches the history of the realms from a transaction indexer.
+type HistoryClient interface {
+	// History lists the transactions targeting the realm of the given
+	// path, the most recent first. Pages start at 1. It also returns
+	// whether more transactions follow the page: the indexer stops
+	// searching there, so their total number isn't known.
+	History(ctx context.Context, path string, page, perPage int) ([]components.HistoryTx, bool, error)
+}
+
+type rpcHistoryClient struct {
+	domain string
+	logger *slog.Logger
+	client *client.RPCClient
+}
+
+var _ HistoryClient = (*rpcHistoryClient)(nil)
+
+// NewRPCHistoryClient creates a HistoryClient backed by the transaction
+// indexer of the node, which must use the "kv" event store.
+func NewRPCHistoryClient(logger *slog.Logger, cli *client.RPCClient, domain string) HistoryClient {
+	return &rpcHistoryClient{
+		logger: logger,
+		domain: domain,
+		client: cli,
+	}
+}
+
+// History searches the transactions whose messages target the realm, using
+// the message.pkg_path attribute of the indexer.
+func (c *rpcHistoryClient) History(ctx context.Context, path string, page, perPage int) ([]components.HistoryTx, bool, error) {
+	pkgPath := gopath.Join(c.domain, strings.Trim(path, "/"))
+	cond := query.Condition{Key: eventstore.MessagePkgPathKey, Op: query.OpEqual, Operand: pkgPath}
+
+	start := time.Now()
+	res, err := c.client.TxSearch(ctx, cond.String(), page, perPage, "desc")
+	took := time.Since(start)
+	if err != nil {
+		c.logger.Error("tx search request failed", "path", pkgPath, "page", page, "error", err, "took", took)
+
+		var rpcErr *rpctypes.RPCError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, false, fmt.Errorf("%w: %s", ErrClientTimeout, err.Error())
+		case errors.As(err, &rpcErr) && rpcErr.Code == ctypes.CodeIndexingDisabled:
+			return nil, false, fmt.Errorf("%w: %s", ErrHistoryUnavailable, err.Error())
+		case errors.As(err, &rpcErr) && rpcErr.Code == ctypes.CodePageOutOfRange:
+			return nil, false, fmt.Errorf("%w: %s", ErrHistoryPageNotFound, err.Error())
+		}
+
+		return nil, false, fmt.Errorf("%w: %s", ErrClientBadRequest, err.Error())
+	}
+
+	c.logger.Debug("tx search response received", "path", pkgPath, "page", page, "more", res.HasMore, "took", took)
+
+	txs := make([]components.HistoryTx, 0, len(res.Txs))
+	for _, rtx := range res.Txs {
+		txs = append(txs, historyTx(rtx, pkgPath))
+	}
+
+	return txs, res.HasMore, nil
+}
+
+// historyTx converts an indexed transaction to its history entry, keeping the
+// messages which target pkgPath, and the events it emitted.
+func historyTx(rtx *ctypes.ResultTx, pkgPath string) components.HistoryTx {
+	htx := components.HistoryTx{
+		Hash:    base64.StdEncoding.EncodeToString(rtx.Hash),
+		Height:  rtx.Height,
+		GasUsed: rtx.TxResult.GasUsed,
+		Result:  strings.TrimSpace(string(rtx.TxResult.Data)),
+	}
+
+	if rtx.TxResult.Error != nil {
+		htx.Result = ""
+		if htx.Error = rtx.TxResult.Log; htx.Error == "" {
+			htx.Error = rtx.TxResult.Error.Error()
+		}
+	}
+
+	var tx std.Tx
+	if err := amino.Unmarshal(rtx.Tx, &tx); err == nil {
+		if signers := tx.GetSigners(); len(signers) > 0 {
+			htx.Caller = signers[0].String()
+		}
+
+		for _, msg := range tx.GetMsgs() {
+			if hmsg, ok := historyMsg(msg, pkgPath); ok {
+				htx.Msgs = append(htx.Msgs, hmsg)
+			}
+		}
+	}
+
+	for _, ev := range rtx.TxResult.Events {
+		if hev, ok := historyEvent(ev); ok && hev.PkgPath == pkgPath {
+			htx.Events = append(htx.Events, hev)
+		}
+	}
+
+	return htx
+}
+
+// historyMsg converts a message of a transaction, if it targets pkgPath.
+func historyMsg(msg std.Msg, pkgPath string) (components.HistoryMsg, bool) {
+	switch msg := msg.(type) {
+	case vm.MsgCall:
+		if msg.PkgPath != pkgPath {
+			return components.HistoryMsg{}, false
+		}
+		return components.HistoryMsg{
+			Type: msg.Type(),
+			Func: msg.Func,
+			Args: msg.Args,
+			Send: msg.Send.String(),
+		}, true
+	case vm.MsgAddPackage:
+		if msg.Package == nil || msg.Package.Path != pkgPath {
+			return components.HistoryMsg{}, false
+		}
+		return components.HistoryMsg{
+			Type: msg.Type(),
+			Send: msg.Send.String(),
+		}, true
+	default:
+		return components.HistoryMsg{}, false
+	}
+}
+
+// historyEvent converts an event emitted by a transaction, decoded the same way
+// the indexer does. Its package path is shown apart from its attributes.
+func historyEvent(ev abci.Event) (components.HistoryEvent, bool) {
+	dev, ok := eventstore.DecodeEvent(ev)
+	if !ok {
+		return components.HistoryEvent{}, false
+	}
+
+	hev := components.HistoryEvent{Type: dev.Type, PkgPath: dev.PkgPath}
+	for _, attr := range dev.Attrs {
+		if attr.Key == "pkg_path" {
+			continue
+		}
+		hev.Attrs = append(hev.Attrs, components.HistoryEventAttr{Key: attr.Key, Value: attr.Value})
+	}
+	return hev, true
+}
@@ -0,0 +1,117 @@
+package gnoweb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryTx(t *testing.T) {
+	t.Parallel()
+
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			vm.NewMsgCall(caller, std.MustParseCoins("100ugnot"), "gno.land/r/demo/foo", "Transfer", []string{"g1dest", "10"}),
+			vm.NewMsgCall(caller, nil, "gno.land/r/demo/bar", "Other", nil),
+		},
+		Fee: std.NewFee(1000, std.MustParseCoin("1ugnot")),
+	}
+	txbz, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	rtx := &ctypes.ResultTx{
+		Hash:   []byte("hash"),
+		Height: 42,
+		Tx:     txbz,
+		TxResult: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Data: []byte("(true bool)\n"),
+				Events: []abci.Event{
+					gnostd.GnoEvent{
+						Type:    "Transfer",
+						PkgPath: "gno.land/r/demo/foo",
+						Attributes: []gnostd.GnoEventAttribute{
+							{Key: "to", Value: "g1dest"},
+							{Key: "amount", Value: "10"},
+						},
+					},
+					gnostd.StorageDepositEvent{
+						BytesDelta: 12,
+						PkgPath:    "gno.land/r/demo/foo",
+					},
+					gnostd.GnoEvent{
+						Type:    "Other",
+						PkgPath: "gno.land/r/demo/bar",
+					},
+				},
+			},
+			GasUsed: 1234,
+		},
+	}
+
+	assert.Equal(t, components.HistoryTx{
+		Hash:   base64.StdEncoding.EncodeToString([]byte("hash")),
+		Height: 42,
+		Caller: caller.String(),
+		Msgs: []components.HistoryMsg{
+			{Type: "exec", Func: "Transfer", Args: []string{"g1dest", "10"}, Send: "100ugnot"},
+		},
+		Events: []components.HistoryEvent{
+			{
+				Type:    "Transfer",
+				PkgPath: "gno.land/r/demo/foo",
+				Attrs: []components.HistoryEventAttr{
+					{Key: "to", Value: "g1dest"},
+					{Key: "amount", Value: "10"},
+				},
+			},
+			{
+				Type:    "StorageDepositEvent",
+				PkgPath: "gno.land/r/demo/foo",
+				Attrs:   []components.HistoryEventAttr{{Key: "bytes_delta", Value: "12"}},
+			},
+		},
+		Result:  "(true bool)",
+		GasUsed: 1234,
+	}, historyTx(rtx, "gno.land/r/demo/foo"))
+
+	// A failed transaction has no result, but an error.
+	rtx.TxResult.Error = std.UnauthorizedError{}
+	rtx.TxResult.Log = "unauthorized: not the admin"
+	htx := historyTx(rtx, "gno.land/r/demo/foo")
+	assert.Empty(t, htx.Result)
+	assert.Equal(t, "unauthorized: not the admin", htx.Error)
+}
+
+func TestMockClient_History(t *testing.T) {
+	t.Parallel()
+
+	txs := []components.HistoryTx{{Hash: "3"}, {Hash: "2"}, {Hash: "1"}}
+	client := NewMockClient(&MockPackage{Path: "/r/demo/foo", History: txs})
+
+	page, more, err := client.History(context.Background(), "/r/demo/foo", 1, 2)
+	require.NoError(t, err)
+	assert.True(t, more)
+	assert.Equal(t, txs[:2], page)
+
+	page, more, err = client.History(context.Background(), "/r/demo/foo", 2, 2)
+	require.NoError(t, err)
+	assert.False(t, more)
+	assert.Equal(t, txs[2:], page)
+
+	_, _, err = client.History(context.Background(), "/r/demo/foo", 3, 2)
+	assert.True(t, errors.Is(err, ErrHistoryPageNotFound))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/yuin/goldmark"
@@ -36,6 +37,7 @@ var (
 	ErrFormInputMissingName       = errors.New(tagGnoInput + " must have a 'name' attribute")
 	ErrFormInvalidInputType       = errors.New("invalid input type")
 	ErrFormInputNameAlreadyUsed   = errors.New("input name already used")
+	ErrFormFuncWithPath           = errors.New(tagGnoForm + " cannot have both 'func' and 'path' attributes")
 )
 
 // Whitelist of allowed input types
@@ -148,6 +150,8 @@ type FormNode struct {
 	ElementsName map[string]bool
 	RenderPath   string // Path to render after form submission
 	RealmName    string
+	Func         string // Function to call, for transaction forms
+	Send         string // Coins to send with the call, for transaction forms
 }
 
 func NewFormNode() *FormNode {
@@ -164,6 +168,10 @@ func (n *FormNode) Dump(source []byte, level int) {
 		"path": n.RenderPath,
 		"name": n.RealmName,
 	}
+	if n.Func != "" {
+		kv["func"] = n.Func
+		kv["send"] = n.Send
+	}
 
 	for i, element := range n.Elements {
 		switch e := element.(type) {
@@ -261,6 +269,13 @@ func (p *formParser) Open(parent ast.Node, reader text.Reader, pc parser.Context
 	}
 
 	fn.RenderPath, _ = ExtractAttr(tok.Attr, "path")
+	fn.Func, _ = ExtractAttr(tok.Attr, "func")
+	fn.Send, _ = ExtractAttr(tok.Attr, "send")
+	if fn.Func != "" && fn.RenderPath != "" {
+		fn.Error = ErrFormFuncWithPath
+		return fn, parser.NoChildren
+	}
+
 	if gnourl, ok := getUrlFromContext(pc); ok {
 		fn.RealmName = gnourl.Path // Use full path instead of just namespace
 	}
@@ -483,17 +498,36 @@ func (r *formRenderer) render(w util.BufWriter, source []byte, node ast.Node, en
 
 	// Form action must include the full path
 	formAction := n.RealmName // start with /r/docs/markdown
-	if n.RenderPath != "" {
+	switch {
+	case n.Func != "":
+		// A transaction form is submitted to a browser wallet by the
+		// wallet bridge. Without any wallet, it falls back on the
+		// help page of the function, filled with the form data.
+		formAction += "$help&func=" + url.QueryEscape(n.Func)
+	case n.RenderPath != "":
 		formAction += ":" + strings.TrimPrefix(n.RenderPath, "/")
 	}
 
 	// Render form opening and header
-	fmt.Fprintf(w, `<form class="gno-form" method="post" action="%s" autocomplete="off" spellcheck="false">`+"\n", HTMLEscapeString(formAction))
+	if n.Func != "" {
+		fmt.Fprintf(w, `<form class="gno-form" method="post" action="%s" data-tx-path="%s" data-tx-func="%s" autocomplete="off" spellcheck="false">`+"\n",
+			HTMLEscapeString(formAction),
+			HTMLEscapeString(n.RealmName),
+			HTMLEscapeString(n.Func))
+	} else {
+		fmt.Fprintf(w, `<form class="gno-form" method="post" action="%s" autocomplete="off" spellcheck="false">`+"\n", HTMLEscapeString(formAction))
+	}
 	fmt.Fprintln(w, `<div class="gno-form_header">`)
 	fmt.Fprintf(w, `<span><span class="font-bold">%s</span> Form</span>`+"\n", HTMLEscapeString(n.RealmName))
 	fmt.Fprintf(w, `<span class="tooltip" data-tooltip="Processed securely by %s"><svg class="w-3 h-3"><use href="#ico-info"></use></svg></span>`+"\n", HTMLEscapeString(n.RealmName))
 	fmt.Fprintln(w, `</div>`)
 
+	if n.Send != "" {
+		fmt.Fprintf(w, `<div class="gno-form_description">This call sends %s to %s</div>`+"\n",
+			HTMLEscapeString(n.Send), HTMLEscapeString(n.RealmName))
+		fmt.Fprintf(w, `<input type="hidden" name=".send" value="%s" />`+"\n", HTMLEscapeString(n.Send))
+	}
+
 	// Render all form elements in order of appearance
 	lastDescID := "" // Track the last description ID for aria-labelledby fallback
 
@@ -638,8 +672,12 @@ func (r *formRenderer) render(w util.BufWriter, source []byte, node ast.Node, en
 		}
 	}
 
-	// Display submit button only if there is at least one input or textarea
-	if len(n.Elements) > 0 {
+	// Display submit button only if there is at least one input or textarea,
+	// or if the form calls a function, which may have no parameters
+	switch {
+	case n.Func != "":
+		fmt.Fprintf(w, `<input type="submit" value="Call %s on %s Realm" />`+"\n", HTMLEscapeString(n.Func), HTMLEscapeString(n.RealmName))
+	case len(n.Elements) > 0:
 		fmt.Fprintf(w, `<input type="submit" value="Submit to %s Realm" />`+"\n", HTMLEscapeString(n.RealmName))
 	}
 
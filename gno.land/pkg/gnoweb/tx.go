@@ -0,0 +1,213 @@
+package gnoweb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Default fee of the transactions, matching the commands of the help page.
+const (
+	DefaultTxGasWanted = 5_000_000
+	DefaultTxGasFee    = "1000000ugnot"
+)
+
+var (
+	ErrTxFuncNotFound = errors.New("function not found")
+	ErrTxInvalidQuery = errors.New("invalid transaction query")
+)
+
+// TxResponse is the response of the `$tx` endpoint: an unsigned transaction
+// calling a realm function, and what a wallet needs to sign it.
+type TxResponse struct {
+	ChainID string `json:"chain_id"`
+	Remote  string `json:"remote"`
+
+	// Tx is the unsigned std.Tx, in amino JSON.
+	Tx json.RawMessage `json:"tx"`
+
+	// SignBytes is the exact payload to sign, i.e. the std.SignDoc of the
+	// transaction in sorted amino JSON. It is only set when the caller is
+	// known, as it depends on its account number and sequence.
+	SignBytes []byte `json:"sign_bytes,omitempty"`
+}
+
+// ServeTx serves, as JSON, an unsigned transaction calling the function given
+// by the `func` web query, with the arguments given by parameter name, e.g.
+// `/r/demo/foo$tx&func=Transfer&to=g1...&amount=10`.
+//
+// Only realm functions can be called. The optional `.caller` and `.send` web
+// queries set the corresponding fields of the message; any other query starting
+// with a dot is rejected, so that a form can't set the fee or the memo of the
+// transaction, which use the defaults. The payload to sign is only returned
+// when the caller is given; otherwise, the wallet has to fill the caller and
+// build it from the account number and sequence of the caller.
+func (h *HTTPHandler) ServeTx(ctx context.Context, gnourl *weburl.GnoURL, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+
+	res, err := h.buildTx(ctx, gnourl)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrTxInvalidQuery):
+			status = http.StatusBadRequest
+		case errors.Is(err, ErrTxFuncNotFound),
+			errors.Is(err, ErrClientPackageNotFound),
+			errors.Is(err, ErrClientAccountNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrClientTimeout):
+			status = http.StatusRequestTimeout
+		default:
+			h.Logger.Error("unable to build transaction", "path", gnourl.EncodeWebURL(), "error", err)
+			err = errors.New("internal error")
+		}
+
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(struct {
+			Error string `json:"error"`
+		}{err.Error()}); err != nil {
+			h.Logger.Error("unable to write transaction error", "path", gnourl.EncodeWebURL(), "error", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		h.Logger.Error("unable to write transaction", "path", gnourl.EncodeWebURL(), "error", err)
+	}
+}
+
+// buildTx builds the transaction of the `$tx` endpoint.
+func (h *HTTPHandler) buildTx(ctx context.Context, gnourl *weburl.GnoURL) (*TxResponse, error) {
+	if !gnourl.IsRealm() {
+		return nil, fmt.Errorf("%w: %q isn't a realm", ErrTxInvalidQuery, gnourl.Path)
+	}
+
+	query := gnourl.WebQuery
+	for key := range query {
+		if strings.HasPrefix(key, ".") && key != ".caller" && key != ".send" {
+			return nil, fmt.Errorf("%w: %q can't be set", ErrTxInvalidQuery, key)
+		}
+	}
+
+	fn, err := h.callableFunc(ctx, gnourl)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, len(fn.Params))
+	for i, param := range fn.Params {
+		args[i] = query.Get(param.Name)
+	}
+
+	msg := vm.MsgCall{
+		PkgPath: path.Join(h.Static.Domain, gnourl.Path),
+		Func:    fn.Name,
+		Args:    args,
+	}
+
+	if send := query.Get(".send"); send != "" {
+		if msg.Send, err = std.ParseCoins(send); err != nil {
+			return nil, fmt.Errorf("%w: send %q: %w", ErrTxInvalidQuery, send, err)
+		}
+	}
+
+	fee := std.NewFee(DefaultTxGasWanted, std.MustParseCoin(DefaultTxGasFee))
+	sdoc := std.SignDoc{
+		ChainID: h.Static.ChainId,
+		Fee:     fee,
+	}
+
+	caller := query.Get(".caller")
+	if caller != "" {
+		if msg.Caller, err = crypto.AddressFromBech32(caller); err != nil {
+			return nil, fmt.Errorf("%w: caller %q: %w", ErrTxInvalidQuery, caller, err)
+		}
+
+		acc, err := h.Client.Account(ctx, msg.Caller)
+		if err != nil {
+			return nil, fmt.Errorf("caller %q: %w", caller, err)
+		}
+		sdoc.AccountNumber, sdoc.Sequence = acc.AccountNumber, acc.Sequence
+	}
+
+	tx := std.Tx{
+		Msgs: []std.Msg{msg},
+		Fee:  fee,
+	}
+	sdoc.Msgs = tx.Msgs
+
+	txbz, err := amino.MarshalJSON(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal tx: %w", err)
+	}
+
+	res := &TxResponse{
+		ChainID: h.Static.ChainId,
+		Remote:  h.Static.RemoteHelp,
+		Tx:      txbz,
+	}
+
+	if caller != "" {
+		if res.SignBytes, err = std.GetSignaturePayload(sdoc); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// callableFunc returns the callable function of the package of gnourl given
+// by the `func` web query.
+func (h *HTTPHandler) callableFunc(ctx context.Context, gnourl *weburl.GnoURL) (*doc.JSONFunc, error) {
+	fname := gnourl.WebQuery.Get("func")
+	if fname == "" {
+		return nil, fmt.Errorf("%w: missing function", ErrTxInvalidQuery)
+	}
+
+	jdoc, err := h.Client.Doc(ctx, gnourl.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fn := range callableFuncs(jdoc) {
+		if fn.Name == fname {
+			return fn, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrTxFuncNotFound, fname)
+}
+
+// callableFuncs returns the functions of a package which can be called by a
+// transaction, i.e. its exported non-method functions. The realm parameter
+// of crossing functions is removed, as it isn't given by the caller.
+func callableFuncs(jdoc *doc.JSONDocumentation) []*doc.JSONFunc {
+	fsigs := []*doc.JSONFunc{}
+	for _, fun := range jdoc.Funcs {
+		if !(fun.Type == "" && token.IsExported(fun.Name)) {
+			continue
+		}
+
+		if len(fun.Params) >= 1 && fun.Params[0].Type == "realm" {
+			// Don't make an entry field for "cur realm". The signature will still show it.
+			fn := *fun // copy, the doc may be shared
+			fn.Params = fun.Params[1:]
+			fun = &fn
+		}
+		fsigs = append(fsigs, fun)
+	}
+
+	return fsigs
+}
@@ -0,0 +1,213 @@
+package gnoweb_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_ServeTx(t *testing.T) {
+	t.Parallel()
+
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	unknown := crypto.AddressFromPreimage([]byte("unknown"))
+
+	mockPackage := &gnoweb.MockPackage{
+		Domain: "example.com",
+		Path:   "/r/mock/path",
+		Functions: []*doc.JSONFunc{
+			{Name: "Transfer", Params: []*doc.JSONField{
+				{Name: "cur", Type: "realm"},
+				{Name: "to", Type: "address"},
+				{Name: "amount", Type: "int"},
+			}},
+			{Name: "Render", Params: []*doc.JSONField{{Name: "path", Type: "string"}}, Results: []*doc.JSONField{{Name: "", Type: "string"}}},
+			{Name: "Method", Type: "Foo"},
+			{Name: "private"},
+		},
+	}
+
+	client := gnoweb.NewMockClient(mockPackage)
+	client.Accounts = map[crypto.Address]*std.BaseAccount{
+		caller: {Address: caller, AccountNumber: 42, Sequence: 7},
+	}
+
+	config := newTestHandlerConfig(t, client)
+	config.Meta = gnoweb.StaticMetadata{
+		Domain:     "example.com",
+		ChainId:    "test-chain",
+		RemoteHelp: "127.0.0.1:26657",
+	}
+
+	logger := slog.New(slog.NewTextHandler(&testingLogger{t}, &slog.HandlerOptions{}))
+	handler, err := gnoweb.NewHTTPHandler(logger, config)
+	require.NoError(t, err)
+
+	serve := func(t *testing.T, path string) (int, []byte) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		return rr.Code, rr.Body.Bytes()
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		t.Parallel()
+
+		status, body := serve(t, "/r/mock/path$tx&func=Transfer&amount=10&to=g1dest&.send=100ugnot")
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var res gnoweb.TxResponse
+		require.NoError(t, json.Unmarshal(body, &res))
+		assert.Equal(t, "test-chain", res.ChainID)
+		assert.Equal(t, "127.0.0.1:26657", res.Remote)
+
+		var tx std.Tx
+		require.NoError(t, amino.UnmarshalJSON(res.Tx, &tx))
+		assert.Equal(t, std.NewFee(gnoweb.DefaultTxGasWanted, std.MustParseCoin(gnoweb.DefaultTxGasFee)), tx.Fee)
+		assert.Empty(t, tx.Memo)
+		assert.Empty(t, tx.Signatures)
+		require.Len(t, tx.Msgs, 1)
+
+		// The arguments follow the order of the parameters, without
+		// the realm parameter.
+		assert.Equal(t, vm.MsgCall{
+			Send:    std.MustParseCoins("100ugnot"),
+			PkgPath: "example.com/r/mock/path",
+			Func:    "Transfer",
+			Args:    []string{"g1dest", "10"},
+		}, tx.Msgs[0])
+
+		// Without caller, the payload to sign is left to the wallet,
+		// as the account number and sequence are unknown.
+		assert.Nil(t, res.SignBytes)
+		assert.NotContains(t, string(body), "sign_bytes")
+	})
+
+	t.Run("caller", func(t *testing.T) {
+		t.Parallel()
+
+		status, body := serve(t, "/r/mock/path$tx&func=Render&path=foo&.caller="+caller.String())
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var res gnoweb.TxResponse
+		require.NoError(t, json.Unmarshal(body, &res))
+
+		var tx std.Tx
+		require.NoError(t, amino.UnmarshalJSON(res.Tx, &tx))
+		assert.Equal(t, std.NewFee(gnoweb.DefaultTxGasWanted, std.MustParseCoin(gnoweb.DefaultTxGasFee)), tx.Fee)
+		assert.Equal(t, caller, tx.Msgs[0].(vm.MsgCall).Caller)
+
+		expected, err := std.GetSignaturePayload(std.SignDoc{
+			ChainID:       "test-chain",
+			AccountNumber: 42,
+			Sequence:      7,
+			Fee:           tx.Fee,
+			Msgs:          tx.Msgs,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.SignBytes)
+	})
+
+	errorCases := []struct {
+		Path    string
+		Status  int
+		Contain string
+	}{
+		{"/r/mock/path$tx", http.StatusBadRequest, "missing function"},
+		{"/r/mock/path$tx&func=Method", http.StatusNotFound, "function not found"},
+		{"/r/mock/path$tx&func=private", http.StatusNotFound, "function not found"},
+		{"/r/mock/other$tx&func=Transfer", http.StatusNotFound, "package not found"},
+		{"/r/mock/path$tx&func=Render&.send=foo", http.StatusBadRequest, "send"},
+		// The fee and memo can't be set, e.g. by the inputs of a form.
+		{"/r/mock/path$tx&func=Render&.gas-wanted=100", http.StatusBadRequest, `".gas-wanted" can't be set`},
+		{"/r/mock/path$tx&func=Render&.gas-fee=10ugnot", http.StatusBadRequest, `".gas-fee" can't be set`},
+		{"/r/mock/path$tx&func=Render&.memo=hello", http.StatusBadRequest, `".memo" can't be set`},
+		{"/p/mock/path$tx&func=Render", http.StatusBadRequest, "isn't a realm"},
+		{"/r/mock/path$tx&func=Render&.caller=g1invalid", http.StatusBadRequest, "caller"},
+		{"/r/mock/path$tx&func=Render&.caller=" + unknown.String(), http.StatusNotFound, "account not found"},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(strings.TrimPrefix(tc.Path, "/"), func(t *testing.T) {
+			t.Parallel()
+
+			status, body := serve(t, tc.Path)
+			assert.Equal(t, tc.Status, status)
+
+			var res struct{ Error string }
+			require.NoError(t, json.Unmarshal(body, &res))
+			assert.Contains(t, res.Error, tc.Contain)
+		})
+	}
+}
+
+func TestHTTPHandler_PostHelpForm(t *testing.T) {
+	t.Parallel()
+
+	mockPackage := &gnoweb.MockPackage{
+		Domain: "example.com",
+		Path:   "/r/mock/path",
+		Functions: []*doc.JSONFunc{
+			{Name: "Transfer", Params: []*doc.JSONField{
+				{Name: "cur", Type: "realm"},
+				{Name: "to", Type: "address"},
+				{Name: "amount", Type: "int"},
+			}},
+		},
+	}
+	config := newTestHandlerConfig(t, gnoweb.NewMockClient(mockPackage))
+
+	logger := slog.New(slog.NewTextHandler(&testingLogger{t}, &slog.HandlerOptions{}))
+	handler, err := gnoweb.NewHTTPHandler(logger, config)
+	require.NoError(t, err)
+
+	cases := []struct {
+		Path     string
+		Form     string
+		Location string
+	}{
+		// The form data of a render form is used as the query.
+		{"/r/mock/path:foo", "to=g1dest&amount=10", "/r/mock/path:foo?amount=10&to=g1dest"},
+		// The form data of a call form is used as the function arguments.
+		{"/r/mock/path$help&func=Transfer", "to=g1dest&amount=10", "/r/mock/path$amount=10&func=Transfer&help&to=g1dest"},
+		{"/r/mock/path$help&func=Transfer", ".send=5ugnot&to=g1dest", "/r/mock/path$.send=5ugnot&func=Transfer&help&to=g1dest"},
+		// Other form data is dropped, so it can't turn the page into
+		// another endpoint.
+		{"/r/mock/path$help&func=Transfer", "to=g1dest&tx=1&source=1", "/r/mock/path$func=Transfer&help&to=g1dest"},
+		{"/r/mock/path$help&func=Unknown", "to=g1dest&tx=1", "/r/mock/path$func=Unknown&help"},
+	}
+
+	for _, tc := range cases {
+		t.Run(strings.TrimPrefix(tc.Path, "/")+"/"+tc.Form, func(t *testing.T) {
+			t.Parallel()
+
+			form := strings.NewReader(tc.Form)
+			req, err := http.NewRequest(http.MethodPost, tc.Path, form)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusSeeOther, rr.Code)
+			assert.Equal(t, tc.Location, rr.Header().Get("Location"))
+		})
+	}
+}
@@ -79,11 +79,9 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	if cfg.PkgDir == "" {
 		return errors.New("pkgdir not specified")
 	}
-	if cfg.RootCfg.GasWanted == 0 {
-		return errors.New("gas-wanted not specified")
-	}
-	if cfg.RootCfg.GasFee == "" {
-		return errors.New("gas-fee not specified")
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
 	}
 
 	if len(args) != 1 {
@@ -119,12 +117,6 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 		panic(fmt.Sprintf("found an empty package %q", cfg.PkgPath))
 	}
 
-	// parse gas wanted & fee.
-	gaswanted := cfg.RootCfg.GasWanted
-	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
-	if err != nil {
-		panic(err)
-	}
 	// construct msg & tx and marshal.
 	msg := vm.MsgAddPackage{
 		Creator:    creator,
@@ -134,7 +126,7 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
@@ -88,11 +88,9 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 	if len(args) != 1 {
 		return flag.ErrHelp
 	}
-	if cfg.RootCfg.GasWanted == 0 {
-		return errors.New("gas-wanted not specified")
-	}
-	if cfg.RootCfg.GasFee == "" {
-		return errors.New("gas-fee not specified")
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
 	}
 
 	// read statement.
@@ -123,13 +121,6 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 		return errors.Wrap(err, "parsing storage deposit coins")
 	}
 
-	// parse gas wanted & fee.
-	gaswanted := cfg.RootCfg.GasWanted
-	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
-	if err != nil {
-		return errors.Wrap(err, "parsing gas fee coin")
-	}
-
 	// construct msg & tx and marshal.
 	msg := vm.MsgCall{
 		Caller:     caller,
@@ -141,7 +132,7 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
@@ -35,6 +35,8 @@ func NewMakeTxCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		client.NewMakeSendCmd(cfg, io),
+		client.NewMakeGrantCmd(cfg, io),
+		client.NewMakeRevokeCmd(cfg, io),
 
 		// custom commands
 		NewMakeAddPkgCmd(cfg, io),
@@ -54,11 +54,9 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	if len(args) != 2 {
 		return flag.ErrHelp
 	}
-	if cfg.RootCfg.GasWanted == 0 {
-		return errors.New("gas-wanted not specified")
-	}
-	if cfg.RootCfg.GasFee == "" {
-		return errors.New("gas-fee not specified")
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
 	}
 
 	nameOrBech32 := args[0]
@@ -81,13 +79,6 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 		return errors.Wrap(err, "parsing storage deposit coins")
 	}
 
-	// parse gas wanted & fee.
-	gaswanted := cfg.RootCfg.GasWanted
-	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
-	if err != nil {
-		return errors.Wrap(err, "parsing gas fee coin")
-	}
-
 	memPkg := &std.MemPackage{}
 	if sourcePath == "-" { // stdin
 		data, err := io.ReadAll(cmdio.In())
@@ -137,7 +128,7 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
@@ -3,9 +3,12 @@ package vm
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -59,6 +62,49 @@ func (bnk *SDKBanker) RemoveCoin(b32addr crypto.Bech32Address, denom string, amo
 	}
 }
 
+// ----------------------------------------
+// SDKFeeAllowances
+
+// This implements FeeAllowancesInterface,
+// which is available as ExecContext.FeeAllowances.
+// The granter is checked by the Gno code of "chain/allowance".
+
+type SDKFeeAllowances struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKFeeAllowances(vmk *VMKeeper, ctx sdk.Context) *SDKFeeAllowances {
+	return &SDKFeeAllowances{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (fas *SDKFeeAllowances) GetFeeAllowance(b32granter, b32grantee crypto.Bech32Address) (std.Coins, time.Time, bool) {
+	granter := crypto.MustAddressFromString(string(b32granter))
+	grantee := crypto.MustAddressFromString(string(b32grantee))
+	a, ok := fas.vmk.acck.GetFeeAllowance(fas.ctx, granter, grantee)
+	return a.SpendLimit, a.Expiration, ok
+}
+
+func (fas *SDKFeeAllowances) GrantFeeAllowance(b32granter, b32grantee crypto.Bech32Address, spendLimit std.Coins, expiration time.Time) error {
+	granter := crypto.MustAddressFromString(string(b32granter))
+	grantee := crypto.MustAddressFromString(string(b32grantee))
+	a := auth.FeeAllowance{SpendLimit: spendLimit, Expiration: expiration}
+	// The error of the keeper doesn't tell what is wrong with the spend limit.
+	if a.ValidateBasic() != nil {
+		return fmt.Errorf("invalid spend limit %s", spendLimit)
+	}
+	return fas.vmk.acck.GrantFeeAllowance(fas.ctx, granter, grantee, a)
+}
+
+func (fas *SDKFeeAllowances) RevokeFeeAllowance(b32granter, b32grantee crypto.Bech32Address) error {
+	granter := crypto.MustAddressFromString(string(b32granter))
+	grantee := crypto.MustAddressFromString(string(b32grantee))
+	return fas.vmk.acck.RevokeFeeAllowance(fas.ctx, granter, grantee)
+}
+
 // ----------------------------------------
 // SDKParams
 
@@ -110,6 +156,44 @@ func (prm *SDKParams) SetStrings(key string, value []string) {
 	prm.pmk.SetStrings(prm.ctx, key, value)
 }
 
+func (prm *SDKParams) GetString(key string, ptr *string) (bool, error) {
+	return prm.get(key, ptr)
+}
+
+func (prm *SDKParams) GetBool(key string, ptr *bool) (bool, error) {
+	return prm.get(key, ptr)
+}
+
+func (prm *SDKParams) GetInt64(key string, ptr *int64) (bool, error) {
+	return prm.get(key, ptr)
+}
+
+func (prm *SDKParams) GetUint64(key string, ptr *uint64) (bool, error) {
+	return prm.get(key, ptr)
+}
+
+func (prm *SDKParams) GetBytes(key string, ptr *[]byte) (bool, error) {
+	return prm.get(key, ptr)
+}
+
+func (prm *SDKParams) GetStrings(key string, ptr *[]string) (bool, error) {
+	return prm.get(key, ptr)
+}
+
+// get decodes the param of the given key into ptr, and returns whether it is
+// set. Unlike the getters of the params keeper, it returns an error rather
+// than panicking if the param has another type.
+func (prm *SDKParams) get(key string, ptr any) (bool, error) {
+	bz := prm.pmk.GetRaw(prm.ctx, key)
+	if bz == nil {
+		return false, nil
+	}
+	if err := amino.UnmarshalJSON(bz, ptr); err != nil {
+		return false, fmt.Errorf("param %q has another type", key)
+	}
+	return true, nil
+}
+
 func (prm *SDKParams) willSetKeeperParams(ctx sdk.Context, key string, value any) {
 	parts := strings.Split(key, ":")
 	if len(parts) == 0 {
@@ -1,17 +1,45 @@
 package vm
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 // GenesisState - all state that must be provided at genesis
 type GenesisState struct {
 	Params      Params         `json:"params" yaml:"params"`
 	RealmParams []params.Param `json:"realm_params" yaml:"realm_params"`
+
+	// RawRealmParams, Packages and State are only set in genesis states
+	// exported from a live chain: they hold the realm params as stored, the
+	// deployed packages, in deployment order, and the raw store entries of
+	// their state. They are restored as they are, without running the
+	// packages again.
+	RawRealmParams []RawParam        `json:"raw_realm_params,omitempty" yaml:"raw_realm_params,omitempty"`
+	Packages       []*std.MemPackage `json:"packages,omitempty" yaml:"packages,omitempty"`
+	State          []StoreEntry      `json:"state,omitempty" yaml:"state,omitempty"`
+}
+
+// RawParam is a realm param with its value as stored by the params keeper.
+// The value is JSON, where int64, uint64 and bytes values look like strings,
+// so it has no type.
+type RawParam struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"` // JSON value
+}
+
+// StoreEntry is a raw key/value entry of the VM's stores.
+type StoreEntry struct {
+	IAVL  bool   `json:"iavl,omitempty" yaml:"iavl,omitempty"` // entry of the iavl store, rather than of the base store
+	Key   []byte `json:"key" yaml:"key"`
+	Value []byte `json:"value" yaml:"value"`
 }
 
 // NewGenesisState - Create a new genesis state
@@ -56,10 +84,70 @@ func (vm *VMKeeper) InitGenesis(ctx sdk.Context, gs GenesisState) {
 	for _, rp := range gs.RealmParams {
 		vm.prmk.SetAny(ctx, "vm:"+rp.Key, rp.Value)
 	}
+	for _, rp := range gs.RawRealmParams {
+		vm.prmk.SetRaw(ctx, "vm:"+rp.Key, []byte(rp.Value))
+	}
+
+	if len(gs.Packages) == 0 {
+		return
+	}
+	ctx = vm.MakeGnoTransactionStore(ctx)
+	gnostore := vm.getGnoTransactionStore(ctx)
+	for _, mpkg := range gs.Packages {
+		mptype, ok := mpkg.Type.(gno.MemPackageType)
+		if !ok {
+			panic(fmt.Errorf("invalid type %v of genesis package %q", mpkg.Type, mpkg.Path))
+		}
+		gnostore.AddMemPackage(mpkg, mptype)
+	}
+	baseStore, iavlStore := ctx.Store(vm.baseKey), ctx.Store(vm.iavlKey)
+	for _, entry := range gs.State {
+		if entry.IAVL {
+			iavlStore.Set(entry.Key, entry.Value)
+		} else {
+			baseStore.Set(entry.Key, entry.Value)
+		}
+	}
+	// Block nodes are not persisted, so the packages are preprocessed like
+	// on restart.
+	m := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath: "",
+			Output:  vm.Output,
+			Store:   gnostore,
+		})
+	defer m.Release()
+	for _, mpkg := range gs.Packages {
+		m.PreprocessFilesAndSaveBlockNodes(mpkg)
+	}
+	vm.CommitGnoTransactionStore(ctx)
 }
 
-// ExportGenesis returns a GenesisState for a given context and keeper
+// ExportGenesis returns a GenesisState for a given context and keeper.
+// Along with the params, it exports the realm params, and the deployed
+// packages with their state, so that they can be restored on another chain
+// with InitGenesis. The standard libraries are not exported, as each chain
+// loads its own.
 func (vm *VMKeeper) ExportGenesis(ctx sdk.Context) GenesisState {
-	params := vm.GetParams(ctx)
-	return NewGenesisState(params)
+	gs := NewGenesisState(vm.GetParams(ctx))
+
+	vm.prmk.IterateRaw(ctx, "vm:", func(key string, value []byte) bool {
+		key = strings.TrimPrefix(key, "vm:")
+		if !strings.HasPrefix(key, "p:") { // module params, exported above.
+			gs.RawRealmParams = append(gs.RawRealmParams, RawParam{Key: key, Value: string(value)})
+		}
+		return false
+	})
+
+	baseStore, iavlStore := ctx.Store(vm.baseKey), ctx.Store(vm.iavlKey)
+	gnostore := gno.NewStore(nil, baseStore, iavlStore)
+	for mpkg := range gnostore.IterMemPackage() {
+		if !gno.IsStdlib(mpkg.Path) {
+			gs.Packages = append(gs.Packages, mpkg)
+		}
+	}
+	gno.IterPackageState(baseStore, iavlStore, func(iavl bool, key, value []byte) {
+		gs.State = append(gs.State, StoreEntry{IAVL: iavl, Key: bytes.Clone(key), Value: bytes.Clone(value)})
+	})
+	return gs
 }
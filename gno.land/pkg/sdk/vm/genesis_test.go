@@ -0,0 +1,26 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportGenesisRealmParams(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	const key = "gno.land/r/test:foo"
+	env.prmk.SetInt64(ctx, "vm:"+key, 42)
+
+	gs := env.vmk.ExportGenesis(ctx)
+	assert.Equal(t, []RawParam{{Key: key, Value: `"42"`}}, gs.RawRealmParams)
+
+	// The value is restored as stored.
+	env2 := setupTestEnv()
+	env2.vmk.InitGenesis(env2.ctx, gs)
+	var val int64
+	env2.prmk.GetInt64(env2.ctx, "vm:"+key, &val)
+	assert.Equal(t, int64(42), val)
+	assert.Equal(t, env.prmk.GetRaw(ctx, "vm:"+key), env2.prmk.GetRaw(env2.ctx, "vm:"+key))
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -78,6 +79,7 @@ const (
 	QueryDoc     = "qdoc"
 	QueryPaths   = "qpaths"
 	QueryStorage = "qstorage"
+	QueryState   = "qstate"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -101,6 +103,8 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 		res = vh.queryPaths(ctx, req)
 	case QueryStorage:
 		res = vh.queryStorage(ctx, req)
+	case QueryState:
+		res = vh.queryState(ctx, req)
 	default:
 		return sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -255,6 +259,24 @@ func (vh vmHandler) queryStorage(ctx sdk.Context, req abci.RequestQuery) (res ab
 	return
 }
 
+// queryState returns the JSON of a page of the state of a package, to be
+// restored on another chain, such as a fork. The query data is the package
+// path, optionally followed by ":" and the key from which the page starts.
+func (vh vmHandler) queryState(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgpath, start, ok := strings.Cut(string(req.Data), ":")
+	var startKey []byte
+	if ok && start != "" {
+		startKey = []byte(start)
+	}
+	result, err := vh.vm.QueryState(ctx, pkgpath, startKey)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = amino.MustMarshalJSON(result)
+	return
+}
+
 // ----------------------------------------
 // misc
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -466,3 +467,74 @@ func Hello(msg string) (res string) { res = prefix+" "+msg; return }
 		})
 	}
 }
+
+func TestVmHandlerQuery_State(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// A realm, and a sub-realm whose objects and types must not be included.
+	for _, pkgPath := range []string{"gno.land/r/hello", "gno.land/r/hello/sub"} {
+		files := []*std.MemFile{
+			{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+			{Name: "hello.gno", Body: `package hello
+
+type Counter struct{ N int }
+
+var c = &Counter{N: 1}
+
+func Inc(cur realm) int { c.N++; return c.N }
+`},
+		}
+		err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+		assert.NoError(t, err)
+	}
+	msg := NewMsgCall(addr, nil, "gno.land/r/hello", "Inc", nil)
+	_, err := env.vmk.Call(ctx, msg)
+	assert.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	res := env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qstate", Data: []byte("gno.land/r/doesnotexist")})
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.ErrorIs(t, res.Error, InvalidPkgPathError{})
+
+	res = env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qstate", Data: []byte("gno.land/r/hello")})
+	if !assert.True(t, res.IsOK(), "should not have error: %s", res.Log) {
+		return
+	}
+	var ps PackageState
+	amino.MustUnmarshalJSON(res.Data, &ps)
+	assert.Equal(t, "gno.land/r/hello", ps.Package.Path)
+	assert.NotEmpty(t, ps.State)
+	assert.Nil(t, ps.Next)
+	for _, entry := range ps.State {
+		assert.NotContains(t, string(entry.Key), "hello/sub")
+	}
+
+	// The following pages start from the given key, without the package.
+	var start int
+	for start = 1; ps.State[start].IAVL; start++ {
+	}
+	res = env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qstate", Data: []byte("gno.land/r/hello:" + string(ps.State[start].Key))})
+	if !assert.True(t, res.IsOK(), "should not have error: %s", res.Log) {
+		return
+	}
+	var page PackageState
+	amino.MustUnmarshalJSON(res.Data, &page)
+	assert.Nil(t, page.Package)
+	assert.Equal(t, ps.State[start:], page.State)
+
+	// The package and its state are restored on another chain.
+	env2 := setupTestEnv()
+	gs := NewGenesisState(DefaultParams())
+	gs.Packages = []*std.MemPackage{ps.Package}
+	gs.State = ps.State
+	env2.vmk.InitGenesis(env2.ctx, gs)
+	out, err := env2.vmk.QueryEval(env2.ctx, "gno.land/r/hello", "c.N")
+	assert.NoError(t, err)
+	assert.Equal(t, "(2 int)", out)
+}
@@ -46,6 +46,10 @@ const (
 	maxAllocTx    = 500_000_000
 	maxAllocQuery = 1_500_000_000 // higher limit for queries
 	maxGasQuery   = 3_000_000_000 // same as max block gas
+
+	// maxStateQuerySize is the maximum size of the store entries returned by
+	// a QueryState page, not counting the first entry.
+	maxStateQuerySize = 4 << 20
 )
 
 // vm.VMKeeperI defines a module interface that supports Gno
@@ -365,9 +369,10 @@ func (vm *VMKeeper) checkNamespacePermission(ctx sdk.Context, creator crypto.Add
 		OriginCaller:    creator.Bech32(),
 		OriginSendSpent: new(std.Coins),
 		// XXX: should we remove the banker ?
-		Banker:      NewSDKBanker(vm, ctx),
-		Params:      NewSDKParams(vm.prmk, ctx),
-		EventLogger: ctx.EventLogger(),
+		Banker:        NewSDKBanker(vm, ctx),
+		Params:        NewSDKParams(vm.prmk, ctx),
+		FeeAllowances: NewSDKFeeAllowances(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 
 	m := gno.NewMachineWithOptions(
@@ -517,6 +522,7 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		FeeAllowances:   NewSDKFeeAllowances(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
@@ -620,6 +626,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		FeeAllowances:   NewSDKFeeAllowances(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 	// Construct machine and evaluate.
@@ -765,6 +772,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		FeeAllowances:   NewSDKFeeAllowances(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 
@@ -1022,9 +1030,10 @@ func (vm *VMKeeper) queryEvalInternal(ctx sdk.Context, pkgPath string, expr stri
 		// OrigCaller:    caller,
 		// OrigSend:      send,
 		// OrigSendSpent: nil,
-		Banker:      NewSDKBanker(vm, ctx), // safe as long as ctx is a fork to be discarded.
-		Params:      NewSDKParams(vm.prmk, ctx),
-		EventLogger: ctx.EventLogger(),
+		Banker:        NewSDKBanker(vm, ctx), // safe as long as ctx is a fork to be discarded.
+		Params:        NewSDKParams(vm.prmk, ctx),
+		FeeAllowances: NewSDKFeeAllowances(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
@@ -1096,6 +1105,53 @@ func (vm *VMKeeper) QueryStorage(ctx sdk.Context, pkgPath string) (string, error
 	return res, nil
 }
 
+// PackageState is the result of QueryState: a deployed package, along with
+// the raw entries of the VM's stores which hold its state.
+type PackageState struct {
+	Package *std.MemPackage `json:"package,omitempty"` // only in the first page
+	State   []StoreEntry    `json:"state"`
+
+	// Next is the key from which the next page starts, or nil for the last
+	// page.
+	Next []byte `json:"next,omitempty"`
+}
+
+// QueryState returns a page of the state of the package at pkgPath, which can
+// be restored on another chain through the Packages and State of the genesis.
+// The first page, with a nil start, includes the package. The pages are
+// limited to about maxStateQuerySize bytes; the following pages are queried
+// with the Next key of the previous one, at the same height.
+func (vm *VMKeeper) QueryState(ctx sdk.Context, pkgPath string, start []byte) (*PackageState, error) {
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	memPkg := store.GetMemPackage(pkgPath)
+	if memPkg == nil {
+		err := ErrInvalidPkgPath(fmt.Sprintf(
+			"package not found: %s", pkgPath))
+		return nil, err
+	}
+
+	ps := &PackageState{}
+	size := 0
+	if start == nil {
+		ps.Package = memPkg
+		for _, file := range memPkg.Files {
+			size += len(file.Name) + len(file.Body)
+		}
+	}
+	baseStore, iavlStore := ctx.Store(vm.baseKey), ctx.Store(vm.iavlKey)
+	gno.IterPackageStateOf(baseStore, iavlStore, pkgPath, start, func(iavl bool, key, value []byte) bool {
+		// The hashes of the iavl store stay with their object.
+		if !iavl && len(ps.State) > 0 && size+len(key)+len(value) > maxStateQuerySize {
+			ps.Next = bytes.Clone(key)
+			return false
+		}
+		size += len(key) + len(value)
+		ps.State = append(ps.State, StoreEntry{IAVL: iavl, Key: bytes.Clone(key), Value: bytes.Clone(value)})
+		return true
+	})
+	return ps, nil
+}
+
 // processStorageDeposit processes storage deposit adjustments for package realms based on
 // storage size changes tracked within the gnoStore.
 //
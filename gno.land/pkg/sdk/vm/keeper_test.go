@@ -20,6 +20,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
@@ -532,6 +533,160 @@ func Do(cur realm) string {
 	assert.Equal(t, int64(1337), bar)
 }
 
+// Reading x/params from a realm.
+func TestVMKeeperGetParams(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	const pkgPath = "gno.land/r/myuser/myrealm"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "init.gno", Body: `
+package params
+
+import (
+	"std"
+	"strconv"
+)
+
+func init() {
+	std.SetParamInt64("bar.int64", 1337)
+}
+
+func Get(cur realm) string {
+	bar, _ := std.GetParamInt64("bar.int64")
+	domain, _ := std.GetParamString("vm:p:chain_domain")
+	price, _ := std.GetParamString("vm:p:storage_price")
+	_, found := std.GetParamString("vm:p:unknown")
+	return strconv.Itoa(int(bar)) + " " + domain + " " + price + " " + strconv.FormatBool(found)
+}`},
+	}
+
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	msg2 := NewMsgCall(addr, nil, pkgPath, "Get", []string{})
+	res, err := env.vmk.Call(ctx, msg2)
+	require.NoError(t, err)
+	assert.Equal(t, `("1337 gno.land 100ugnot false" string)`+"\n\n", res)
+}
+
+// Any realm can read the params of another realm, but only set its own.
+func TestVMKeeperGetParamsOtherRealm(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	const ownerPath = "gno.land/r/myuser/owner"
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, ownerPath, []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(ownerPath)},
+		{Name: "owner.gno", Body: `
+package owner
+
+import "std"
+
+func init() {
+	std.SetParamString("name", "owner")
+}`},
+	}))
+	require.NoError(t, err)
+
+	const readerPath = "gno.land/r/myuser/reader"
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, readerPath, []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(readerPath)},
+		{Name: "reader.gno", Body: `
+package reader
+
+import "std"
+
+func Get(cur realm) string {
+	local, found := std.GetParamString("name")
+	if found {
+		panic("unexpected local param: " + local)
+	}
+	name, _ := std.GetParamString("vm:` + ownerPath + `:name")
+	return name
+}
+
+func Set(cur realm) {
+	std.SetParamString("vm:` + ownerPath + `:name", "reader")
+}`},
+	}))
+	require.NoError(t, err)
+
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, readerPath, "Get", []string{}))
+	require.NoError(t, err)
+	assert.Equal(t, `("owner" string)`+"\n\n", res)
+
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, readerPath, "Set", []string{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid param key: vm:"+ownerPath+":name")
+
+	var name string
+	env.vmk.prmk.GetString(ctx, "vm:"+ownerPath+":name", &name)
+	assert.Equal(t, "owner", name)
+}
+
+// Reading params from a realm is charged gas, and reading a param with the
+// wrong type is a panic the realm can recover from.
+func TestVMKeeperGetParamsGasAndType(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	const pkgPath = "gno.land/r/myuser/myrealm"
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "params.gno", Body: `
+package params
+
+import "std"
+
+func Read(cur realm, n int) {
+	for i := 0; i < n; i++ {
+		std.GetParamString("vm:p:chain_domain")
+	}
+}
+
+func ReadWrongType(cur realm) (res string) {
+	defer func() {
+		res = "recovered: " + recover().(string)
+	}()
+	std.GetParamInt64("vm:p:chain_domain")
+	return "not recovered"
+}`},
+	}))
+	require.NoError(t, err)
+
+	gasRead := func(n string) int64 {
+		gctx := auth.SetGasMeter(ctx, 10_000_000)
+		_, err := env.vmk.Call(gctx, NewMsgCall(addr, nil, pkgPath, "Read", []string{n}))
+		require.NoError(t, err)
+		return gctx.GasMeter().GasConsumed()
+	}
+	assert.GreaterOrEqual(t, gasRead("100")-gasRead("0"), int64(100*1000))
+
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "ReadWrongType", []string{}))
+	require.NoError(t, err)
+	assert.Equal(t, `("recovered: param \"vm:p:chain_domain\" has another type" string)`+"\n\n", res)
+}
+
 // Assign admin as OriginCaller on deploying the package.
 func TestVMKeeperOriginCallerInit(t *testing.T) {
 	env := setupTestEnv()
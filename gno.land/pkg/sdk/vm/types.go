@@ -4,6 +4,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -11,6 +12,9 @@ import (
 // AccountKeeperI is the limited interface only needed for VM.
 type AccountKeeperI interface {
 	GetAccount(ctx sdk.Context, addr crypto.Address) std.Account
+	GetFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) (auth.FeeAllowance, bool)
+	GrantFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address, a auth.FeeAllowance) error
+	RevokeFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) error
 }
 
 // BankKeeperI is the limited interface only needed for VM.
@@ -29,6 +33,7 @@ type ParamsKeeperI interface {
 
 	IsRegistered(moduleName string) bool
 	GetRegisteredKeeper(moduleName string) params.ParamfulKeeper
+	IterateRaw(ctx sdk.Context, prefix string, fn func(key string, value []byte) (stop bool))
 }
 
 // Public facing function signatures.
@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/packages"
@@ -14,9 +16,10 @@ import (
 )
 
 type cleanCfg struct {
-	dryRun   bool // clean -n flag
-	verbose  bool // clean -x flag
-	modCache bool // clean -modcache flag
+	dryRun    bool          // clean -n flag
+	verbose   bool          // clean -x flag
+	modCache  bool          // clean -modcache flag
+	olderThan time.Duration // clean -older-than flag
 }
 
 func newCleanCmd(io commands.IO) *commands.Command {
@@ -56,6 +59,13 @@ func (c *cleanCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"remove the entire module download cache and exit",
 	)
+
+	fs.DurationVar(
+		&c.olderThan,
+		"older-than",
+		0,
+		"with -modcache, only remove the cached packages not used for the given duration (e.g. 720h)",
+	)
 }
 
 func execClean(cfg *cleanCfg, args []string, io commands.IO) error {
@@ -63,6 +73,20 @@ func execClean(cfg *cleanCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
+	if cfg.olderThan != 0 && !cfg.modCache {
+		return errors.New("-older-than requires -modcache")
+	}
+
+	if cfg.modCache && cfg.olderThan > 0 {
+		removed, err := packages.PruneCache(time.Now().Add(-cfg.olderThan), cfg.dryRun)
+		if cfg.dryRun || cfg.verbose {
+			for _, dir := range removed {
+				io.Println("rm -rf", dir)
+			}
+		}
+		return err
+	}
+
 	if cfg.modCache {
 		modCacheDir := gnomod.ModCachePath()
 		if !cfg.dryRun {
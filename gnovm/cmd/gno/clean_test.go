@@ -42,6 +42,15 @@ func TestCleanApp(t *testing.T) {
 			simulateExternalRepo: true,
 			stdoutShouldContain:  "rm -rf ",
 		},
+		{
+			args:                 []string{"clean", "-modcache", "-older-than", "720h"},
+			testDir:              "../../tests/integ/empty_dir",
+			simulateExternalRepo: true,
+		},
+		{
+			args:        []string{"clean", "-older-than", "720h"},
+			errShouldBe: "-older-than requires -modcache",
+		},
 	}
 	testMainCaseRun(t, tc)
 
@@ -60,6 +60,9 @@ func execEnv(cfg *envCfg, args []string, io commands.IO) error {
 		// GNOHOME Should point to the user local configuration.
 		// The most common place for this should be $HOME/gno.
 		{Key: "GNOHOME", Value: gnoenv.HomeDir()},
+		// GNOPROXY is the comma-separated list of sources used to fetch
+		// remote packages, e.g. "direct" or "/mnt/mirror,off".
+		{Key: "GNOPROXY", Value: gnoenv.Proxy()},
 	}
 
 	// Setup filters
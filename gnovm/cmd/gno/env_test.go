@@ -9,10 +9,12 @@ func TestEnvApp(t *testing.T) {
 	const (
 		testGnoRootEnv = "/faster/better/stronger"
 		testGnoHomeEnv = "/around/the/world"
+		testGnoProxy   = "/one/more/time.tar.gz,off"
 	)
 
 	t.Setenv("GNOROOT", testGnoRootEnv)
 	t.Setenv("GNOHOME", testGnoHomeEnv)
+	t.Setenv("GNOPROXY", testGnoProxy)
 	tc := []testMainCase{
 		// shell
 		{args: []string{"env", "foo"}, stdoutShouldBe: "\n"},
@@ -21,6 +23,7 @@ func TestEnvApp(t *testing.T) {
 		{args: []string{"env", "GNOHOME", "storm"}, stdoutShouldBe: testGnoHomeEnv + "\n\n", noTmpGnohome: true},
 		{args: []string{"env"}, stdoutShouldContain: fmt.Sprintf("GNOROOT=%q", testGnoRootEnv)},
 		{args: []string{"env"}, stdoutShouldContain: fmt.Sprintf("GNOHOME=%q", testGnoHomeEnv), noTmpGnohome: true},
+		{args: []string{"env", "GNOPROXY"}, stdoutShouldBe: testGnoProxy + "\n"},
 
 		// json
 		{args: []string{"env", "-json"}, stdoutShouldContain: fmt.Sprintf("\"GNOROOT\": %q", testGnoRootEnv)},
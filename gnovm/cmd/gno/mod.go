@@ -11,11 +11,12 @@ import (
 	"go.uber.org/multierr"
 	"golang.org/x/mod/module"
 
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/packages"
 	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
-	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/rpcpkgfetcher"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/proxypkgfetcher"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 )
@@ -41,7 +42,7 @@ func newModCmd(io commands.IO) *commands.Command {
 		newModInitCmd(),
 		newModTidy(io),
 		// vendor
-		// verify
+		newModVerifyCmd(io),
 		newModWhy(io),
 	)
 
@@ -54,8 +55,21 @@ func newModDownloadCmd(io commands.IO) *commands.Command {
 	return commands.NewCommand(
 		commands.Metadata{
 			Name:       "download",
-			ShortUsage: "download [flags]",
+			ShortUsage: "download [flags] [<pkgpath>...]",
 			ShortHelp:  "download modules to local cache",
+			LongHelp: `Downloads the dependencies of the packages in the current directory to the
+module cache, or the given remote packages if any.
+
+Packages are fetched from the sources listed in GNOPROXY, a comma-separated list
+tried in order. Sources can be:
+
+	direct		the chain serving the package (default)
+	off		disallow fetching packages from the following sources
+	<dir>		a local mirror, laid out as <dir>/<pkgpath>/<files>
+	<archive>	a .tar, .tar.gz or .tgz archive of a local mirror
+
+For instance, GNOPROXY=/mnt/mirror.tar.gz,off allows running gno test without
+any network access, as long as all the dependencies are in the mirror.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -64,6 +78,22 @@ func newModDownloadCmd(io commands.IO) *commands.Command {
 	)
 }
 
+func newModVerifyCmd(io commands.IO) *commands.Command {
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "verify",
+			ShortUsage: "verify",
+			ShortHelp:  "verify cached modules have expected content",
+			LongHelp: `Checks that the packages stored in the module cache have not been
+modified since they were downloaded.`,
+		},
+		commands.NewEmptyConfig(),
+		func(_ context.Context, args []string) error {
+			return execModVerify(args, io)
+		},
+	)
+}
+
 func newModGraphCmd(io commands.IO) *commands.Command {
 	cfg := &modGraphCfg{}
 	return commands.NewCommand(
@@ -233,21 +263,33 @@ func execModGraph(cfg *modGraphCfg, args []string, io commands.IO) error {
 }
 
 func execModDownload(cfg *modDownloadCfg, args []string, io commands.IO) error {
-	if len(args) > 0 {
-		return flag.ErrHelp
-	}
-
 	fetcher := testPackageFetcher
 	if fetcher == nil {
 		remoteOverrides, err := parseRemoteOverrides(cfg.remoteOverrides)
 		if err != nil {
 			return fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
 		}
-		fetcher = rpcpkgfetcher.New(remoteOverrides)
+		fetcher, err = proxypkgfetcher.New(gnoenv.Proxy(), remoteOverrides)
+		if err != nil {
+			return fmt.Errorf("invalid GNOPROXY: %w", err)
+		}
 	} else if len(cfg.remoteOverrides) != 0 {
 		return fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
 	}
 
+	if len(args) > 0 {
+		var errs error
+		for _, pkgPath := range args {
+			if err := module.CheckImportPath(pkgPath); err != nil {
+				return err
+			}
+			if err := packages.DownloadPackageToCache(io.Err(), pkgPath, fetcher); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("%s: %w", pkgPath, err))
+			}
+		}
+		return errs
+	}
+
 	loadCfg := packages.LoadConfig{
 		Fetcher:    fetcher,
 		Deps:       true,
@@ -274,6 +316,23 @@ func execModDownload(cfg *modDownloadCfg, args []string, io commands.IO) error {
 	return nil
 }
 
+func execModVerify(args []string, io commands.IO) error {
+	if len(args) > 0 {
+		return flag.ErrHelp
+	}
+
+	_, err := packages.VerifyCache()
+	if err != nil {
+		for _, err := range multierr.Errors(err) {
+			io.ErrPrintln(err)
+		}
+		return errors.New("module cache verification failed")
+	}
+
+	io.Println("all modules verified")
+	return nil
+}
+
 func parseRemoteOverrides(arg string) (map[string]string, error) {
 	if arg == "" {
 		return map[string]string{}, nil
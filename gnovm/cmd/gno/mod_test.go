@@ -72,6 +72,19 @@ func TestModApp(t *testing.T) {
 			simulateExternalRepo: true,
 			stderrShouldContain:  "gno: downloading gno.land/p/nt/avl",
 		},
+		{
+			args:                []string{"mod", "download", "gno.land/p/nt/avl"},
+			stderrShouldContain: "gno: downloading gno.land/p/nt/avl",
+		},
+		{
+			args:                []string{"mod", "download", "gno.land/p/demo/notexists"},
+			stderrShouldContain: "gno: downloading gno.land/p/demo/notexists",
+			errShouldContain:    "package \"gno.land/p/demo/notexists\" is not available",
+		},
+		{
+			args:             []string{"mod", "download", "not a path"},
+			errShouldContain: "malformed import path",
+		},
 		// TODO: that functionality is not available on gnomod.toml anymore. should we remove this?
 		// {
 		// 	args:                 []string{"mod", "download"},
@@ -81,6 +94,16 @@ func TestModApp(t *testing.T) {
 		// 	errShouldContain:     "query files list for pkg \"gno.land/p/demo/notexists\": package \"gno.land/p/demo/notexists\" is not available",
 		// },
 
+		// test `gno mod verify`
+		{
+			args:           []string{"mod", "verify"},
+			stdoutShouldBe: "all modules verified\n",
+		},
+		{
+			args:        []string{"mod", "verify", "gno.land/p/nt/avl"},
+			errShouldBe: "flag: help requested",
+		},
+
 		// test `gno mod init` with module name
 		{
 			args:                 []string{"mod", "init", "gno.land/p/demo/foo"},
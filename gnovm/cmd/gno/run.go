@@ -74,7 +74,7 @@ func (c *runCmd) RegisterFlags(fs *flag.FlagSet) {
 		&c.debugAddr,
 		"debug-addr",
 		"",
-		"enable interactive debugger using tcp address in the form [host]:port",
+		"enable interactive debugger using tcp address in the form [host]:port, for a text or DAP client",
 	)
 }
 
@@ -130,6 +130,7 @@ func execRun(cfg *runCmd, args []string, cio commands.IO) error {
 		if err := m.Debugger.Serve(cfg.debugAddr); err != nil {
 			return err
 		}
+		defer m.Debugger.Close()
 	}
 
 	// run files
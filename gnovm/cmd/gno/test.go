@@ -27,12 +27,19 @@ type testCmd struct {
 	rootDir             string
 	autoGnomod          bool
 	run                 string
+	bench               string
+	benchTime           test.BenchTime
+	fuzz                string
+	fuzzTime            test.BenchTime
 	timeout             time.Duration
 	updateGoldenTests   bool
 	printRuntimeMetrics bool
 	printEvents         bool
 	debug               bool
 	debugAddr           string
+	cover               bool
+	coverMode           string
+	coverProfile        string
 }
 
 func newTestCmd(io commands.IO) *commands.Command {
@@ -50,9 +57,22 @@ file pattern "*_test.gno" or "*_filetest.gno".
 
 The <package> can be directory or file path (relative or absolute).
 
-- "*_test.gno" files work like "*_test.go" files, but they contain only test
-functions. Benchmark and fuzz functions aren't supported yet. Similarly, only
-tests that belong to the same package are supported for now (no "xxx_test").
+- "*_test.gno" files work like "*_test.go" files, but they contain only test,
+benchmark and fuzz functions. Similarly, only tests that belong to the same
+package are supported for now (no "xxx_test").
+
+Benchmark functions are only run when the -bench flag is given. Each benchmark
+is run with an increasing b.N until it lasts for at least -benchtime, and
+reports the average wall-clock time, VM cpu cycles, gas and bytes allocated by
+the VM allocator for each iteration.
+
+Fuzz functions run the inputs of their seed corpus like subtests: the values
+passed to f.Add, and the files in "testdata/fuzz/FuzzXxx", which use the same
+format as Go. With the -fuzz flag, the matching fuzz function is fuzzed: new
+inputs are generated from the corpus until one of them fails, or until
+-fuzztime has elapsed. Fuzzed arguments can be strings, byte slices, booleans,
+integers and floating-point numbers. A failing input is written to
+"testdata/fuzz/FuzzXxx", so that it is run by subsequent calls to 'gno test'.
 
 The package path used to execute the "*_test.gno" file is fetched from the
 module name found in 'gno.mod', or else it is set to
@@ -92,6 +112,11 @@ of the GnoVM, acting as a "golden test":
 To speed up execution, imports of pure packages are processed separately from
 the execution of the tests. This makes testing faster, but means that the
 initialization of imported pure packages cannot be checked in filetests.
+
+The -cover flag enables statement coverage analysis of the tested packages,
+and prints the percentage of statements executed by the tests of each package.
+The -coverprofile flag additionally writes a coverage profile, in the same
+format as 'go test', which can be inspected using 'go tool cover'.
 `,
 		},
 		cmd,
@@ -144,6 +169,33 @@ func (c *testCmd) RegisterFlags(fs *flag.FlagSet) {
 		"test name filtering pattern",
 	)
 
+	fs.StringVar(
+		&c.bench,
+		"bench",
+		"",
+		"run benchmarks matching the regular expression (use . for all)",
+	)
+
+	c.benchTime = test.DefaultBenchTime
+	fs.Var(
+		&c.benchTime,
+		"benchtime",
+		"run each benchmark for duration d, or N times if specified as Nx",
+	)
+
+	fs.StringVar(
+		&c.fuzz,
+		"fuzz",
+		"",
+		"run the fuzz test matching the regular expression",
+	)
+
+	fs.Var(
+		&c.fuzzTime,
+		"fuzztime",
+		"time spent fuzzing, or number of iterations if specified as Nx (default: until failure)",
+	)
+
 	fs.DurationVar(
 		&c.timeout,
 		"timeout",
@@ -176,7 +228,28 @@ func (c *testCmd) RegisterFlags(fs *flag.FlagSet) {
 		&c.debugAddr,
 		"debug-addr",
 		"",
-		"enable interactive debugger using tcp address in the form [host]:port",
+		"enable interactive debugger using tcp address in the form [host]:port, for a text or DAP client",
+	)
+
+	fs.BoolVar(
+		&c.cover,
+		"cover",
+		false,
+		"enable coverage analysis",
+	)
+
+	fs.StringVar(
+		&c.coverMode,
+		"covermode",
+		"",
+		"coverage mode: set (default), count (implies -cover)",
+	)
+
+	fs.StringVar(
+		&c.coverProfile,
+		"coverprofile",
+		"",
+		"write a coverage profile to the file after all tests have run (implies -cover)",
 	)
 }
 
@@ -208,6 +281,29 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		return nil
 	}
 
+	if cmd.fuzz != "" {
+		matched := 0
+		for _, pkg := range pkgs {
+			if len(pkg.Match) != 0 {
+				matched++
+			}
+		}
+		if matched > 1 {
+			return errors.New("cannot use -fuzz flag with multiple packages")
+		}
+	}
+
+	if cmd.coverMode != "" || cmd.coverProfile != "" {
+		cmd.cover = true
+	}
+	switch cmd.coverMode {
+	case "":
+		cmd.coverMode = "set"
+	case "set", "count":
+	default:
+		return fmt.Errorf("invalid -covermode %q: must be set or count", cmd.coverMode)
+	}
+
 	if cmd.timeout > 0 {
 		go func() {
 			time.Sleep(cmd.timeout)
@@ -222,21 +318,38 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 	}
 	opts := test.NewTestOptions(cmd.rootDir, stdout, io.Err(), pkgs)
 	opts.RunFlag = cmd.run
+	opts.BenchFlag = cmd.bench
+	opts.BenchTime = cmd.benchTime
+	opts.FuzzFlag = cmd.fuzz
+	opts.FuzzTime = cmd.fuzzTime
 	opts.Sync = cmd.updateGoldenTests
 	opts.Verbose = cmd.verbose
 	opts.Metrics = cmd.printRuntimeMetrics
 	opts.Events = cmd.printEvents
-	opts.Debug = cmd.debug
+	opts.Debug = cmd.debug || cmd.debugAddr != ""
+	opts.DebugAddr = cmd.debugAddr
+	defer opts.CloseDebugger()
 	opts.FailfastFlag = cmd.failfast
 	cache := make(gno.TypeCheckCache, 64)
 
 	// test.ProdStore() is suitable for type-checking prod (non-test) files.
 	// _, pgs := test.ProdStore(cmd.rootDir, opts.WriterForStore())
 
+	// coverage contains the coverage of all tested packages; coverDirs maps
+	// their package path to their directory, to write the profile.
+	var coverage *gno.Coverage
+	coverDirs := map[string]string{}
+	if cmd.cover {
+		coverage = gno.NewCoverage()
+	}
+
 	buildErrCount := 0
 	testErrCount := 0
 	fail := func() error {
 		io.ErrPrintfln("FAIL")
+		if err := writeCoverProfile(cmd, coverage, coverDirs); err != nil {
+			io.ErrPrintfln("%s", err.Error())
+		}
 		return fmt.Errorf("FAIL: %d build errors, %d test errors", buildErrCount, testErrCount)
 	}
 
@@ -304,6 +417,16 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 
 		// Read MemPackage with all files.
 		mpkg := gno.MustReadMemPackage(pkg.Dir, pkgPath, gno.MPAnyAll)
+
+		// Track statement coverage of the package's production files.
+		var pkgCoverage *gno.Coverage
+		if cmd.cover {
+			pkgCoverage = gno.NewCoverage()
+			// Parsing errors are reported when type checking.
+			_ = pkgCoverage.AddMemPackage(mpkg)
+		}
+		opts.Coverage = pkgCoverage
+
 		var didPanic, didError bool
 		startedAt := time.Now()
 		didPanic = catchPanic(pkg.Dir, pkgPath, io.Err(), func() {
@@ -337,6 +460,15 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		// Print status with duration.
 		duration := time.Since(startedAt)
 		dstr := fmtDuration(duration)
+		if pkgCoverage != nil {
+			if _, total := pkgCoverage.Stats(pkgPath); total == 0 {
+				dstr += "\tcoverage: [no statements]"
+			} else {
+				dstr += fmt.Sprintf("\tcoverage: %.1f%% of statements", pkgCoverage.Percent(pkgPath))
+			}
+			coverage.Merge(pkgCoverage)
+			coverDirs[pkgPath] = pkg.Dir
+		}
 		if didPanic || didError {
 			io.ErrPrintfln("FAIL    %s \t%s", prettyDir, dstr)
 			testErrCount++
@@ -351,7 +483,36 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		return fail()
 	}
 
-	return nil
+	return writeCoverProfile(cmd, coverage, coverDirs)
+}
+
+// writeCoverProfile writes coverage to cmd.coverProfile, if set. File names
+// in the profile are the absolute paths of the source files, so that the
+// profile can be used by 'go tool cover' without resolving Gno import paths.
+func writeCoverProfile(cmd *testCmd, coverage *gno.Coverage, dirs map[string]string) error {
+	if coverage == nil || cmd.coverProfile == "" {
+		return nil
+	}
+	f, err := os.Create(cmd.coverProfile)
+	if err != nil {
+		return fmt.Errorf("unable to create coverage profile: %w", err)
+	}
+
+	err = coverage.WriteProfile(f, cmd.coverMode, func(cf *gno.CoverFile) string {
+		dir, ok := dirs[cf.PkgPath]
+		if !ok {
+			return cf.PkgPath + "/" + cf.File
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		return filepath.Join(dir, cf.File)
+	})
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write coverage profile: %w", err)
+	}
+	return f.Close()
 }
 
 func determinePkgPath(mod *gnomod.File, dir, rootDir string) (string, bool) {
@@ -0,0 +1,16 @@
+package gnoenv
+
+import "os"
+
+// DefaultProxy is the proxy list used when GNOPROXY is not set: packages are
+// fetched directly from the chains serving them.
+const DefaultProxy = "direct"
+
+// Proxy returns the comma-separated list of sources used to fetch remote
+// packages, as set by GNOPROXY.
+func Proxy() string {
+	if proxy := os.Getenv("GNOPROXY"); proxy != "" {
+		return proxy
+	}
+	return DefaultProxy
+}
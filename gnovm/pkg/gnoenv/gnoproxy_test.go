@@ -0,0 +1,15 @@
+package gnoenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxy(t *testing.T) {
+	t.Setenv("GNOPROXY", "")
+	require.Equal(t, DefaultProxy, Proxy())
+
+	t.Setenv("GNOPROXY", "/mnt/mirror,off")
+	require.Equal(t, "/mnt/mirror,off", Proxy())
+}
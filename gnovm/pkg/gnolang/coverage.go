@@ -0,0 +1,256 @@
+package gnolang
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Coverage records which statements of a set of packages have been executed
+// by a [Machine]. Statements are registered ahead of execution using
+// [Coverage.AddMemPackage] or [Coverage.AddFile], and are identified by their
+// package path, file name and span; executed statements which were not
+// registered are ignored.
+//
+// A Coverage is not safe for concurrent use.
+type Coverage struct {
+	files []*CoverFile
+	index map[string]*CoverFile // pkgPath/file -> CoverFile
+}
+
+// CoverFile contains the coverage blocks of a single source file.
+type CoverFile struct {
+	PkgPath string
+	File    string
+	Blocks  []CoverBlock
+
+	index map[Span]int // stmt span -> index in Blocks
+}
+
+// CoverBlock is a single statement tracked by a [Coverage].
+// Span is the portion of the source attributed to the statement itself,
+// excluding the bodies of nested blocks.
+type CoverBlock struct {
+	Span
+	NumStmts int
+	Count    int64
+}
+
+// NewCoverage returns a new, empty Coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{
+		index: make(map[string]*CoverFile),
+	}
+}
+
+// AddMemPackage registers the statements of all the production files of mpkg;
+// *_test.gno and *_filetest.gno files are skipped.
+func (c *Coverage) AddMemPackage(mpkg *std.MemPackage) error {
+	for _, mfile := range mpkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") ||
+			endsWithAny(mfile.Name, []string{"_test.gno", "_filetest.gno"}) {
+			continue
+		}
+		fn, err := ParseFile(mfile.Name, mfile.Body)
+		if err != nil {
+			return err
+		}
+		c.AddFile(mpkg.Path, fn)
+	}
+	return nil
+}
+
+// AddFile registers all the statements contained in the bodies of fn, which
+// is assumed to be named fn.FileName within pkgPath.
+func (c *Coverage) AddFile(pkgPath string, fn *FileNode) {
+	key := pkgPath + "/" + fn.FileName
+	if _, ok := c.index[key]; ok {
+		return
+	}
+	cf := &CoverFile{
+		PkgPath: pkgPath,
+		File:    fn.FileName,
+		index:   make(map[Span]int),
+	}
+	Transcribe(fn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		switch ftype {
+		case TRANS_FUNCLIT_BODY, TRANS_BLOCK_BODY, TRANS_FOR_BODY,
+			TRANS_IF_CASE_BODY, TRANS_RANGE_BODY, TRANS_SELECTCASE_BODY,
+			TRANS_SWITCHCASE_BODY, TRANS_FUNC_BODY:
+		default:
+			return n, TRANS_CONTINUE
+		}
+		s, ok := n.(Stmt)
+		if !ok {
+			return n, TRANS_CONTINUE
+		}
+		span := coverKey(s)
+		if span.IsZero() {
+			return n, TRANS_CONTINUE
+		}
+		if _, exists := cf.index[span]; !exists {
+			cf.index[span] = len(cf.Blocks)
+			cf.Blocks = append(cf.Blocks, CoverBlock{
+				Span:     coverSpan(s),
+				NumStmts: 1,
+			})
+		}
+		return n, TRANS_CONTINUE
+	})
+	c.files = append(c.files, cf)
+	c.index[key] = cf
+}
+
+// Merge adds the files of other into c. Files already present in c are
+// replaced.
+func (c *Coverage) Merge(other *Coverage) {
+	for _, cf := range other.files {
+		key := cf.PkgPath + "/" + cf.File
+		if old, ok := c.index[key]; ok {
+			for i, f := range c.files {
+				if f == old {
+					c.files[i] = cf
+				}
+			}
+		} else {
+			c.files = append(c.files, cf)
+		}
+		c.index[key] = cf
+	}
+}
+
+// Files returns the registered files, in the order they were added.
+func (c *Coverage) Files() []*CoverFile {
+	return c.files
+}
+
+// Stats returns the number of executed statements and the total number of
+// statements registered for pkgPath. If pkgPath is empty, all the packages
+// are considered.
+func (c *Coverage) Stats(pkgPath string) (covered, total int) {
+	for _, cf := range c.files {
+		if pkgPath != "" && cf.PkgPath != pkgPath {
+			continue
+		}
+		for _, b := range cf.Blocks {
+			total += b.NumStmts
+			if b.Count > 0 {
+				covered += b.NumStmts
+			}
+		}
+	}
+	return
+}
+
+// Percent returns the percentage of executed statements for pkgPath, as
+// [Coverage.Stats]. It returns 0 if there are no statements.
+func (c *Coverage) Percent(pkgPath string) float64 {
+	covered, total := c.Stats(pkgPath)
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total) * 100
+}
+
+// WriteProfile writes c to w using Go's coverprofile text format, understood
+// by `go tool cover`. mode must be either "set" or "count". fileName maps each
+// file to the name used in the profile; if nil, pkgPath/file is used.
+func (c *Coverage) WriteProfile(w io.Writer, mode string, fileName func(cf *CoverFile) string) error {
+	switch mode {
+	case "set", "count":
+	default:
+		return fmt.Errorf("invalid cover mode %q", mode)
+	}
+	if _, err := fmt.Fprintf(w, "mode: %s\n", mode); err != nil {
+		return err
+	}
+	for _, cf := range c.files {
+		name := cf.PkgPath + "/" + cf.File
+		if fileName != nil {
+			name = fileName(cf)
+		}
+		for _, b := range cf.Blocks {
+			count := b.Count
+			if mode == "set" && count > 0 {
+				count = 1
+			}
+			_, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d %d %d\n", name,
+				b.Line, b.Column, b.End.Line, b.End.Column,
+				b.NumStmts, count)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hit records the execution of s, if it was registered.
+func (c *Coverage) hit(m *Machine, s Stmt) {
+	span := coverKey(s)
+	if span.IsZero() {
+		return
+	}
+	loc := m.LastBlock().GetSource(m.Store).GetLocation()
+	cf := c.index[loc.PkgPath+"/"+loc.File]
+	if cf == nil {
+		return
+	}
+	if i, ok := cf.index[span]; ok {
+		cf.Blocks[i].Count++
+	}
+}
+
+// coverKey returns the span identifying s, regardless of whether it was
+// obtained by parsing or after preprocessing.
+func coverKey(s Stmt) Span {
+	span := s.GetSpan()
+	span.Num = 0
+	return span
+}
+
+// coverSpan returns the span of s, truncated to exclude the bodies of any
+// nested blocks, so that blocks in the resulting profile don't overlap.
+func coverSpan(s Stmt) Span {
+	span := coverKey(s)
+	var first Node
+	switch cs := s.(type) {
+	case *IfStmt:
+		if len(cs.Then.Body) > 0 {
+			first = cs.Then.Body[0]
+		} else {
+			first = &cs.Then
+		}
+	case *ForStmt:
+		if len(cs.Body) > 0 {
+			first = cs.Body[0]
+		}
+	case *RangeStmt:
+		if len(cs.Body) > 0 {
+			first = cs.Body[0]
+		}
+	case *SwitchStmt:
+		if len(cs.Clauses) > 0 {
+			first = &cs.Clauses[0]
+		}
+	case *SelectStmt:
+		if len(cs.Cases) > 0 {
+			first = &cs.Cases[0]
+		}
+	case *BlockStmt:
+		if len(cs.Body) > 0 {
+			first = cs.Body[0]
+		}
+	}
+	if first != nil {
+		if pos := first.GetSpan().Pos; !pos.IsZero() && pos.Compare(span.Pos) > 0 {
+			span.End = pos
+		}
+	}
+	return span
+}
@@ -0,0 +1,78 @@
+package gnolang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	const src = `package cov
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func Unused() int {
+	y := 1
+	return y
+}
+`
+	mpkg := &std.MemPackage{
+		Type: MPUserProd,
+		Name: "cov",
+		Path: "gno.land/p/demo/cov",
+		Files: []*std.MemFile{
+			{Name: "cov.gno", Body: src},
+			{Name: "cov_test.gno", Body: "package cov\n\nfunc helper() { _ = 1 }\n"},
+		},
+	}
+
+	cov := NewCoverage()
+	require.NoError(t, cov.AddMemPackage(mpkg))
+	require.Len(t, cov.Files(), 1)
+
+	covered, total := cov.Stats(mpkg.Path)
+	assert.Equal(t, 0, covered)
+	assert.Equal(t, 5, total)
+
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath:  mpkg.Path,
+		Coverage: cov,
+	})
+	defer m.Release()
+	m.RunMemPackage(mpkg, false)
+	m.Eval(Call(X("Abs"), 3))
+
+	covered, total = cov.Stats(mpkg.Path)
+	assert.Equal(t, 2, covered)
+	assert.Equal(t, 5, total)
+	assert.InDelta(t, 40.0, cov.Percent(mpkg.Path), 0.001)
+
+	var sb strings.Builder
+	require.NoError(t, cov.WriteProfile(&sb, "set", nil))
+	assert.Equal(t, `mode: set
+gno.land/p/demo/cov/cov.gno:4.2,5.3 1 1
+gno.land/p/demo/cov/cov.gno:5.3,5.12 1 0
+gno.land/p/demo/cov/cov.gno:7.2,7.10 1 1
+gno.land/p/demo/cov/cov.gno:11.2,11.8 1 0
+gno.land/p/demo/cov/cov.gno:12.2,12.10 1 0
+`, sb.String())
+
+	m.Eval(Call(X("Abs"), -3))
+	m.Eval(Call(X("Abs"), -4))
+	sb.Reset()
+	require.NoError(t, cov.WriteProfile(&sb, "count", func(cf *CoverFile) string {
+		return cf.File
+	}))
+	assert.Contains(t, sb.String(), "cov.gno:4.2,5.3 1 3\n")
+	assert.Contains(t, sb.String(), "cov.gno:5.3,5.12 1 2\n")
+
+	assert.Error(t, cov.WriteProfile(&sb, "atomic", nil))
+}
@@ -7,6 +7,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io"
 	"net"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
@@ -53,12 +55,13 @@ type Debugger struct {
 	loc         Location                    // source location of the current machine instruction
 	prevLoc     Location                    // source location of the previous machine instruction
 	nextLoc     Location                    // source location at the 'next' command
-	breakpoints []Location                  // list of breakpoints set by user, as source locations
+	breakpoints []*breakpoint               // list of breakpoints and watchpoints set by user
 	call        []Location                  // for function tracking, ideally should be provided by machine frame
 	frameLevel  int                         // frame level of the current machine instruction
 	nextDepth   int                         // function call depth at the 'next' command
 	getSrc      func(string, string) string // helper to access source from repl or others
 	rootDir     string
+	dap         *dapSession // if set, the debugger is driven by a DAP client
 }
 
 // Enable makes the debugger d active, using in as input reader, out as output writer and f as a source helper.
@@ -71,6 +74,31 @@ func (d *Debugger) Enable(in io.Reader, out io.Writer, f func(string, string) st
 	d.rootDir = gnoenv.RootDir()
 }
 
+// Resume makes d continue the debugging session of prev, which was used by
+// another machine, so that breakpoints and the debugger IO are preserved
+// when running consecutive tests for example.
+func (d *Debugger) Resume(prev *Debugger) {
+	*d = *prev
+	d.loc = Location{}
+	d.prevLoc = Location{}
+	d.nextLoc = Location{}
+	d.call = nil
+	d.frameLevel = 0
+	d.nextDepth = 0
+}
+
+// Close ends the debugging session. A remote DAP client is notified that
+// the program has terminated.
+func (d *Debugger) Close() error {
+	if d.dap != nil {
+		return d.dap.close()
+	}
+	if c, ok := d.in.(io.Closer); ok && d.in != os.Stdin {
+		return c.Close()
+	}
+	return nil
+}
+
 // Disable makes the debugger d inactive.
 func (d *Debugger) Disable() {
 	d.enabled = false
@@ -79,6 +107,19 @@ func (d *Debugger) Disable() {
 	d.nextLoc = Location{}
 }
 
+// breakpoint is a source location or a watched value where the program
+// stops, if its condition and hit count condition are satisfied.
+type breakpoint struct {
+	Location              // source location, if not a watchpoint
+	watch    string       // watched expression, if a watchpoint
+	ptr      PointerValue // watched value
+	old      TypedValue   // last known watched value
+	cond     ast.Expr     // optional condition
+	hitOp    string       // optional hit count condition operator
+	hitCount int          // hit count condition argument
+	hits     int          // number of times the breakpoint was hit
+}
+
 type debugCommand struct {
 	debugFunc          func(*Machine, string) error // debug command
 	usage, short, long string                       // command help texts
@@ -95,6 +136,7 @@ func init() {
 		"break":       {debugBreak, breakUsage, breakShort, breakLong},
 		"breakpoints": {debugBreakpoints, breakpointsUsage, breakpointsShort, ""},
 		"clear":       {debugClear, clearUsage, clearShort, ""},
+		"condition":   {debugCondition, conditionUsage, conditionShort, conditionLong},
 		"continue":    {debugContinue, continueUsage, continueShort, ""},
 		"detach":      {debugDetach, detachUsage, detachShort, ""},
 		"down":        {debugDown, downUsage, downShort, ""},
@@ -102,12 +144,14 @@ func init() {
 		"help":        {debugHelp, helpUsage, helpShort, ""},
 		"list":        {debugList, listUsage, listShort, listLong},
 		"print":       {debugPrint, printUsage, printShort, ""},
+		"set":         {debugSet, setUsage, setShort, setLong},
 		"stack":       {debugStack, stackUsage, stackShort, ""},
 		"next":        {debugContinue, nextUsage, nextShort, ""},
 		"step":        {debugContinue, stepUsage, stepShort, ""},
 		"stepi":       {debugContinue, stepiUsage, stepiShort, ""},
 		"stepout":     {debugContinue, stepoutUsage, stepoutShort, ""},
 		"up":          {debugUp, upUsage, upShort, ""},
+		"watch":       {debugWatch, watchUsage, watchShort, watchLong},
 	}
 
 	// Sort command names for help.
@@ -122,6 +166,7 @@ func init() {
 	debugCmds["bp"] = debugCmds["breakpoints"]
 	debugCmds["bt"] = debugCmds["stack"]
 	debugCmds["c"] = debugCmds["continue"]
+	debugCmds["cond"] = debugCmds["condition"]
 	debugCmds["h"] = debugCmds["help"]
 	debugCmds["l"] = debugCmds["list"]
 	debugCmds["n"] = debugCmds["next"]
@@ -140,10 +185,17 @@ loop:
 		switch m.Debugger.state {
 		case DebugAtInit:
 			debugUpdateLocation(m)
+			m.Debugger.state = DebugAtCmd
+			if m.Debugger.dap != nil {
+				continue loop
+			}
 			fmt.Fprintln(m.Debugger.out, "Welcome to the Gnovm debugger. Type 'help' for list of commands.")
 			m.Debugger.scanner = bufio.NewScanner(m.Debugger.in)
-			m.Debugger.state = DebugAtCmd
 		case DebugAtCmd:
+			if m.Debugger.dap != nil {
+				dapCmd(m)
+				continue loop
+			}
 			if err := debugCmd(m); err != nil {
 				fmt.Fprintln(m.Debugger.out, "Command failed:", err)
 			}
@@ -151,15 +203,18 @@ loop:
 			if !m.Debugger.enabled {
 				break loop
 			}
+			if m.Debugger.dap != nil && dapPoll(m) {
+				continue loop
+			}
 			switch m.Debugger.lastCmd {
 			case "si", "stepi":
 				m.Debugger.state = DebugAtCmd
-				debugLineInfo(m)
+				debugStopped(m, "step")
 			case "s", "step":
 				if m.Debugger.loc != m.Debugger.prevLoc && m.Debugger.loc.File != "" {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStopped(m, "step")
 					continue loop
 				}
 			case "n", "next":
@@ -167,21 +222,21 @@ loop:
 					(m.Debugger.nextDepth == 0 || !sameLine(m.Debugger.loc, m.Debugger.nextLoc) && callDepth(m) <= m.Debugger.nextDepth) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStopped(m, "step")
 					continue loop
 				}
 			case "stepout", "so":
 				if callDepth(m) < m.Debugger.nextDepth {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStopped(m, "step")
 					continue loop
 				}
 			default:
 				if atBreak(m) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStopped(m, "breakpoint")
 					continue loop
 				}
 			}
@@ -220,20 +275,106 @@ func sameLine(loc1, loc2 Location) bool {
 	return loc1.PkgPath == loc2.PkgPath && loc1.File == loc2.File && loc1.Line == loc2.Line
 }
 
-// atBreak returns true if current machine location matches a breakpoint, false otherwise.
+// atBreak returns true if current machine location matches a breakpoint,
+// or if a watched value has changed, false otherwise.
 func atBreak(m *Machine) bool {
+	for i, b := range m.Debugger.breakpoints {
+		if b.watch == "" || sameValue(b.ptr.Deref(), b.old) {
+			continue
+		}
+		old := b.old
+		b.old = b.ptr.Deref()
+		if b.shouldStop(m, i) {
+			fmt.Fprintf(m.Debugger.out, "Watchpoint %d: %s changed from %v to %v\n", i, b.watch, old, b.old)
+			return true
+		}
+	}
+
 	loc := m.Debugger.loc
 	if loc == m.Debugger.prevLoc {
 		return false
 	}
-	for _, b := range m.Debugger.breakpoints {
-		if loc.File == b.File && loc.Line == b.Line {
+	if s := m.Debugger.dap; s != nil {
+		// DAP breakpoints are set on lines, break only when entering a line.
+		prev := s.lastLoc
+		s.lastLoc = loc
+		if sameLine(loc, prev) {
+			return false
+		}
+	}
+	for i, b := range m.Debugger.breakpoints {
+		if b.watch != "" || loc.Line != b.Line {
+			continue
+		}
+		if loc.File == b.File || m.Debugger.dap != nil && m.Debugger.dap.sourcePath(m, loc) == b.File {
+			if b.shouldStop(m, i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shouldStop returns true if the program must stop at breakpoint b, whose
+// id is i, according to its condition and hit count condition.
+func (b *breakpoint) shouldStop(m *Machine, i int) bool {
+	if b.cond != nil {
+		ok, err := debugEvalCond(m, b.cond)
+		if err != nil {
+			// Stop, so the user can fix the condition.
+			fmt.Fprintf(m.Debugger.out, "Breakpoint %d: error evaluating condition: %v\n", i, err)
 			return true
 		}
+		if !ok {
+			return false
+		}
+	}
+	b.hits++
+	switch b.hitOp {
+	case "":
+		return true
+	case "==":
+		return b.hits == b.hitCount
+	case "!=":
+		return b.hits != b.hitCount
+	case ">":
+		return b.hits > b.hitCount
+	case ">=":
+		return b.hits >= b.hitCount
+	case "<":
+		return b.hits < b.hitCount
+	case "<=":
+		return b.hits <= b.hitCount
+	case "%":
+		return b.hits%b.hitCount == 0
 	}
 	return false
 }
 
+// sameValue returns true if a and b are the same value. Composite values
+// are the same if they refer to the same object.
+func sameValue(a, b TypedValue) (same bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			same = false // not comparable
+		}
+	}()
+	return a.T == b.T && a.N == b.N && a.V == b.V
+}
+
+// debugStopped notifies the user that the program stopped for the given
+// reason, after a breakpoint or a step command.
+func debugStopped(m *Machine, reason string) {
+	switch {
+	case m.Debugger.dap != nil:
+		m.Debugger.dap.stopped(m.Debugger.loc, reason)
+	case m.Debugger.lastCmd == "si" || m.Debugger.lastCmd == "stepi":
+		debugLineInfo(m)
+	default:
+		debugList(m, "")
+	}
+}
+
 // debugCmd processes a debugger REPL command. It displays a prompt, then
 // reads and parses a command from the debugger input stream, then executes
 // the corresponding function or returns an error.
@@ -271,19 +412,38 @@ func trimLeftSpace(s string) string { return strings.TrimLeftFunc(s, unicode.IsS
 func indexSpace(s string) int       { return strings.IndexFunc(s, unicode.IsSpace) }
 
 // Serve waits for a remote client to connect to addr and use this connection for debugger IO.
+// If the client speaks the Debug Adapter Protocol (DAP), as editors do, the
+// debugger is driven by DAP requests instead of the text commands.
 // It returns an error if the connection can not be established, or nil.
 func (d *Debugger) Serve(addr string) error {
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
 	}
+	defer l.Close()
 	print("Waiting for debugger client to connect at ", addr)
 	conn, err := l.Accept()
 	if err != nil {
 		return err
 	}
 	println(" connected!")
-	d.in, d.out = conn, conn
+
+	// DAP clients send a request, starting with a Content-Length header,
+	// as soon as they are connected.
+	br := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(dapDetectTimeout))
+	b, err := br.Peek(1)
+	conn.SetReadDeadline(time.Time{})
+	if err == nil && b[0] == 'C' {
+		d.dap = newDAPSession(br, conn)
+		d.in, d.out = conn, io.Discard
+		return nil
+	}
+	d.in = struct {
+		io.Reader
+		io.Closer
+	}{br, conn}
+	d.out = conn
 	return nil
 }
 
@@ -332,7 +492,7 @@ func debugUpdateLocation(m *Machine) {
 
 // ---------------------------------------
 const (
-	breakUsage = `break|b [locspec]`
+	breakUsage = `break|b [locspec] [if <condition>]`
 	breakShort = `Set a breakpoint.`
 	breakLong  = `
 The syntax accepted for locspec is:
@@ -340,22 +500,46 @@ The syntax accepted for locspec is:
 - <line> specifies the line in the current source file.
 - +<offset> specifies the line offset lines after the current one.
 - -<offset> specifies the line offset lines before the current one.
+
+If a condition is specified, the program stops at the breakpoint only if
+the condition evaluates to true. See 'help condition'.
 `
 )
 
 func debugBreak(m *Machine, arg string) error {
-	loc, err := parseLocSpec(m, arg)
+	spec, cond, hasCond := strings.Cut(arg, " if ")
+	loc, err := parseLocSpec(m, strings.TrimSpace(spec))
 	if err != nil {
 		return err
 	}
-	m.Debugger.breakpoints = append(m.Debugger.breakpoints, loc)
+	b := &breakpoint{Location: loc}
+	if hasCond {
+		if b.cond, err = parser.ParseExpr(cond); err != nil {
+			return err
+		}
+	}
+	m.Debugger.breakpoints = append(m.Debugger.breakpoints, b)
 	printBreakpoint(m, len(m.Debugger.breakpoints)-1)
 	return nil
 }
 
 func printBreakpoint(m *Machine, i int) {
 	b := m.Debugger.breakpoints[i]
-	fmt.Fprintf(m.Debugger.out, "Breakpoint %d at %s %s\n", i, b.PkgPath, b)
+	if b.watch != "" {
+		fmt.Fprintf(m.Debugger.out, "Watchpoint %d on %s", i, b.watch)
+	} else {
+		fmt.Fprintf(m.Debugger.out, "Breakpoint %d at %s %s", i, b.PkgPath, b.Location)
+	}
+	if b.cond != nil {
+		fmt.Fprintf(m.Debugger.out, " if %s", types.ExprString(b.cond))
+	}
+	if b.hitOp != "" {
+		fmt.Fprintf(m.Debugger.out, " (hit count %s %d)", b.hitOp, b.hitCount)
+	}
+	if b.hits > 0 {
+		fmt.Fprintf(m.Debugger.out, " [hits: %d]", b.hits)
+	}
+	fmt.Fprintln(m.Debugger.out)
 }
 
 func parseLocSpec(m *Machine, arg string) (loc Location, err error) {
@@ -436,6 +620,73 @@ func debugClear(m *Machine, arg string) error {
 	return nil
 }
 
+// ---------------------------------------
+const (
+	conditionUsage = `condition|cond [-hitcount] <id> [condition]`
+	conditionShort = `Set or remove the condition of a breakpoint.`
+	conditionLong  = `
+The condition is an expression which must evaluate to a boolean. It may
+use the values accepted by 'print', combined with the comparison operators
+==, !=, <, <=, >, >=, and the logical operators &&, || and !.
+If the condition is empty, the breakpoint becomes unconditional.
+
+With -hitcount, the condition applies to the number of times the breakpoint
+was hit, and is of the form '<op> <n>', where op is one of ==, !=, <, <=, >,
+>= or %, which stops every n hits. If op is omitted, it defaults to >=.
+`
+)
+
+func debugCondition(m *Machine, arg string) error {
+	hitCount := false
+	if rest, ok := strings.CutPrefix(arg, "-hitcount"); ok {
+		hitCount = true
+		arg = trimLeftSpace(rest)
+	}
+	sid, cond, _ := strings.Cut(arg, " ")
+	id, err := strconv.Atoi(sid)
+	if err != nil || id < 0 || id >= len(m.Debugger.breakpoints) {
+		return fmt.Errorf("invalid breakpoint id: %v", sid)
+	}
+	b := m.Debugger.breakpoints[id]
+	cond = strings.TrimSpace(cond)
+	switch {
+	case hitCount:
+		if b.hitOp, b.hitCount, err = parseHitCondition(cond); err != nil {
+			return err
+		}
+	case cond == "":
+		b.cond = nil
+	default:
+		if b.cond, err = parser.ParseExpr(cond); err != nil {
+			return err
+		}
+	}
+	printBreakpoint(m, id)
+	return nil
+}
+
+// parseHitCondition parses a hit count condition such as '>= 3'.
+// An empty condition removes the hit count condition.
+func parseHitCondition(cond string) (op string, n int, err error) {
+	if cond == "" {
+		return "", 0, nil
+	}
+	op = ">="
+	for _, o := range []string{"==", "!=", "<=", ">=", "<", ">", "%"} {
+		if rest, ok := strings.CutPrefix(cond, o); ok {
+			op, cond = o, strings.TrimSpace(rest)
+			break
+		}
+	}
+	if n, err = strconv.Atoi(cond); err != nil {
+		return "", 0, err
+	}
+	if op == "%" && n <= 0 {
+		return "", 0, fmt.Errorf("invalid hit count modulo: %d", n)
+	}
+	return op, n, nil
+}
+
 // ---------------------------------------
 // NOTE: the difference between continue, next, step, stepi and stepout is handled within the Debug() loop.
 const (
@@ -460,6 +711,11 @@ func debugContinue(m *Machine, arg string) error {
 	m.Debugger.frameLevel = 0
 	m.Debugger.nextDepth = callDepth(m)
 	m.Debugger.nextLoc = m.Debugger.loc
+	for _, b := range m.Debugger.breakpoints {
+		if b.watch != "" {
+			b.old = b.ptr.Deref() // only report changes from now on
+		}
+	}
 	return nil
 }
 
@@ -646,13 +902,66 @@ func debugPrint(m *Machine, arg string) (err error) {
 	return nil
 }
 
+// ---------------------------------------
+const (
+	setUsage = `set <variable> = <expression>`
+	setShort = `Change the value of a variable.`
+	setLong  = `
+The variable can be any addressable expression accepted by 'print', such as
+a local or global variable, a struct field or a slice element. The value
+must have the same type as the variable, except for literals which are
+converted to the variable type.
+`
+)
+
+func debugSet(m *Machine, arg string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	lhs, rhs, ok := strings.Cut(arg, "=")
+	if !ok {
+		return errors.New("missing '=' in set command")
+	}
+	lx, err := parser.ParseExpr(lhs)
+	if err != nil {
+		return err
+	}
+	rx, err := parser.ParseExpr(rhs)
+	if err != nil {
+		return err
+	}
+	ptr, err := debugEvalPointer(m, lx)
+	if err != nil {
+		return err
+	}
+	tv, err := debugEvalExpr(m, rx)
+	if err != nil {
+		return err
+	}
+	t := ptr.TV.T
+	if t == DataByteType {
+		t = Uint8Type
+	}
+	debugConvertLit(m, rx, &tv, t)
+	if t != nil && tv.T != nil && t.TypeID() != tv.T.TypeID() {
+		return fmt.Errorf("cannot use %v as %s value", tv, t)
+	}
+	ptr.Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+	fmt.Fprintln(m.Debugger.out, ptr.Deref())
+	return nil
+}
+
 // debugEvalExpr evaluates a Go expression in the context of the VM and returns
 // the corresponding typed value, or an error.
 // The supported expression syntax is a small subset of Go expressions:
-// basic literals, identifiers, selectors, index expressions, or a combination
-// of those are supported, but none of function calls, arithmetic, logic or
-// assign operations, type assertions of convertions.
-// This is sufficient for a debugger to perform 'print (*f).S[x][y]' for example.
+// basic literals, identifiers, selectors, index expressions, comparisons,
+// logical operations, or a combination of those are supported, but none of
+// function calls, arithmetic or assign operations, type assertions of convertions.
+// This is sufficient for a debugger to perform 'print (*f).S[x][y]' or
+// 'break 10 if x > 3 && y == "foo"' for example.
 func debugEvalExpr(m *Machine, node ast.Node) (tv TypedValue, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -683,62 +992,213 @@ func debugEvalExpr(m *Machine, node ast.Node) (tv TypedValue, err error) {
 			return typedString(s), nil
 		}
 		return tv, fmt.Errorf("invalid basic literal value: %s", n.Value)
+	case *ast.ParenExpr:
+		return debugEvalExpr(m, n.X)
+	case *ast.Ident, *ast.StarExpr, *ast.SelectorExpr, *ast.IndexExpr:
+		ptr, err := debugEvalPointer(m, n)
+		if err != nil {
+			return tv, err
+		}
+		return ptr.Deref(), nil
+	case *ast.UnaryExpr:
+		switch n.Op {
+		case token.NOT:
+			x, err := debugEvalCond(m, n.X)
+			return typedBool(!x), err
+		case token.SUB:
+			// Only negative numeric literals are supported.
+			if lit, ok := n.X.(*ast.BasicLit); ok && lit.Kind == token.INT {
+				return debugEvalExpr(m, &ast.BasicLit{Kind: lit.Kind, Value: "-" + lit.Value})
+			}
+		}
+	case *ast.BinaryExpr:
+		return debugEvalBinary(m, n)
+	}
+	return tv, fmt.Errorf("expression not supported: %v", node)
+}
+
+// debugEvalPointer evaluates an addressable Go expression in the context of
+// the VM and returns a pointer to the corresponding value, or an error.
+func debugEvalPointer(m *Machine, node ast.Node) (ptr PointerValue, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	switch n := node.(type) {
 	case *ast.Ident:
-		if tv, ok := debugLookup(m, n.Name); ok {
-			return tv, nil
+		if ptr, ok := debugLookupPointer(m, n.Name); ok {
+			return ptr, nil
 		}
-		return tv, fmt.Errorf("could not find symbol value for %s", n.Name)
+		return ptr, fmt.Errorf("could not find symbol value for %s", n.Name)
 	case *ast.ParenExpr:
-		return debugEvalExpr(m, n.X)
+		return debugEvalPointer(m, n.X)
 	case *ast.StarExpr:
 		x, err := debugEvalExpr(m, n.X)
 		if err != nil {
-			return tv, err
+			return ptr, err
 		}
 		pv, ok := x.V.(PointerValue)
 		if !ok {
-			return tv, fmt.Errorf("Not a pointer value: %v", x)
+			return ptr, fmt.Errorf("Not a pointer value: %v", x)
 		}
-		return pv.Deref(), nil
+		return pv, nil
 	case *ast.SelectorExpr:
 		x, err := debugEvalExpr(m, n.X)
 		if err != nil {
-			return tv, err
+			return ptr, err
 		}
 		if pv, ok := x.V.(*PackageValue); ok {
-			if i, ok := pv.Block.(*Block).Source.GetLocalIndex(Name(n.Sel.Name)); ok {
-				return pv.Block.(*Block).Values[i], nil
+			b := pv.Block.(*Block)
+			if i, ok := b.Source.GetLocalIndex(Name(n.Sel.Name)); ok {
+				return b.GetPointerToInt(m.Store, int(i)), nil
 			}
-			return tv, fmt.Errorf("invalid selector: %s", n.Sel.Name)
+			return ptr, fmt.Errorf("invalid selector: %s", n.Sel.Name)
 		}
 		tr, _, _, _, _ := findEmbeddedFieldType(x.T.GetPkgPath(), x.T, Name(n.Sel.Name), nil)
 		if len(tr) == 0 {
-			return tv, fmt.Errorf("invalid selector: %s", n.Sel.Name)
+			return ptr, fmt.Errorf("invalid selector: %s", n.Sel.Name)
 		}
-		for _, vp := range tr {
-			x = x.GetPointerToFromTV(m.Alloc, m.Store, vp).Deref()
+		for i, vp := range tr {
+			ptr = x.GetPointerToFromTV(m.Alloc, m.Store, vp)
+			if i < len(tr)-1 {
+				x = ptr.Deref()
+			}
 		}
-		return x, nil
+		return ptr, nil
 	case *ast.IndexExpr:
 		x, err := debugEvalExpr(m, n.X)
 		if err != nil {
-			return tv, err
+			return ptr, err
 		}
 		index, err := debugEvalExpr(m, n.Index)
 		if err != nil {
-			return tv, err
+			return ptr, err
 		}
-		return x.GetPointerAtIndex(m.Realm, m.Alloc, m.Store, &index).Deref(), nil
+		return x.GetPointerAtIndex(m.Realm, m.Alloc, m.Store, &index), nil
+	}
+	return ptr, fmt.Errorf("expression not supported: %v", node)
+}
+
+// debugEvalBinary evaluates the comparison or logical operation n.
+func debugEvalBinary(m *Machine, n *ast.BinaryExpr) (tv TypedValue, err error) {
+	switch n.Op {
+	case token.LAND, token.LOR:
+		x, err := debugEvalCond(m, n.X)
+		if err != nil || x == (n.Op == token.LOR) {
+			return typedBool(x), err
+		}
+		y, err := debugEvalCond(m, n.Y)
+		return typedBool(y), err
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+	default:
+		return tv, fmt.Errorf("expression not supported: %v", n)
+	}
+	x, err := debugEvalExpr(m, n.X)
+	if err != nil {
+		return tv, err
+	}
+	y, err := debugEvalExpr(m, n.Y)
+	if err != nil {
+		return tv, err
+	}
+	// Literals are converted to the type of the other operand.
+	debugConvertLit(m, n.X, &x, y.T)
+	debugConvertLit(m, n.Y, &y, x.T)
+	if x.T != nil && y.T != nil && x.T.TypeID() != y.T.TypeID() {
+		return tv, fmt.Errorf("mismatched types %s and %s", x.T, y.T)
+	}
+	switch n.Op {
+	case token.EQL:
+		return typedBool(isEql(m.Store, &x, &y)), nil
+	case token.NEQ:
+		return typedBool(!isEql(m.Store, &x, &y)), nil
+	case token.LSS:
+		return typedBool(isLss(&x, &y)), nil
+	case token.LEQ:
+		return typedBool(isLeq(&x, &y)), nil
+	case token.GTR:
+		return typedBool(isGtr(&x, &y)), nil
 	default:
-		err = fmt.Errorf("expression not supported: %v", n)
+		return typedBool(isGeq(&x, &y)), nil
+	}
+}
+
+// debugEvalCond evaluates a boolean expression.
+func debugEvalCond(m *Machine, node ast.Node) (bool, error) {
+	tv, err := debugEvalExpr(m, node)
+	if err != nil {
+		return false, err
+	}
+	if tv.T == nil || tv.T.Kind() != BoolKind {
+		return false, fmt.Errorf("not a boolean value: %v", tv)
+	}
+	return tv.GetBool(), nil
+}
+
+// debugConvertLit converts tv, the value of node, to type t if node is a
+// literal, as Go does for untyped constants.
+func debugConvertLit(m *Machine, node ast.Node, tv *TypedValue, t Type) {
+	if u, ok := node.(*ast.UnaryExpr); ok && u.Op == token.SUB {
+		node = u.X
+	}
+	if _, ok := node.(*ast.BasicLit); !ok || t == nil || tv.T == t {
+		return
 	}
-	return tv, err
+	ConvertTo(m.Alloc, m.Store, tv, t, false)
 }
 
 // debugLookup returns the current VM value corresponding to name ident in
 // the current function call frame, or the global frame if not found.
 // Note: the commands 'up' and 'down' change the frame level to start from.
 func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
+	ptr, ok := debugLookupPointer(m, name)
+	if !ok {
+		return tv, false
+	}
+	return ptr.Deref(), true
+}
+
+// debugLookupPointer is like debugLookup, but returns a pointer to the value.
+func debugLookupPointer(m *Machine, name string) (ptr PointerValue, ok bool) {
+	sblocks := debugFrameBlocks(m)
+	if sblocks == nil {
+		return ptr, false
+	}
+
+	// Search value in current frame level blocks, or main scope.
+	for _, b := range sblocks {
+		switch t := b.Source.(type) {
+		case *IfStmt:
+			for i, s := range ifBody(m, t).Source.GetBlockNames() {
+				if string(s) == name {
+					return b.GetPointerToInt(m.Store, i), true
+				}
+			}
+		}
+		for i, s := range b.Source.GetBlockNames() {
+			if string(s) == name {
+				return b.GetPointerToInt(m.Store, i), true
+			}
+		}
+	}
+	// Fallback: search a global value, like StaticBlock.GetSlot.
+	bn := sblocks[0].Source
+	for bn != nil {
+		sb := bn.GetStaticBlock()
+		if i, ok := sb.GetLocalIndex(Name(name)); ok && sb.Types[i] != nil {
+			return sb.GetBlock().GetPointerToInt(m.Store, int(i)), true
+		}
+		bn = bn.GetParentNode(m.Store)
+	}
+	return ptr, false
+}
+
+// debugFrameBlocks returns the blocks of the current frame level, from the
+// innermost to the function block, followed by the global block.
+// It returns nil if the frame level doesn't exist.
+func debugFrameBlocks(m *Machine) []*Block {
 	// Position to the right frame.
 	ncall := 0
 	var i int
@@ -757,7 +1217,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		}
 	}
 	if i < 0 {
-		return tv, false
+		return nil
 	}
 
 	// XXX The following logic isn't necessary and it isn't correct either.
@@ -791,7 +1251,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		}
 	}
 	if i < 0 {
-		return tv, false
+		return nil
 	}
 
 	// get SourceBlocks in the same frame level.
@@ -805,28 +1265,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 	if i > 0 {
 		sblocks = append(sblocks, m.Blocks[0]) // Add global block
 	}
-
-	// Search value in current frame level blocks, or main scope.
-	for _, b := range sblocks {
-		switch t := b.Source.(type) {
-		case *IfStmt:
-			for i, s := range ifBody(m, t).Source.GetBlockNames() {
-				if string(s) == name {
-					return b.Values[i], true
-				}
-			}
-		}
-		for i, s := range b.Source.GetBlockNames() {
-			if string(s) == name {
-				return b.Values[i], true
-			}
-		}
-	}
-	// Fallback: search a global value.
-	if v := sblocks[0].Source.GetSlot(m.Store, Name(name), true); v != nil {
-		return *v, true
-	}
-	return tv, false
+	return sblocks
 }
 
 // ifBody returns the Then or Else body corresponding to the current location.
@@ -903,3 +1342,38 @@ func debugUp(m *Machine, arg string) (err error) {
 	debugList(m, "")
 	return nil
 }
+
+// ---------------------------------------
+const (
+	watchUsage = `watch <expression>`
+	watchShort = `Set a watchpoint.`
+	watchLong  = `
+The program stops when the value of the expression changes. The expression
+must be addressable, like the variables of the 'set' command, and is
+evaluated once when the watchpoint is set, so the same variable or realm
+object field is watched even after leaving its scope. For composite values,
+only the assignment of a new value is detected, not the change of one of
+its elements: watch the element itself instead.
+
+Watchpoints are listed with breakpoints, and can also be removed with
+'clear' or made conditional with 'condition'.
+`
+)
+
+func debugWatch(m *Machine, arg string) error {
+	if arg == "" {
+		return errors.New("missing argument")
+	}
+	x, err := parser.ParseExpr(arg)
+	if err != nil {
+		return err
+	}
+	ptr, err := debugEvalPointer(m, x)
+	if err != nil {
+		return err
+	}
+	b := &breakpoint{watch: arg, ptr: ptr, old: ptr.Deref()}
+	m.Debugger.breakpoints = append(m.Debugger.breakpoints, b)
+	printBreakpoint(m, len(m.Debugger.breakpoints)-1)
+	return nil
+}
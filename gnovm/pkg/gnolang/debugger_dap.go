@@ -0,0 +1,698 @@
+package gnolang
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// This file implements a Debug Adapter Protocol (DAP) server on top of the
+// debugger, so that editors can drive it. See the specification at
+// https://microsoft.github.io/debug-adapter-protocol/specification.
+//
+// The debugger state machine is unchanged: when stopped, DAP requests are
+// processed instead of text commands, and execution requests (continue,
+// next, stepIn, stepOut) are mapped to the corresponding text commands.
+
+const (
+	dapDetectTimeout = time.Second
+	dapThreadID      = 1 // the VM is single threaded
+	dapMaxChildren   = 1000
+	dapMaxValueLen   = 1024
+)
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type dapResponse struct {
+	Seq        int    `json:"seq"`
+	Type       string `json:"type"`
+	RequestSeq int    `json:"request_seq"`
+	Success    bool   `json:"success"`
+	Command    string `json:"command"`
+	Message    string `json:"message,omitempty"`
+	Body       any    `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int    `json:"seq"`
+	Type  string `json:"type"`
+	Event string `json:"event"`
+	Body  any    `json:"body,omitempty"`
+}
+
+type dapSource struct {
+	Name            string `json:"name,omitempty"`
+	Path            string `json:"path,omitempty"`
+	SourceReference int    `json:"sourceReference,omitempty"`
+}
+
+type dapBreakpoint struct {
+	Verified bool   `json:"verified"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+type dapStackFrame struct {
+	ID     int        `json:"id"`
+	Name   string     `json:"name"`
+	Source *dapSource `json:"source,omitempty"`
+	Line   int        `json:"line"`
+	Column int        `json:"column"`
+}
+
+type dapScope struct {
+	Name               string `json:"name"`
+	PresentationHint   string `json:"presentationHint,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// dapVarRef is the target of a DAP variables reference.
+type dapVarRef struct {
+	scope string     // "locals" or "globals", or "" for a value
+	level int        // frame level of the scope
+	tv    TypedValue // value, if scope is ""
+}
+
+// dapSession is a DAP session with a remote client.
+type dapSession struct {
+	w    io.WriteCloser
+	mu   sync.Mutex // protects w and seq
+	seq  int
+	reqs chan *dapRequest // requests read from the client
+
+	stopOnEntry bool
+	lastLoc     Location          // last location checked for breakpoints
+	vars        []dapVarRef       // variables references of the current stop, starting at 1
+	sources     []Location        // source references, starting at 1
+	paths       map[string]string // client paths of source files, by base name
+	locPaths    map[Location]string
+}
+
+func newDAPSession(r *bufio.Reader, w io.WriteCloser) *dapSession {
+	s := &dapSession{
+		w:        w,
+		reqs:     make(chan *dapRequest, 64),
+		paths:    map[string]string{},
+		locPaths: map[Location]string{},
+	}
+	go s.read(r)
+	return s
+}
+
+// read reads requests from r until an error occurs, and sends them to the
+// reqs channel, which is closed at the end. Errors are reported to the client.
+func (s *dapSession) read(r *bufio.Reader) {
+	defer close(s.reqs)
+	for {
+		b, err := readDAPMessage(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				s.output("DAP: " + err.Error())
+			}
+			return
+		}
+		req := &dapRequest{}
+		if err := json.Unmarshal(b, req); err != nil {
+			s.output("DAP: invalid message: " + err.Error())
+			continue
+		}
+		if req.Type == "request" {
+			s.reqs <- req
+		}
+	}
+}
+
+// readDAPMessage reads a message, made of headers followed by a JSON content.
+func readDAPMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			if length, err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+	b := make([]byte, length)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+func (s *dapSession) send(msg any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	switch msg := msg.(type) {
+	case *dapResponse:
+		msg.Seq = s.seq
+	case *dapEvent:
+		msg.Seq = s.seq
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(b), b)
+}
+
+func (s *dapSession) respond(req *dapRequest, body any) {
+	s.send(&dapResponse{Type: "response", RequestSeq: req.Seq, Success: true, Command: req.Command, Body: body})
+}
+
+func (s *dapSession) respondError(req *dapRequest, err error) {
+	s.send(&dapResponse{Type: "response", RequestSeq: req.Seq, Command: req.Command, Message: err.Error()})
+}
+
+func (s *dapSession) event(name string, body any) {
+	s.send(&dapEvent{Type: "event", Event: name, Body: body})
+}
+
+// output sends an error message to the client, to be shown in its console.
+func (s *dapSession) output(msg string) {
+	s.event("output", map[string]any{"category": "stderr", "output": msg + "\n"})
+}
+
+// stopped notifies the client that the program stopped at loc. Variables
+// references are only valid while the program is stopped.
+func (s *dapSession) stopped(loc Location, reason string) {
+	s.lastLoc = loc
+	s.vars = nil
+	s.event("stopped", map[string]any{"reason": reason, "threadId": dapThreadID, "allThreadsStopped": true})
+}
+
+// close notifies the client that the program terminated, waits briefly for
+// it to disconnect, and closes the connection.
+func (s *dapSession) close() error {
+	s.event("exited", map[string]any{"exitCode": 0})
+	s.event("terminated", nil)
+	timeout := time.After(dapDetectTimeout)
+loop:
+	for {
+		select {
+		case req, ok := <-s.reqs:
+			if !ok {
+				break loop
+			}
+			s.respond(req, nil)
+			if req.Command == "disconnect" {
+				break loop
+			}
+		case <-timeout:
+			break loop
+		}
+	}
+	return s.w.Close()
+}
+
+// dapCmd processes the next DAP request while the program is stopped.
+// Errors are reported to the client.
+func dapCmd(m *Machine) {
+	req, ok := <-m.Debugger.dap.reqs
+	if !ok {
+		// The client is gone, resume the program.
+		if err := debugDetach(m, ""); err != nil {
+			fmt.Fprintln(m.Debugger.out, "Command failed:", err)
+		}
+		return
+	}
+	dapHandle(m, req)
+}
+
+// dapPoll processes the pending DAP requests while the program is running.
+// It returns true if the program was paused.
+func dapPoll(m *Machine) bool {
+	s := m.Debugger.dap
+	for len(s.reqs) > 0 {
+		req, ok := <-s.reqs
+		if !ok {
+			break
+		}
+		if req.Command == "pause" {
+			s.respond(req, nil)
+			m.Debugger.state = DebugAtCmd
+			m.Debugger.prevLoc = m.Debugger.loc
+			s.stopped(m.Debugger.loc, "pause")
+			return true
+		}
+		dapHandle(m, req)
+	}
+	return false
+}
+
+// dapHandle processes a DAP request, and sends an error response to the
+// client if it fails.
+func dapHandle(m *Machine, req *dapRequest) {
+	s := m.Debugger.dap
+	defer func() {
+		if r := recover(); r != nil {
+			s.respondError(req, fmt.Errorf("%v", r))
+		}
+	}()
+	if err := dapDispatch(m, req); err != nil {
+		s.respondError(req, err)
+	}
+}
+
+// dapDispatch runs the command of a DAP request.
+func dapDispatch(m *Machine, req *dapRequest) error {
+	s := m.Debugger.dap
+	switch req.Command {
+	case "initialize":
+		s.respond(req, map[string]any{
+			"supportsConfigurationDoneRequest":  true,
+			"supportsEvaluateForHovers":         true,
+			"supportsConditionalBreakpoints":    true,
+			"supportsHitConditionalBreakpoints": true,
+			"supportTerminateDebuggee":          true,
+			"supportsTerminateRequest":          true,
+		})
+		s.event("initialized", nil)
+	case "launch", "attach":
+		var args struct {
+			StopOnEntry bool `json:"stopOnEntry"`
+		}
+		if len(req.Arguments) > 0 {
+			if err := json.Unmarshal(req.Arguments, &args); err != nil {
+				return err
+			}
+		}
+		s.stopOnEntry = args.StopOnEntry
+		s.respond(req, nil)
+	case "setBreakpoints":
+		return dapSetBreakpoints(m, req)
+	case "setExceptionBreakpoints", "setFunctionBreakpoints":
+		s.respond(req, map[string]any{"breakpoints": []dapBreakpoint{}})
+	case "configurationDone":
+		s.respond(req, nil)
+		if s.stopOnEntry {
+			s.stopped(m.Debugger.loc, "entry")
+			return nil
+		}
+		m.Debugger.lastCmd = "continue"
+		return debugContinue(m, "")
+	case "threads":
+		s.respond(req, map[string]any{"threads": []map[string]any{{"id": dapThreadID, "name": "main"}}})
+	case "stackTrace":
+		s.respond(req, dapStackTrace(m))
+	case "scopes":
+		var args struct {
+			FrameID int `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return err
+		}
+		s.respond(req, map[string]any{"scopes": []dapScope{
+			{Name: "Locals", PresentationHint: "locals", VariablesReference: s.addVar(dapVarRef{scope: "locals", level: args.FrameID})},
+			{Name: "Globals", VariablesReference: s.addVar(dapVarRef{scope: "globals", level: args.FrameID})},
+		}})
+	case "variables":
+		var args struct {
+			VariablesReference int `json:"variablesReference"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return err
+		}
+		if args.VariablesReference < 1 || args.VariablesReference > len(s.vars) {
+			return fmt.Errorf("invalid variables reference: %d", args.VariablesReference)
+		}
+		s.respond(req, map[string]any{"variables": dapVariables(m, s.vars[args.VariablesReference-1])})
+	case "evaluate":
+		var args struct {
+			Expression string `json:"expression"`
+			FrameID    int    `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return err
+		}
+		expr, err := parser.ParseExpr(args.Expression)
+		if err != nil {
+			return err
+		}
+		level := m.Debugger.frameLevel
+		m.Debugger.frameLevel = args.FrameID
+		tv, err := debugEvalExpr(m, expr)
+		m.Debugger.frameLevel = level
+		if err != nil {
+			return err
+		}
+		v := s.variable(m, "", tv)
+		s.respond(req, map[string]any{"result": v.Value, "type": v.Type, "variablesReference": v.VariablesReference})
+	case "source":
+		var args struct {
+			SourceReference int `json:"sourceReference"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return err
+		}
+		if args.SourceReference < 1 || args.SourceReference > len(s.sources) {
+			return fmt.Errorf("invalid source reference: %d", args.SourceReference)
+		}
+		loc := s.sources[args.SourceReference-1]
+		src, err := fileContent(m.Store, loc.PkgPath, loc.File)
+		if err != nil && m.Debugger.getSrc != nil {
+			src, err = m.Debugger.getSrc(loc.PkgPath, loc.File), nil
+		}
+		if err != nil {
+			return err
+		}
+		s.respond(req, map[string]any{"content": src})
+	case "continue":
+		s.respond(req, map[string]any{"allThreadsContinued": true})
+		m.Debugger.lastCmd = "continue"
+		return debugContinue(m, "")
+	case "next":
+		s.respond(req, nil)
+		m.Debugger.lastCmd = "next"
+		return debugContinue(m, "")
+	case "stepIn":
+		var args struct {
+			Granularity string `json:"granularity"`
+		}
+		if len(req.Arguments) > 0 {
+			if err := json.Unmarshal(req.Arguments, &args); err != nil {
+				return err
+			}
+		}
+		s.respond(req, nil)
+		m.Debugger.lastCmd = "step"
+		if args.Granularity == "instruction" {
+			m.Debugger.lastCmd = "stepi"
+		}
+		return debugContinue(m, "")
+	case "stepOut":
+		s.respond(req, nil)
+		m.Debugger.lastCmd = "stepout"
+		return debugContinue(m, "")
+	case "pause":
+		// Already stopped.
+		s.respond(req, nil)
+	case "disconnect":
+		var args struct {
+			TerminateDebuggee *bool `json:"terminateDebuggee"`
+		}
+		if len(req.Arguments) > 0 {
+			if err := json.Unmarshal(req.Arguments, &args); err != nil {
+				return err
+			}
+		}
+		s.respond(req, nil)
+		if args.TerminateDebuggee == nil || *args.TerminateDebuggee {
+			return debugExit(m, "")
+		}
+		return debugDetach(m, "")
+	case "terminate":
+		s.respond(req, nil)
+		s.event("terminated", nil)
+		return debugExit(m, "")
+	default:
+		return fmt.Errorf("unsupported request: %s", req.Command)
+	}
+	return nil
+}
+
+// dapSetBreakpoints replaces all the breakpoints of a source file.
+func dapSetBreakpoints(m *Machine, req *dapRequest) error {
+	s := m.Debugger.dap
+	var args struct {
+		Source      dapSource `json:"source"`
+		Breakpoints []struct {
+			Line         int    `json:"line"`
+			Condition    string `json:"condition"`
+			HitCondition string `json:"hitCondition"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(req.Arguments, &args); err != nil {
+		return err
+	}
+	path := args.Source.Path
+	if path == "" {
+		return errors.New("missing source path")
+	}
+	s.paths[filepath.Base(path)] = path
+	clear(s.locPaths)
+
+	bps := m.Debugger.breakpoints[:0]
+	for _, b := range m.Debugger.breakpoints {
+		if b.File != path {
+			bps = append(bps, b)
+		}
+	}
+	res := make([]dapBreakpoint, len(args.Breakpoints))
+	for i, b := range args.Breakpoints {
+		res[i] = dapBreakpoint{Line: b.Line}
+		bp := &breakpoint{Location: Location{File: path, Span: Span{Pos: Pos{Line: b.Line}}}}
+		var err error
+		if b.Condition != "" {
+			bp.cond, err = parser.ParseExpr(b.Condition)
+		}
+		if err == nil {
+			bp.hitOp, bp.hitCount, err = parseHitCondition(strings.TrimSpace(b.HitCondition))
+		}
+		if err != nil {
+			res[i].Message = err.Error()
+			continue
+		}
+		res[i].Verified = true
+		bps = append(bps, bp)
+	}
+	m.Debugger.breakpoints = bps
+	s.respond(req, map[string]any{"breakpoints": res})
+	return nil
+}
+
+func dapStackTrace(m *Machine) map[string]any {
+	s := m.Debugger.dap
+	frames := []dapStackFrame{}
+	for i := 0; ; i++ {
+		ff := debugFrameFunc(m, i)
+		if ff == nil {
+			break
+		}
+		loc := debugFrameLoc(m, i)
+		var fname string
+		if ff.IsMethod {
+			fname = fmt.Sprintf("%v.(%v).%v", ff.PkgPath, ff.Type.(*FuncType).Params[0].Type, ff.Name)
+		} else {
+			fname = fmt.Sprintf("%v.%v", ff.PkgPath, ff.Name)
+		}
+		frames = append(frames, dapStackFrame{
+			ID:     i,
+			Name:   fname,
+			Source: s.source(m, loc),
+			Line:   loc.Line,
+			Column: max(loc.Column, 1),
+		})
+	}
+	return map[string]any{"stackFrames": frames, "totalFrames": len(frames)}
+}
+
+// source returns the DAP source of loc. If the file can't be found on disk,
+// its content will be provided by the source request.
+func (s *dapSession) source(m *Machine, loc Location) *dapSource {
+	if loc.File == "" {
+		return nil
+	}
+	if p := s.sourcePath(m, loc); p != "" {
+		return &dapSource{Name: filepath.Base(p), Path: p}
+	}
+	key := Location{PkgPath: loc.PkgPath, File: loc.File}
+	for i, l := range s.sources {
+		if l == key {
+			return &dapSource{Name: loc.File, SourceReference: i + 1}
+		}
+	}
+	s.sources = append(s.sources, key)
+	return &dapSource{Name: loc.File, SourceReference: len(s.sources)}
+}
+
+// sourcePath returns the path on disk of the source file of loc, as known
+// by the client, or "".
+func (s *dapSession) sourcePath(m *Machine, loc Location) string {
+	key := Location{PkgPath: loc.PkgPath, File: loc.File}
+	if p, ok := s.locPaths[key]; ok {
+		return p
+	}
+	p := ""
+	if filepath.IsAbs(loc.File) {
+		p = loc.File
+	} else {
+		if rootDir := m.Debugger.rootDir; rootDir != "" {
+			for _, dir := range []string{"gnovm/stdlibs", "examples"} {
+				if f := filepath.Join(rootDir, dir, loc.PkgPath, loc.File); fileExists(f) {
+					p = f
+					break
+				}
+			}
+		}
+		if p == "" {
+			if f, ok := s.paths[filepath.Base(loc.File)]; ok {
+				p = f
+			} else if fileExists(loc.File) {
+				p, _ = filepath.Abs(loc.File)
+			}
+		}
+	}
+	s.locPaths[key] = p
+	return p
+}
+
+func fileExists(name string) bool {
+	fi, err := os.Stat(name)
+	return err == nil && !fi.IsDir()
+}
+
+func (s *dapSession) addVar(ref dapVarRef) int {
+	s.vars = append(s.vars, ref)
+	return len(s.vars)
+}
+
+// variable returns the DAP variable of tv. Variables with children get a
+// reference to list them.
+func (s *dapSession) variable(m *Machine, name string, tv TypedValue) dapVariable {
+	if hiv, ok := tv.V.(*HeapItemValue); ok {
+		tv = hiv.Value
+	}
+	fillValueTV(m.Store, &tv)
+	v := dapVariable{Name: name, Value: dapValueString(tv)}
+	if tv.T != nil {
+		v.Type = tv.T.String()
+	}
+	if dapHasChildren(tv) {
+		v.VariablesReference = s.addVar(dapVarRef{tv: tv})
+	}
+	return v
+}
+
+func dapValueString(tv TypedValue) string {
+	if tv.T == nil {
+		return undefinedStr
+	}
+	var str string
+	if bt, ok := baseOf(tv.T).(PrimitiveType); ok && bt.Kind() == StringKind {
+		str = strconv.Quote(tv.GetString())
+	} else {
+		str = tv.ProtectedSprint(newSeenValues(), false)
+	}
+	if len(str) > dapMaxValueLen {
+		str = str[:dapMaxValueLen] + "..."
+	}
+	return str
+}
+
+func dapHasChildren(tv TypedValue) bool {
+	if tv.T == nil || tv.V == nil {
+		return false
+	}
+	switch baseOf(tv.T).(type) {
+	case *PointerType, *StructType:
+		return true
+	case *ArrayType, *SliceType, *MapType:
+		return tv.GetLength() > 0
+	}
+	return false
+}
+
+// dapVariables returns the variables of a scope, or the children of a value.
+func dapVariables(m *Machine, ref dapVarRef) []dapVariable {
+	s := m.Debugger.dap
+	vars := []dapVariable{}
+	switch ref.scope {
+	case "locals":
+		level := m.Debugger.frameLevel
+		m.Debugger.frameLevel = ref.level
+		sblocks := debugFrameBlocks(m)
+		m.Debugger.frameLevel = level
+		seen := map[Name]bool{}
+		for _, b := range sblocks {
+			switch b.Source.(type) {
+			case *FileNode, *PackageNode:
+				continue
+			}
+			for i, n := range b.Source.GetBlockNames() {
+				if seen[n] || n == blankIdentifier || strings.HasPrefix(string(n), ".") || i >= len(b.Values) {
+					continue
+				}
+				seen[n] = true
+				vars = append(vars, s.variable(m, string(n), b.Values[i]))
+			}
+		}
+	case "globals":
+		ff := debugFrameFunc(m, ref.level)
+		if ff == nil {
+			break
+		}
+		pv := ff.GetPackage(m.Store)
+		pb := pv.GetBlock(m.Store)
+		for i, n := range pb.Source.GetBlockNames() {
+			tv := pb.Values[i]
+			switch tv.T.(type) {
+			case *TypeType:
+				continue
+			case *FuncType:
+				if fv, ok := tv.V.(*FuncValue); ok && fv.Name == n {
+					continue // function declaration
+				}
+			}
+			if strings.HasPrefix(string(n), ".") || n == blankIdentifier {
+				continue
+			}
+			vars = append(vars, s.variable(m, string(n), tv))
+		}
+	default:
+		tv := ref.tv
+		switch bt := baseOf(tv.T).(type) {
+		case *PointerType:
+			vars = append(vars, s.variable(m, "*", tv.V.(PointerValue).Deref()))
+		case *StructType:
+			sv := tv.V.(*StructValue)
+			for i, f := range bt.Fields {
+				vars = append(vars, s.variable(m, string(f.Name), sv.Fields[i]))
+			}
+		case *ArrayType, *SliceType:
+			for i := range min(tv.GetLength(), dapMaxChildren) {
+				vars = append(vars, s.variable(m, "["+strconv.Itoa(i)+"]", tv.GetPointerAtIndexInt(m.Store, i).Deref()))
+			}
+		case *MapType:
+			mv := tv.V.(*MapValue)
+			i := 0
+			for cur := mv.List.Head; cur != nil && i < dapMaxChildren; cur = cur.Next {
+				vars = append(vars, s.variable(m, "["+dapValueString(cur.Key)+"]", cur.Value))
+				i++
+			}
+		}
+	}
+	return vars
+}
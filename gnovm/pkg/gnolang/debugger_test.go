@@ -3,9 +3,11 @@ package gnolang_test
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -63,6 +65,7 @@ func evalTest(debugAddr, in, file string) (out, err string) {
 	m.RunFiles(f)
 	ex, _ := gnolang.ParseExpr("main()")
 	m.Eval(ex)
+	m.Debugger.Close()
 	out, err = bout.String(), berr.String()
 	return
 }
@@ -142,6 +145,21 @@ func TestDebug(t *testing.T) {
 		{in: "b 27\nc\np b\n", out: `("!zero" string)`},
 		// {in: "b 22\nc\np t.A[3]\n", out: "Command failed: &{(\"slice index out of bounds: 3 (len=3)\" string) <nil> <nil>}"},
 		{in: "b 43\nc\nc\nc\np i\ndetach\n", out: "(1 int)"},
+		{in: "b 43 if i == 3\nc\np i\n", out: "(3 int)"},
+		{in: "b 7 if i > 2\nbp\n", out: "sample.gno:7:5-46:2 if i > 2"},
+		{in: "b 7 if foo\nc\n", out: "Breakpoint 0: error evaluating condition: could not find symbol value for foo"},
+		{in: "b 7 if i +\n", out: "Command failed: 1:4: expected operand"},
+		{in: "b 43\ncond 0 i >= 2 && !(x == 7)\nc\np i\n", out: "(2 int)"},
+		{in: "b 43\ncond -hitcount 0 == 4\nc\nbp\n", out: "(hit count == 4) [hits: 4]"},
+		{in: "b 43\ncond -hitcount 0 % 0\n", out: "Command failed: invalid hit count modulo: 0"},
+		{in: "cond 3 i > 0\n", out: "Command failed: invalid breakpoint id: 3"},
+		{in: "p 3 > 2\n", out: "(true bool)"},
+		{in: "b 45\nc\nset x = 10\np x\n", out: "(10 int)"},
+		{in: "b 21\nc\nset t.A[1] = 42\np t.A[1]\n", out: "(42 int)"},
+		{in: "b 45\nc\nset x = \"a\"\n", out: "Command failed: cannot convert StringKind to IntKind"},
+		{in: "set x\n", out: "Command failed: missing '=' in set command"},
+		{in: "b 41\nc\nn\nwatch x\nc\np i\n", out: "Watchpoint 1: x changed from (0 int) to (1 int)"},
+		{in: "watch foo\n", out: "Command failed: could not find symbol value for foo"},
 		{in: "b 37\nc\nnext\n", out: "=>   39:"},
 		{in: "b 40\nc\nnext\n", out: "=>   41:"},
 		{in: "b 22\nc\nstepout\n", out: "=>   40:"},
@@ -198,3 +216,169 @@ func TestRemoteError(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+const dapAddress = "localhost:17359"
+
+type dapClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+	seq  int
+}
+
+func (c *dapClient) send(command string, args any) {
+	c.t.Helper()
+	c.seq++
+	b, err := json.Marshal(map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n%s", len(b), b)
+}
+
+// expect reads messages until the response to command, or the event if
+// command is prefixed with "event:", and returns its body.
+func (c *dapClient) expect(command string) map[string]any {
+	c.t.Helper()
+	c.conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		var length int
+		for {
+			line, err := c.r.ReadString('\n')
+			if err != nil {
+				c.t.Fatalf("waiting for %s: %v", command, err)
+			}
+			line = strings.TrimSpace(line)
+			if line == "" {
+				break
+			}
+			fmt.Sscanf(line, "Content-Length: %d", &length)
+		}
+		b := make([]byte, length)
+		if _, err := io.ReadFull(c.r, b); err != nil {
+			c.t.Fatal(err)
+		}
+		var msg struct {
+			Type    string         `json:"type"`
+			Command string         `json:"command"`
+			Event   string         `json:"event"`
+			Success bool           `json:"success"`
+			Message string         `json:"message"`
+			Body    map[string]any `json:"body"`
+		}
+		if err := json.Unmarshal(b, &msg); err != nil {
+			c.t.Fatal(err)
+		}
+		if msg.Type == "event" && "event:"+msg.Event == command ||
+			msg.Type == "response" && msg.Command == command {
+			if msg.Type == "response" && !msg.Success {
+				c.t.Fatalf("%s failed: %s", command, msg.Message)
+			}
+			return msg.Body
+		}
+	}
+}
+
+func TestRemoteDAP(t *testing.T) {
+	var (
+		conn  net.Conn
+		err   error
+		retry int
+	)
+
+	go evalTest(dapAddress, "", debugTarget)
+
+	for retry = 100; retry > 0; retry-- {
+		conn, err = net.Dial("tcp", dapAddress)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if retry == 0 {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := &dapClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+
+	path, err := filepath.Abs(debugTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.send("initialize", map[string]any{"adapterID": "gno"})
+	if body := c.expect("initialize"); body["supportsConfigurationDoneRequest"] != true {
+		t.Errorf("unexpected capabilities: %v", body)
+	}
+	c.expect("event:initialized")
+	c.send("launch", map[string]any{})
+	c.expect("launch")
+	c.send("setBreakpoints", map[string]any{
+		"source":      map[string]any{"path": path},
+		"breakpoints": []map[string]any{{"line": 7}},
+	})
+	c.expect("setBreakpoints")
+	c.send("configurationDone", nil)
+	c.expect("configurationDone")
+
+	if body := c.expect("event:stopped"); body["reason"] != "breakpoint" {
+		t.Errorf("unexpected stop reason: %v", body["reason"])
+	}
+
+	c.send("stackTrace", map[string]any{"threadId": 1})
+	frames := c.expect("stackTrace")["stackFrames"].([]any)
+	if len(frames) != 3 {
+		t.Fatalf("unexpected stack frames: %v", frames)
+	}
+	frame := frames[0].(map[string]any)
+	if frame["name"] != "main.f" || frame["line"] != 7.0 || frame["source"].(map[string]any)["path"] != path {
+		t.Errorf("unexpected top frame: %v", frame)
+	}
+
+	c.send("scopes", map[string]any{"frameId": 0})
+	scopes := c.expect("scopes")["scopes"].([]any)
+	locals := scopes[0].(map[string]any)["variablesReference"]
+	c.send("variables", map[string]any{"variablesReference": locals})
+	vars := fmt.Sprint(c.expect("variables")["variables"])
+	if !strings.Contains(vars, `name:name type:string value:"hello"`) || !strings.Contains(vars, "name:i type:int value:3") {
+		t.Errorf("unexpected locals: %s", vars)
+	}
+
+	c.send("evaluate", map[string]any{"expression": "s", "frameId": 1})
+	if body := c.expect("evaluate"); body["result"] != `"hello"` {
+		t.Errorf("unexpected evaluate result: %v", body)
+	}
+
+	// Invalid messages are reported to the client.
+	fmt.Fprintf(conn, "Content-Length: 1\r\n\r\n{")
+	if body := c.expect("event:output"); body["category"] != "stderr" || !strings.Contains(body["output"].(string), "invalid message") {
+		t.Errorf("unexpected output event: %v", body)
+	}
+
+	// Stop in method get, and inspect the receiver.
+	c.send("setBreakpoints", map[string]any{
+		"source":      map[string]any{"path": path},
+		"breakpoints": []map[string]any{{"line": 21}},
+	})
+	c.expect("setBreakpoints")
+	c.send("continue", map[string]any{"threadId": 1})
+	c.expect("event:stopped")
+	c.send("evaluate", map[string]any{"expression": "t", "frameId": 0})
+	ref := c.expect("evaluate")["variablesReference"]
+	c.send("variables", map[string]any{"variablesReference": ref})
+	ptr := c.expect("variables")["variables"].([]any)[0].(map[string]any)
+	c.send("variables", map[string]any{"variablesReference": ptr["variablesReference"]})
+	fields := c.expect("variables")["variables"].([]any)[0].(map[string]any)
+	c.send("variables", map[string]any{"variablesReference": fields["variablesReference"]})
+	if elems := fmt.Sprint(c.expect("variables")["variables"]); !strings.Contains(elems, "name:[2] type:int value:3") {
+		t.Errorf("unexpected slice elements: %s", elems)
+	}
+
+	c.send("next", map[string]any{"threadId": 1})
+	if body := c.expect("event:stopped"); body["reason"] != "step" {
+		t.Errorf("unexpected stop reason: %v", body["reason"])
+	}
+
+	c.send("continue", map[string]any{"threadId": 1})
+	c.expect("event:terminated")
+}
@@ -40,6 +40,7 @@ type Machine struct {
 	ReviveEnabled bool          // true if revive() enabled (only in testing mode for now)
 
 	Debugger Debugger
+	Coverage *Coverage // statement coverage, if enabled
 
 	// Configuration
 	Output   io.Writer
@@ -78,7 +79,8 @@ type MachineOptions struct {
 	MaxAllocBytes int64      // or 0 for no limit.
 	GasMeter      store.GasMeter
 	ReviveEnabled bool
-	SkipPackage   bool // don't get/set package or realm.
+	SkipPackage   bool      // don't get/set package or realm.
+	Coverage      *Coverage // records executed statements, if set.
 }
 
 const (
@@ -138,6 +140,7 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	mm.Debugger.in = opts.Input
 	mm.Debugger.out = output
 	mm.ReviveEnabled = opts.ReviveEnabled
+	mm.Coverage = opts.Coverage
 	// Maybe get/set package and realm.
 	if !opts.SkipPackage && opts.PkgPath != "" {
 		pv := (*PackageValue)(nil)
@@ -193,30 +196,37 @@ func (m *Machine) SetActivePackage(pv *PackageValue) {
 func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
 	ch := m.Store.IterMemPackage()
 	for mpkg := range ch {
-		mpkg = MPFProd.FilterMemPackage(mpkg)
-		fset := ParseMemPackage(mpkg)
-		pn := NewPackageNode(Name(mpkg.Name), mpkg.Path, fset)
-		m.Store.SetBlockNode(pn)
-		PredefineFileSet(m.Store, pn, fset)
-		for _, fn := range fset.Files {
-			// Save Types to m.Store (while preprocessing).
-			fn = Preprocess(m.Store, pn, fn).(*FileNode)
-			// Save BlockNodes to m.Store.
-			SaveBlockNodes(m.Store, fn)
-		}
-		// Normally, the fileset would be added onto the
-		// package node only after runFiles(), but we cannot
-		// run files upon restart (only preprocess them).
-		// So, add them here instead.
-		// TODO: is this right?
-		if pn.FileSet == nil {
-			pn.FileSet = fset
-		}
-		// pn.FileSet != nil happens for non-realm file tests.
-		// TODO ensure the files are the same.
+		m.PreprocessFilesAndSaveBlockNodes(mpkg)
 	}
 }
 
+// PreprocessFilesAndSaveBlockNodes is like PreprocessAllFilesAndSaveBlockNodes,
+// for a single mpkg already added to the store; its dependencies must have
+// been preprocessed already.
+func (m *Machine) PreprocessFilesAndSaveBlockNodes(mpkg *std.MemPackage) {
+	mpkg = MPFProd.FilterMemPackage(mpkg)
+	fset := ParseMemPackage(mpkg)
+	pn := NewPackageNode(Name(mpkg.Name), mpkg.Path, fset)
+	m.Store.SetBlockNode(pn)
+	PredefineFileSet(m.Store, pn, fset)
+	for _, fn := range fset.Files {
+		// Save Types to m.Store (while preprocessing).
+		fn = Preprocess(m.Store, pn, fn).(*FileNode)
+		// Save BlockNodes to m.Store.
+		SaveBlockNodes(m.Store, fn)
+	}
+	// Normally, the fileset would be added onto the
+	// package node only after runFiles(), but we cannot
+	// run files upon restart (only preprocess them).
+	// So, add them here instead.
+	// TODO: is this right?
+	if pn.FileSet == nil {
+		pn.FileSet = fset
+	}
+	// pn.FileSet != nil happens for non-realm file tests.
+	// TODO ensure the files are the same.
+}
+
 //----------------------------------------
 // top level Run* methods.
 
@@ -1112,6 +1122,12 @@ func (m *Machine) incrCPU(cycles int64) {
 	m.Cycles += cycles
 }
 
+// IncrCPU charges the given CPU cycles for work done outside of the VM
+// operations, such as by native functions proportionally to their input.
+func (m *Machine) IncrCPU(cycles int64) {
+	m.incrCPU(cycles)
+}
+
 const (
 	// CPU cycles
 	/* Control operators */
@@ -1,6 +1,8 @@
 package gnolang
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"iter"
@@ -270,6 +272,115 @@ func CopyFromCachedStore(destStore, cachedStore Store, cachedBase, cachedIavl st
 	}
 }
 
+// IterPackageState calls fn with the raw entries of baseStore and iavlStore
+// which hold the state of the non-stdlib packages: their objects, realms,
+// types and escaped object hashes. iavl is true for the entries of iavlStore.
+// MemPackages and the package index are not included, see IterMemPackage.
+// fn must copy key and value to retain them.
+// Setting the entries as is in the stores of another chain, where the same
+// MemPackages were added, restores the state of the packages; their block
+// nodes must then be saved with PreprocessFilesAndSaveBlockNodes.
+func IterPackageState(baseStore, iavlStore store.Store, fn func(iavl bool, key, value []byte)) {
+	// Stdlibs are loaded by every chain, so their state is skipped.
+	stdlibs := map[string]bool{}
+	iter := store.PrefixIterator(iavlStore, []byte(backendPackageStdlibPath("")))
+	for ; iter.Valid(); iter.Next() {
+		stdlibs[decodeBackendPackagePathKey(string(iter.Key()))] = true
+	}
+	iter.Close()
+	stdlibIDs := make(map[string]bool, len(stdlibs))
+	for pkgPath := range stdlibs {
+		stdlibIDs[hex.EncodeToString(PkgIDFromPkgPath(pkgPath).Bytes())] = true
+	}
+
+	for _, prefix := range []string{"oid:", "tid:"} {
+		iter := store.PrefixIterator(baseStore, []byte(prefix))
+		for ; iter.Valid(); iter.Next() {
+			key := strings.TrimPrefix(string(iter.Key()), prefix)
+			switch prefix {
+			case "oid:":
+				if pid, _, _ := strings.Cut(key, ":"); stdlibIDs[pid] {
+					continue
+				}
+			case "tid:":
+				if i := strings.LastIndexByte(key, '.'); i > 0 && stdlibs[key[:i]] {
+					continue
+				}
+			}
+			fn(false, iter.Key(), iter.Value())
+
+			// Escaped objects also have their hash in the iavl store.
+			if prefix == "oid:" && !strings.HasSuffix(key, "#realm") {
+				if hash := iavlStore.Get([]byte(key)); hash != nil {
+					fn(true, []byte(key), hash)
+				}
+			}
+		}
+		iter.Close()
+	}
+}
+
+// IterPackageStateOf is like IterPackageState, but only calls fn with the
+// entries holding the state of the package at pkgPath. The entries of
+// baseStore are iterated in the order of their keys, starting from start if
+// it isn't nil, and each hash of iavlStore follows the entry of its object.
+// The iteration stops when fn returns false.
+func IterPackageStateOf(baseStore, iavlStore store.Store, pkgPath string, start []byte, fn func(iavl bool, key, value []byte) bool) {
+	pid := hex.EncodeToString(PkgIDFromPkgPath(pkgPath).Bytes())
+	iter := prefixIteratorFrom(baseStore, []byte("oid:"+pid+":"), start)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if !fn(false, iter.Key(), iter.Value()) {
+			return
+		}
+
+		// Escaped objects also have their hash in the iavl store.
+		key := strings.TrimPrefix(string(iter.Key()), "oid:")
+		if !strings.HasSuffix(key, "#realm") {
+			if hash := iavlStore.Get([]byte(key)); hash != nil {
+				if !fn(true, []byte(key), hash) {
+					return
+				}
+			}
+		}
+	}
+
+	// The type IDs of the package are either "<pkgPath>.<Name>", or
+	// "<pkgPath>[<location>].<Name>" for the types declared in a function;
+	// the prefix also matches the types of other packages, like
+	// "<pkgPath>.v2/...", which are skipped.
+	prefix := "tid:" + pkgPath
+	titer := prefixIteratorFrom(baseStore, []byte(prefix), start)
+	defer titer.Close()
+	for ; titer.Valid(); titer.Next() {
+		rest := strings.TrimPrefix(string(titer.Key()), prefix)
+		switch {
+		case strings.HasPrefix(rest, "."):
+			if strings.ContainsAny(rest[1:], "./") {
+				continue
+			}
+		case strings.HasPrefix(rest, "["+pkgPath+"/"), strings.HasPrefix(rest, "["+pkgPath+":"):
+		default:
+			continue
+		}
+		if !fn(false, titer.Key(), titer.Value()) {
+			return
+		}
+	}
+}
+
+// prefixIteratorFrom iterates over the keys of s with the given prefix, which
+// are greater or equal to start.
+func prefixIteratorFrom(s store.Store, prefix, start []byte) store.Iterator {
+	end := store.PrefixEndBytes(prefix)
+	if bytes.Compare(start, prefix) <= 0 {
+		start = prefix
+	} else if end != nil && bytes.Compare(start, end) > 0 {
+		start = end
+	}
+	return s.Iterator(start, end)
+}
+
 func (ds *defaultStore) GetAllocator() *Allocator {
 	return ds.alloc
 }
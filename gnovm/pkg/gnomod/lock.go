@@ -0,0 +1,152 @@
+package gnomod
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/pelletier/go-toml"
+)
+
+// LockFileName is the name of the lock file, stored next to the root
+// gnomod.toml or gnowork.toml.
+const LockFileName = "gnomod.lock"
+
+// Parsed gnomod.lock file.
+//
+// The lock file records the exact on-chain source used for each remote
+// dependency, so that everyone working on a module tests against the same
+// code.
+type LockFile struct {
+	// Package is the list of locked dependencies, sorted by path.
+	Package []LockedPackage `toml:"package,omitempty" json:"package,omitempty"`
+}
+
+type LockedPackage struct {
+	// Path is the import path of the dependency.
+	Path string `toml:"path" json:"path"`
+	// ChainID is the ID of the chain the dependency was fetched from.
+	ChainID string `toml:"chain_id,omitempty" json:"chain_id,omitempty"`
+	// Height is the block height at which the dependency was added.
+	Height int `toml:"height,omitempty" json:"height,omitempty"`
+	// Hash is the content hash of the dependency, see [HashMemPackage].
+	Hash string `toml:"hash" json:"hash"`
+}
+
+// ParseLockFile parses the lock file at the given path.
+func ParseLockFile(fpath string) (*LockFile, error) {
+	b, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %q: %w", fpath, err)
+	}
+	return ParseLockBytes(fpath, b)
+}
+
+// ParseLockBytes parses a lock file from bytes.
+func ParseLockBytes(fname string, data []byte) (*LockFile, error) {
+	var lf LockFile
+	if err := toml.Unmarshal(data, &lf); err != nil {
+		return nil, fmt.Errorf("error parsing %s file at %q: %w", LockFileName, fname, err)
+	}
+	for _, p := range lf.Package {
+		if p.Path == "" || p.Hash == "" {
+			return nil, fmt.Errorf("invalid %s file at %q: 'path' and 'hash' are required", LockFileName, fname)
+		}
+	}
+	return &lf, nil
+}
+
+// Get returns the locked dependency with the given import path.
+func (lf *LockFile) Get(pkgPath string) (LockedPackage, bool) {
+	i, ok := lf.find(pkgPath)
+	if !ok {
+		return LockedPackage{}, false
+	}
+	return lf.Package[i], true
+}
+
+// Set adds a locked dependency or replaces an existing one.
+func (lf *LockFile) Set(p LockedPackage) {
+	i, ok := lf.find(p.Path)
+	if ok {
+		lf.Package[i] = p
+		return
+	}
+	lf.Package = slices.Insert(lf.Package, i, p)
+}
+
+// Drop drops a locked dependency.
+func (lf *LockFile) Drop(pkgPath string) {
+	if i, ok := lf.find(pkgPath); ok {
+		lf.Package = slices.Delete(lf.Package, i, i+1)
+	}
+}
+
+func (lf *LockFile) find(pkgPath string) (int, bool) {
+	return slices.BinarySearchFunc(lf.Package, pkgPath, func(p LockedPackage, path string) int {
+		return strings.Compare(p.Path, path)
+	})
+}
+
+// Verify checks that p matches the locked dependency with the same path, if
+// any. The chain ID and height are only compared when known on both sides.
+func (lf *LockFile) Verify(p LockedPackage) error {
+	locked, ok := lf.Get(p.Path)
+	if !ok {
+		return nil
+	}
+	if locked.ChainID != "" && p.ChainID != "" && locked.ChainID != p.ChainID {
+		return fmt.Errorf("%s: chain ID mismatch\n\t%s: %s\n\tdownloaded: %s", p.Path, LockFileName, locked.ChainID, p.ChainID)
+	}
+	if locked.Height != 0 && p.Height != 0 && locked.Height != p.Height {
+		return fmt.Errorf("%s: height mismatch\n\t%s: %d\n\tdownloaded: %d", p.Path, LockFileName, locked.Height, p.Height)
+	}
+	if locked.Hash != p.Hash {
+		return fmt.Errorf("%s: hash mismatch\n\t%s: %s\n\tdownloaded: %s", p.Path, LockFileName, locked.Hash, p.Hash)
+	}
+	return nil
+}
+
+// WriteString writes the lock file to a string.
+func (lf *LockFile) WriteString() string {
+	var builder strings.Builder
+	builder.WriteString("# This file is generated by the gno toolchain. DO NOT EDIT.\n")
+	encoder := toml.NewEncoder(&builder)
+	encoder.Order(toml.OrderPreserve)
+
+	err := encoder.Encode(lf)
+	if err != nil {
+		panic(err)
+	}
+
+	return builder.String()
+}
+
+// WriteFile writes the lock file to the given absolute file path.
+func (lf *LockFile) WriteFile(fpath string) error {
+	data := []byte(lf.WriteString())
+	err := os.WriteFile(fpath, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("writefile %q: %w", fpath, err)
+	}
+	return nil
+}
+
+// HashMemPackage returns the content hash of the files of mpkg. The hash
+// doesn't depend on the package path nor on the order of the files.
+func HashMemPackage(mpkg *std.MemPackage) string {
+	files := slices.Clone(mpkg.Files)
+	slices.SortFunc(files, func(a, b *std.MemFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	h := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(h, "%s %d\n%s\n", file.Name, len(file.Body), file.Body)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
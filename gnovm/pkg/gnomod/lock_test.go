@@ -0,0 +1,118 @@
+package gnomod
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFile(t *testing.T) {
+	lf := &LockFile{}
+	lf.Set(LockedPackage{Path: "gno.land/p/nt/ufmt", ChainID: "test5", Height: 12, Hash: "sha256:aa"})
+	lf.Set(LockedPackage{Path: "gno.land/p/nt/avl", ChainID: "test5", Height: 10, Hash: "sha256:bb"})
+	lf.Set(LockedPackage{Path: "gno.land/r/demo/foo", Hash: "sha256:cc"})
+	lf.Set(LockedPackage{Path: "gno.land/p/nt/ufmt", ChainID: "test5", Height: 12, Hash: "sha256:dd"})
+
+	require.Len(t, lf.Package, 3)
+	assert.Equal(t, "gno.land/p/nt/avl", lf.Package[0].Path)
+	assert.Equal(t, "gno.land/p/nt/ufmt", lf.Package[1].Path)
+	assert.Equal(t, "gno.land/r/demo/foo", lf.Package[2].Path)
+
+	p, ok := lf.Get("gno.land/p/nt/ufmt")
+	require.True(t, ok)
+	assert.Equal(t, "sha256:dd", p.Hash)
+
+	lf.Drop("gno.land/r/demo/foo")
+	_, ok = lf.Get("gno.land/r/demo/foo")
+	assert.False(t, ok)
+
+	fpath := filepath.Join(t.TempDir(), LockFileName)
+	require.NoError(t, lf.WriteFile(fpath))
+	parsed, err := ParseLockFile(fpath)
+	require.NoError(t, err)
+	assert.Equal(t, lf, parsed)
+}
+
+func TestLockFileVerify(t *testing.T) {
+	lf := &LockFile{}
+	lf.Set(LockedPackage{Path: "gno.land/p/nt/avl", ChainID: "test5", Height: 10, Hash: "sha256:bb"})
+
+	cases := []struct {
+		name             string
+		pkg              LockedPackage
+		errShouldContain string
+	}{
+		{
+			name: "match",
+			pkg:  LockedPackage{Path: "gno.land/p/nt/avl", ChainID: "test5", Height: 10, Hash: "sha256:bb"},
+		},
+		{
+			name: "unknown origin",
+			pkg:  LockedPackage{Path: "gno.land/p/nt/avl", Hash: "sha256:bb"},
+		},
+		{
+			name: "not locked",
+			pkg:  LockedPackage{Path: "gno.land/p/nt/ufmt", Hash: "sha256:aa"},
+		},
+		{
+			name:             "chain ID mismatch",
+			pkg:              LockedPackage{Path: "gno.land/p/nt/avl", ChainID: "test6", Height: 10, Hash: "sha256:bb"},
+			errShouldContain: "gno.land/p/nt/avl: chain ID mismatch",
+		},
+		{
+			name:             "height mismatch",
+			pkg:              LockedPackage{Path: "gno.land/p/nt/avl", ChainID: "test5", Height: 11, Hash: "sha256:bb"},
+			errShouldContain: "gno.land/p/nt/avl: height mismatch",
+		},
+		{
+			name:             "hash mismatch",
+			pkg:              LockedPackage{Path: "gno.land/p/nt/avl", Hash: "sha256:cc"},
+			errShouldContain: "gno.land/p/nt/avl: hash mismatch",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := lf.Verify(tc.pkg)
+			if tc.errShouldContain == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.errShouldContain)
+			}
+		})
+	}
+}
+
+func TestParseLockBytes(t *testing.T) {
+	_, err := ParseLockBytes(LockFileName, []byte(`not a toml`))
+	require.Error(t, err)
+
+	_, err = ParseLockBytes(LockFileName, []byte("[[package]]\npath = \"gno.land/p/nt/avl\"\n"))
+	require.ErrorContains(t, err, "'path' and 'hash' are required")
+
+	lf, err := ParseLockBytes(LockFileName, nil)
+	require.NoError(t, err)
+	assert.Empty(t, lf.Package)
+}
+
+func TestHashMemPackage(t *testing.T) {
+	a := &std.MemPackage{Path: "gno.land/p/demo/a", Files: []*std.MemFile{
+		{Name: "a.gno", Body: "package a"},
+		{Name: "gnomod.toml", Body: `module = "gno.land/p/demo/a"`},
+	}}
+	b := &std.MemPackage{Path: "gno.land/p/demo/b", Files: []*std.MemFile{
+		{Name: "gnomod.toml", Body: `module = "gno.land/p/demo/a"`},
+		{Name: "a.gno", Body: "package a"},
+	}}
+	c := &std.MemPackage{Path: "gno.land/p/demo/a", Files: []*std.MemFile{
+		{Name: "a.gno", Body: "package a\n"},
+		{Name: "gnomod.toml", Body: `module = "gno.land/p/demo/a"`},
+	}}
+
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", HashMemPackage(a))
+	assert.Equal(t, HashMemPackage(a), HashMemPackage(b))
+	assert.NotEqual(t, HashMemPackage(a), HashMemPackage(c))
+}
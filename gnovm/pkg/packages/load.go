@@ -13,12 +13,12 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
-	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/rpcpkgfetcher"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/proxypkgfetcher"
 	"github.com/gnolang/gno/gnovm/tests/stdlibs"
 )
 
 type LoadConfig struct {
-	Fetcher             pkgdownload.PackageFetcher // package fetcher used to load dependencies not present in patterns. Could be wrapped to support fetching from examples and/or an in-memory cache.
+	Fetcher             pkgdownload.PackageFetcher // package fetcher used to load dependencies not present in patterns. Defaults to the sources configured by GNOPROXY.
 	Deps                bool                       // load dependencies
 	AllowEmpty          bool                       // don't return error when no packages are loaded
 	Fset                *token.FileSet             // external fset to help with pretty errors
@@ -33,7 +33,11 @@ func (conf *LoadConfig) applyDefaults() error {
 		conf.Out = io.Discard
 	}
 	if conf.Fetcher == nil {
-		conf.Fetcher = rpcpkgfetcher.New(nil)
+		fetcher, err := proxypkgfetcher.New(gnoenv.Proxy(), nil)
+		if err != nil {
+			return fmt.Errorf("invalid GNOPROXY: %w", err)
+		}
+		conf.Fetcher = fetcher
 	}
 	if conf.Fset == nil {
 		conf.Fset = token.NewFileSet()
@@ -83,6 +87,12 @@ func Load(conf LoadConfig, patterns ...string) (PkgList, error) {
 
 	localDeps := discoverPkgsForLocalDeps(conf, loaderCtx)
 
+	lockPath := filepath.Join(loaderCtx.Root, gnomod.LockFileName)
+	lf, err := readLockFile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
 	// mark all pattern packages for visit
 	toVisit := []*Package(pkgs)
 
@@ -144,14 +154,27 @@ func Load(conf LoadConfig, patterns ...string) (PkgList, error) {
 				continue
 			}
 
-			// attempt to download package
+			// attempt to download package, unless the locked version is
+			// already in the modcache
+			var restoreErr error
+			if locked, ok := lf.Get(imp.PkgPath); ok {
+				_, restoreErr = RestoreLockedPackage(locked)
+			}
 			dir := PackageDir(imp.PkgPath)
-			markDepForVisit(loadSinglePkg(conf.Out, conf.Fetcher, dir, conf.Fset))
+			dep := loadSinglePkg(conf.Out, conf.Fetcher, dir, conf.Fset)
+			if restoreErr != nil {
+				dep.Errors = append(dep.Errors, &Error{Pos: dir, Msg: restoreErr.Error()})
+			}
+			markDepForVisit(dep)
 		}
 
 		loaded = append(loaded, pkg)
 	}
 
+	if err := lockDeps(lockPath, lf, loaded); err != nil {
+		return nil, err
+	}
+
 	return loaded, nil
 }
 
@@ -46,8 +46,8 @@ func loadSinglePkg(out io.Writer, fetcher pkgdownload.PackageFetcher, pkgDir str
 	mptype := gnolang.MPUserAll
 
 	// get package from modcache if the dir is in it
-	modCachePath := gnomod.ModCachePath()
-	if strings.HasPrefix(filepath.Clean(pkg.Dir), modCachePath) {
+	if isInModCache(pkg.Dir) {
+		modCachePath := gnomod.ModCachePath()
 		pkgPath, err := filepath.Rel(modCachePath, pkg.Dir)
 		if err != nil {
 			pkg.Errors = append(pkg.Errors, &Error{
@@ -0,0 +1,54 @@
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+)
+
+// readLockFile reads the lock file at lockPath, if any.
+func readLockFile(lockPath string) (*gnomod.LockFile, error) {
+	lf, err := gnomod.ParseLockFile(lockPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &gnomod.LockFile{}, nil
+	}
+	return lf, err
+}
+
+// lockDeps verifies the dependencies loaded from the modcache against the
+// lock file, and records the ones missing from it.
+func lockDeps(lockPath string, lf *gnomod.LockFile, pkgs []*Package) error {
+	var (
+		errs    []error
+		changed bool
+	)
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 || !isInModCache(pkg.Dir) {
+			continue
+		}
+
+		locked, err := CachedPackageLock(pkg.ImportPath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, ok := lf.Get(locked.Path); !ok {
+			lf.Set(*locked)
+			changed = true
+			continue
+		}
+		if err := lf.Verify(*locked); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("verifying %s: %w", lockPath, errors.Join(errs...))
+	}
+
+	if changed {
+		return lf.WriteFile(lockPath)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package packages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/examplespkgfetcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLock(t *testing.T) {
+	t.Setenv("GNOHOME", t.TempDir())
+
+	testExamplesAbs, err := filepath.Abs(filepath.Join("testdata", "examples"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gnomod.toml"), []byte("module = \"gno.example.com/r/lock\"\ngno = \"0.9\"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lock.gno"), []byte("package lock\n\nimport \"gno.example.com/p/demo/avl\"\n\nvar _ = avl.NewTree\n"), 0o644))
+	testChdir(t, dir)
+
+	conf := LoadConfig{
+		Deps:    true,
+		Fetcher: examplespkgfetcher.New(testExamplesAbs),
+	}
+	lockPath := filepath.Join(dir, gnomod.LockFileName)
+
+	// first load records the downloaded dependency
+	_, err = Load(conf, ".")
+	require.NoError(t, err)
+	lf, err := gnomod.ParseLockFile(lockPath)
+	require.NoError(t, err)
+	require.Len(t, lf.Package, 1)
+	locked := lf.Package[0]
+	assert.Equal(t, "gno.example.com/p/demo/avl", locked.Path)
+	assert.Regexp(t, "^sha256:", locked.Hash)
+
+	// subsequent loads verify it
+	_, err = Load(conf, ".")
+	require.NoError(t, err)
+
+	// a different source is rejected
+	cached := filepath.Join(PackageDir("gno.example.com/p/demo/avl"), "avl.gno")
+	require.NoError(t, os.WriteFile(cached, []byte("package avl\n\nfunc NewTree() {}\n"), 0o644))
+	_, err = Load(conf, ".")
+	require.ErrorContains(t, err, "gno.example.com/p/demo/avl: hash mismatch")
+
+	// and the lock file is left untouched
+	lf, err = gnomod.ParseLockFile(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, []gnomod.LockedPackage{locked}, lf.Package)
+}
@@ -1,14 +1,19 @@
 package packages
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
+	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gofrs/flock"
 )
 
@@ -46,14 +51,15 @@ func DownloadPackageToCache(out io.Writer, pkgPath string, fetcher pkgdownload.P
 	}
 	defer fl.Unlock()
 
-	markersDir := filepath.Join(modCachePath, ".markers")
-	if err := os.MkdirAll(markersDir, 0o744); err != nil {
+	markerFile := markerPath(modCachePath, pkgPath)
+	if err := os.MkdirAll(filepath.Dir(markerFile), 0o744); err != nil {
 		return fmt.Errorf("ensure .markers dir exists: %w", err)
 	}
-	markerFile := filepath.Join(markersDir, gnolang.DerivePkgBech32Addr(pkgPath).String())
 
 	if _, err := os.Stat(markerFile); err == nil {
-		// package exists in modcache, do nothing
+		// package exists in modcache, only record its use for pruning
+		now := time.Now()
+		_ = os.Chtimes(markerFile, now, now)
 		return nil
 	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("stat marker file for package %q at %q: %w", pkgPath, markerFile, err)
@@ -61,15 +67,364 @@ func DownloadPackageToCache(out io.Writer, pkgPath string, fetcher pkgdownload.P
 
 	fmt.Fprintf(out, "gno: downloading %s\n", pkgPath)
 
-	dst := filepath.Join(modCachePath, filepath.FromSlash(pkgPath))
-	if err := pkgdownload.Download(pkgPath, dst, fetcher); err != nil {
+	// download to a temporary dir first, to store the package by content hash
+	tmp, err := os.MkdirTemp(modCachePath, ".download-")
+	if err != nil {
+		return fmt.Errorf("create download dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	locked, err := pkgdownload.Download(pkgPath, tmp, fetcher)
+	if err != nil {
+		return err
+	}
+	obj, err := objectPath(modCachePath, locked.Hash)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(obj); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(obj), 0o744); err != nil {
+			return fmt.Errorf("ensure .objects dir exists: %w", err)
+		}
+		if err := os.Rename(tmp, obj); err != nil {
+			return fmt.Errorf("store package %q: %w", pkgPath, err)
+		}
+	}
+
+	return checkoutObject(modCachePath, *locked)
+}
+
+// RestoreLockedPackage makes the modcache serve the locked version of a
+// package if it is available in the content-addressed store, without fetching
+// it. It returns false if the locked version is not available.
+func RestoreLockedPackage(locked gnomod.LockedPackage) (bool, error) {
+	modCachePath := gnomod.ModCachePath()
+
+	obj, err := objectPath(modCachePath, locked.Hash)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(obj); err != nil {
+		return false, nil
+	}
+
+	fl, err := LockCache(modCachePath)
+	if err != nil {
+		return false, err
+	}
+	defer fl.Unlock()
+
+	markerFile := markerPath(modCachePath, locked.Path)
+	if marker, err := gnomod.ParseLockFile(markerFile); err == nil {
+		if current, ok := marker.Get(locked.Path); ok && current.Hash == locked.Hash {
+			// already checked out
+			return true, nil
+		}
+	}
+
+	if err := checkoutObject(modCachePath, locked); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkoutObject copies the files of a stored package to its import path
+// directory in the modcache, and marks it as downloaded.
+func checkoutObject(modCachePath string, locked gnomod.LockedPackage) error {
+	obj, err := objectPath(modCachePath, locked.Hash)
+	if err != nil {
 		return err
 	}
+	dst := filepath.Join(modCachePath, filepath.FromSlash(locked.Path))
 
-	// mark package as downloaded
-	if err := os.WriteFile(markerFile, nil, 0o644); err != nil {
+	// remove the files of the previously checked out version, if any,
+	// keeping the sub-directories of nested packages
+	if err := removePackageFiles(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0o744); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(obj)
+	if err != nil {
+		return fmt.Errorf("read stored package %q: %w", locked.Path, err)
+	}
+	for _, entry := range entries {
+		body, err := os.ReadFile(filepath.Join(obj, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read stored package %q: %w", locked.Path, err)
+		}
+		fileDst := filepath.Join(dst, entry.Name())
+		if err := os.WriteFile(fileDst, body, 0o644); err != nil {
+			return fmt.Errorf("write file at %q: %w", fileDst, err)
+		}
+	}
+
+	// mark package as downloaded, recording where it comes from
+	markerFile := markerPath(modCachePath, locked.Path)
+	if err := os.MkdirAll(filepath.Dir(markerFile), 0o744); err != nil {
+		return fmt.Errorf("ensure .markers dir exists: %w", err)
+	}
+	marker := &gnomod.LockFile{}
+	marker.Set(locked)
+	if err := marker.WriteFile(markerFile); err != nil {
 		return fmt.Errorf("write marker file: %w", err)
 	}
 
 	return nil
 }
+
+func removePackageFiles(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CachedPackageLock returns the lock entry of a package in the modcache.
+// The hash is computed from the cached files, so that modifications of the
+// cache are detected.
+func CachedPackageLock(pkgPath string) (*gnomod.LockedPackage, error) {
+	modCachePath := gnomod.ModCachePath()
+
+	markerFile := markerPath(modCachePath, pkgPath)
+	marker, err := gnomod.ParseLockFile(markerFile)
+	if err != nil {
+		return nil, fmt.Errorf("read marker file for package %q: %w", pkgPath, err)
+	}
+	locked, _ := marker.Get(pkgPath) // empty for packages downloaded by older versions
+
+	dir := filepath.Join(modCachePath, filepath.FromSlash(pkgPath))
+	mpkg, err := readCachedPackage(dir, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	locked.Path = pkgPath
+	locked.Hash = gnomod.HashMemPackage(mpkg)
+	if locked.Height == 0 {
+		if mod, err := gnomod.ParseMemPackage(mpkg); err == nil {
+			locked.Height = mod.AddPkg.Height
+		}
+	}
+
+	return &locked, nil
+}
+
+// readCachedPackage reads all the files downloaded for a package in the
+// modcache, regardless of their kind.
+func readCachedPackage(dir string, pkgPath string) (*std.MemPackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read cached package %q: %w", pkgPath, err)
+	}
+
+	mpkg := &std.MemPackage{Path: pkgPath}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		body, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read cached package %q: %w", pkgPath, err)
+		}
+		mpkg.AddFile(&std.MemFile{Name: entry.Name(), Body: string(body)})
+	}
+	return mpkg, nil
+}
+
+var reHash = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// objectPath returns the content-addressed location of a package in the
+// modcache.
+func objectPath(modCachePath string, hash string) (string, error) {
+	if !reHash.MatchString(hash) {
+		return "", fmt.Errorf("invalid package hash %q", hash)
+	}
+	return filepath.Join(modCachePath, ".objects", strings.Replace(hash, ":", "-", 1)), nil
+}
+
+func markerPath(modCachePath string, pkgPath string) string {
+	return filepath.Join(modCachePath, ".markers", gnolang.DerivePkgBech32Addr(pkgPath).String())
+}
+
+func isInModCache(dir string) bool {
+	return strings.HasPrefix(filepath.Clean(dir), gnomod.ModCachePath())
+}
+
+// cachedPackage is a package marked as downloaded in the modcache.
+type cachedPackage struct {
+	gnomod.LockedPackage
+	markerFile string
+	lastUsed   time.Time
+}
+
+// listCache returns the packages marked as downloaded in the modcache.
+// Packages downloaded by older versions, which don't record their content
+// hash, are ignored.
+func listCache(modCachePath string) ([]cachedPackage, error) {
+	markersDir := filepath.Join(modCachePath, ".markers")
+	entries, err := os.ReadDir(markersDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := make([]cachedPackage, 0, len(entries))
+	for _, entry := range entries {
+		markerFile := filepath.Join(markersDir, entry.Name())
+		marker, err := gnomod.ParseLockFile(markerFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(marker.Package) != 1 {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cachedPackage{
+			LockedPackage: marker.Package[0],
+			markerFile:    markerFile,
+			lastUsed:      info.ModTime(),
+		})
+	}
+	return res, nil
+}
+
+// VerifyCache checks that the packages in the modcache have not been modified
+// since they were downloaded. It returns the paths of the verified packages.
+func VerifyCache() ([]string, error) {
+	modCachePath := gnomod.ModCachePath()
+
+	fl, err := LockCache(modCachePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Unlock()
+
+	pkgs, err := listCache(modCachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		verified []string
+		errs     []error
+	)
+	for _, pkg := range pkgs {
+		dir := filepath.Join(modCachePath, filepath.FromSlash(pkg.Path))
+		mpkg, err := readCachedPackage(dir, pkg.Path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if hash := gnomod.HashMemPackage(mpkg); hash != pkg.Hash {
+			errs = append(errs, fmt.Errorf("%s: dir has been modified (%s)", pkg.Path, dir))
+			continue
+		}
+
+		obj, err := objectPath(modCachePath, pkg.Hash)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", pkg.Path, err))
+			continue
+		}
+		if _, err := os.Stat(obj); err == nil {
+			// packages downloaded by older versions are not stored by hash
+			mpkg, err := readCachedPackage(obj, pkg.Path)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if hash := gnomod.HashMemPackage(mpkg); hash != pkg.Hash {
+				errs = append(errs, fmt.Errorf("%s: stored package has been modified (%s)", pkg.Path, obj))
+				continue
+			}
+		}
+
+		verified = append(verified, pkg.Path)
+	}
+
+	return verified, errors.Join(errs...)
+}
+
+// PruneCache removes the packages of the modcache which have not been used
+// since the given time, along with the stored packages they don't reference
+// anymore. It returns the removed directories. If dryRun is true, nothing is
+// removed.
+func PruneCache(unusedSince time.Time, dryRun bool) ([]string, error) {
+	modCachePath := gnomod.ModCachePath()
+
+	fl, err := LockCache(modCachePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Unlock()
+
+	pkgs, err := listCache(modCachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	used := map[string]struct{}{}
+	for _, pkg := range pkgs {
+		if !pkg.lastUsed.Before(unusedSince) {
+			used[pkg.Hash] = struct{}{}
+			continue
+		}
+
+		dir := filepath.Join(modCachePath, filepath.FromSlash(pkg.Path))
+		removed = append(removed, dir)
+		if dryRun {
+			continue
+		}
+		if err := removePackageFiles(dir); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		_ = os.Remove(dir) // only removed if there are no nested packages
+		if err := os.Remove(pkg.markerFile); err != nil {
+			return removed, err
+		}
+	}
+
+	objectsDir := filepath.Join(modCachePath, ".objects")
+	entries, err := os.ReadDir(objectsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return removed, err
+	}
+	for _, entry := range entries {
+		hash := strings.Replace(entry.Name(), "-", ":", 1)
+		if _, ok := used[hash]; ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return removed, err
+		}
+		if !info.ModTime().Before(unusedSince) {
+			continue
+		}
+
+		obj := filepath.Join(objectsDir, entry.Name())
+		removed = append(removed, obj)
+		if dryRun {
+			continue
+		}
+		if err := os.RemoveAll(obj); err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
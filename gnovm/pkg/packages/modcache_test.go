@@ -0,0 +1,89 @@
+package packages
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/examplespkgfetcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModCache(t *testing.T) {
+	t.Setenv("GNOHOME", t.TempDir())
+	modCachePath := gnomod.ModCachePath()
+
+	mirror := t.TempDir()
+	avlDir := filepath.Join(mirror, "gno.example.com", "p", "demo", "avl")
+	require.NoError(t, os.MkdirAll(avlDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(avlDir, "avl.gno"), []byte("package avl\n"), 0o644))
+	fetcher := examplespkgfetcher.New(mirror)
+
+	const pkgPath = "gno.example.com/p/demo/avl"
+	cached := filepath.Join(PackageDir(pkgPath), "avl.gno")
+
+	// download stores the package by content hash
+	require.NoError(t, DownloadPackageToCache(io.Discard, pkgPath, fetcher))
+	v1, err := CachedPackageLock(pkgPath)
+	require.NoError(t, err)
+	obj, err := objectPath(modCachePath, v1.Hash)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(obj, "avl.gno"))
+
+	verified, err := VerifyCache()
+	require.NoError(t, err)
+	assert.Equal(t, []string{pkgPath}, verified)
+
+	// modifications are detected
+	require.NoError(t, os.WriteFile(cached, []byte("package avl // v2\n"), 0o644))
+	_, err = VerifyCache()
+	require.ErrorContains(t, err, pkgPath+": dir has been modified")
+
+	// download a second version, as if fetched from another chain
+	require.NoError(t, os.WriteFile(filepath.Join(avlDir, "avl.gno"), []byte("package avl // v2\n"), 0o644))
+	require.NoError(t, os.Remove(markerPath(modCachePath, pkgPath)))
+	require.NoError(t, DownloadPackageToCache(io.Discard, pkgPath, fetcher))
+	v2, err := CachedPackageLock(pkgPath)
+	require.NoError(t, err)
+	require.NotEqual(t, v1.Hash, v2.Hash)
+
+	// the locked version is restored without fetching it
+	restored, err := RestoreLockedPackage(*v1)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	body, err := os.ReadFile(cached)
+	require.NoError(t, err)
+	assert.Equal(t, "package avl\n", string(body))
+	_, err = VerifyCache()
+	require.NoError(t, err)
+
+	restored, err = RestoreLockedPackage(gnomod.LockedPackage{Path: pkgPath, Hash: "sha256:" + strings.Repeat("0", 64)})
+	require.NoError(t, err)
+	assert.False(t, restored)
+	_, err = RestoreLockedPackage(gnomod.LockedPackage{Path: pkgPath, Hash: "sha256:../../x"})
+	require.ErrorContains(t, err, "invalid package hash")
+
+	// recently used packages are kept
+	removed, err := PruneCache(time.Now().Add(-time.Hour), false)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	// unused ones are removed, along with the stored versions
+	removed, err = PruneCache(time.Now().Add(time.Hour), true)
+	require.NoError(t, err)
+	assert.Len(t, removed, 3)
+	assert.FileExists(t, cached)
+	removed, err = PruneCache(time.Now().Add(time.Hour), false)
+	require.NoError(t, err)
+	assert.Len(t, removed, 3)
+	assert.NoFileExists(t, cached)
+	assert.NoDirExists(t, obj)
+	verified, err = VerifyCache()
+	require.NoError(t, err)
+	assert.Empty(t, verified)
+}
@@ -5,26 +5,46 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 // Download downloads the package identified by `pkgPath` in the directory at `dst` using the provided [PackageFetcher].
 // The directory at `dst` is created if it does not exists.
-func Download(pkgPath string, dst string, fetcher PackageFetcher) error {
+// It returns the lock entry describing the downloaded source.
+func Download(pkgPath string, dst string, fetcher PackageFetcher) (*gnomod.LockedPackage, error) {
 	files, err := fetcher.FetchPackage(pkgPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := os.MkdirAll(dst, 0o744); err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, file := range files {
 		fileDst := filepath.Join(dst, file.Name)
 		if err := os.WriteFile(fileDst, []byte(file.Body), 0o644); err != nil {
-			return fmt.Errorf("write file at %q: %w", fileDst, err)
+			return nil, fmt.Errorf("write file at %q: %w", fileDst, err)
+		}
+	}
+
+	mpkg := &std.MemPackage{Path: pkgPath, Files: files}
+	locked := &gnomod.LockedPackage{
+		Path: pkgPath,
+		Hash: gnomod.HashMemPackage(mpkg),
+	}
+	if mod, err := gnomod.ParseMemPackage(mpkg); err == nil {
+		locked.Height = mod.AddPkg.Height
+	}
+	if cf, ok := fetcher.(ChainPackageFetcher); ok {
+		chainID, err := cf.ChainID(pkgPath)
+		if err != nil {
+			return nil, fmt.Errorf("get chain ID for pkg path %q: %w", pkgPath, err)
 		}
+		locked.ChainID = chainID
 	}
 
-	return nil
+	return locked, nil
 }
@@ -10,6 +10,13 @@ type PackageFetcher interface {
 	FetchPackage(pkgPath string) ([]*std.MemFile, error)
 }
 
+// ChainPackageFetcher is implemented by a [PackageFetcher] fetching packages
+// from a chain, and is used to record the chain a package comes from.
+type ChainPackageFetcher interface {
+	PackageFetcher
+	ChainID(pkgPath string) (string, error)
+}
+
 func NewNoopFetcher() PackageFetcher {
 	return &noopFetcher{}
 }
@@ -0,0 +1,93 @@
+// Package proxypkgfetcher provides an implementation of [pkgdownload.PackageFetcher]
+// to fetch packages from a list of sources, as configured by GNOPROXY.
+package proxypkgfetcher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/examplespkgfetcher"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/rpcpkgfetcher"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ErrOff is returned when fetching a package that isn't available in any of
+// the sources preceding "off" in the proxy list.
+var ErrOff = errors.New("package fetching disabled by GNOPROXY=off")
+
+type source struct {
+	name    string
+	fetcher pkgdownload.PackageFetcher
+}
+
+type proxyPackageFetcher struct {
+	sources []source
+	off     bool
+	served  map[string]pkgdownload.PackageFetcher // by pkg path
+}
+
+var _ pkgdownload.ChainPackageFetcher = (*proxyPackageFetcher)(nil)
+
+// New returns a fetcher trying each source of the comma-separated proxy list
+// in order, until one of them serves the package. Sources can be:
+//   - "direct", to fetch packages from the chain rpc endpoints,
+//   - "off", to disallow fetching packages from any later source,
+//   - a directory, optionally prefixed by file://, laid out as <pkgpath>/<files>,
+//     like the module cache,
+//   - a .tar, .tar.gz or .tgz archive of such a directory.
+func New(proxy string, remoteOverrides map[string]string) (pkgdownload.PackageFetcher, error) {
+	pf := &proxyPackageFetcher{served: map[string]pkgdownload.PackageFetcher{}}
+	for _, name := range strings.Split(proxy, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("invalid proxy list %q: empty source", proxy)
+		case name == "off":
+			pf.off = true
+		case name == "direct":
+			pf.sources = append(pf.sources, source{name, rpcpkgfetcher.New(remoteOverrides)})
+		case isArchive(name):
+			pf.sources = append(pf.sources, source{name, newTarFetcher(strings.TrimPrefix(name, "file://"))})
+		default:
+			pf.sources = append(pf.sources, source{name, examplespkgfetcher.New(strings.TrimPrefix(name, "file://"))})
+		}
+		if pf.off {
+			break
+		}
+	}
+	return pf, nil
+}
+
+// FetchPackage implements [pkgdownload.PackageFetcher].
+func (pf *proxyPackageFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, error) {
+	errs := make([]error, 0, len(pf.sources)+1)
+	for _, src := range pf.sources {
+		files, err := src.fetcher.FetchPackage(pkgPath)
+		if err == nil {
+			pf.served[pkgPath] = src.fetcher
+			return files, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", src.name, err))
+	}
+	if pf.off {
+		errs = append(errs, ErrOff)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// ChainID implements [pkgdownload.ChainPackageFetcher]. It returns an empty
+// chain ID for packages not served by a chain.
+func (pf *proxyPackageFetcher) ChainID(pkgPath string) (string, error) {
+	if cf, ok := pf.served[pkgPath].(pkgdownload.ChainPackageFetcher); ok {
+		return cf.ChainID(pkgPath)
+	}
+	return "", nil
+}
+
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar") ||
+		strings.HasSuffix(name, ".tar.gz") ||
+		strings.HasSuffix(name, ".tgz")
+}
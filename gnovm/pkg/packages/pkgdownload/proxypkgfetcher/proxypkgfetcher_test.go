@@ -0,0 +1,154 @@
+package proxypkgfetcher
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyPackageFetcher(t *testing.T) {
+	mirrorDir := t.TempDir()
+	writeMirrorFile(t, mirrorDir, "gno.land/p/demo/dir/dir.gno", "package dir")
+	writeMirrorFile(t, mirrorDir, "gno.land/p/demo/both/both.gno", "package both // dir")
+
+	archive := filepath.Join(t.TempDir(), "mirror.tar.gz")
+	writeTarGz(t, archive, map[string]string{
+		"./gno.land/p/demo/tar/tar.gno":   "package tar",
+		"gno.land/p/demo/tar/gnomod.toml": `module = "gno.land/p/demo/tar"`,
+		"gno.land/p/demo/both/both.gno":   "package both // tar",
+		".markers/g1xxx":                  "",
+	})
+
+	cases := []struct {
+		name             string
+		proxy            string
+		pkgPath          string
+		files            []*std.MemFile
+		errShouldContain string
+	}{
+		{
+			name:    "dir",
+			proxy:   mirrorDir,
+			pkgPath: "gno.land/p/demo/dir",
+			files:   []*std.MemFile{{Name: "dir.gno", Body: "package dir"}},
+		},
+		{
+			name:    "dir with scheme",
+			proxy:   "file://" + mirrorDir,
+			pkgPath: "gno.land/p/demo/dir",
+			files:   []*std.MemFile{{Name: "dir.gno", Body: "package dir"}},
+		},
+		{
+			name:    "archive",
+			proxy:   archive,
+			pkgPath: "gno.land/p/demo/tar",
+			files: []*std.MemFile{
+				{Name: "gnomod.toml", Body: `module = "gno.land/p/demo/tar"`},
+				{Name: "tar.gno", Body: "package tar"},
+			},
+		},
+		{
+			name:    "first source wins",
+			proxy:   mirrorDir + "," + archive,
+			pkgPath: "gno.land/p/demo/both",
+			files:   []*std.MemFile{{Name: "both.gno", Body: "package both // dir"}},
+		},
+		{
+			name:    "fallback",
+			proxy:   mirrorDir + "," + archive,
+			pkgPath: "gno.land/p/demo/tar",
+			files: []*std.MemFile{
+				{Name: "gnomod.toml", Body: `module = "gno.land/p/demo/tar"`},
+				{Name: "tar.gno", Body: "package tar"},
+			},
+		},
+		{
+			name:             "off",
+			proxy:            mirrorDir + ",off,direct",
+			pkgPath:          "gno.land/p/demo/tar",
+			errShouldContain: ErrOff.Error(),
+		},
+		{
+			name:             "not available",
+			proxy:            archive,
+			pkgPath:          "gno.land/p/demo/dir",
+			errShouldContain: `package "gno.land/p/demo/dir" is not available`,
+		},
+		{
+			name:             "empty source",
+			proxy:            mirrorDir + ",,off",
+			errShouldContain: "empty source",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := New(tc.proxy, nil)
+			if err == nil {
+				var files []*std.MemFile
+				files, err = fetcher.FetchPackage(tc.pkgPath)
+				if err == nil {
+					mpkg := &std.MemPackage{Files: files}
+					mpkg.Sort()
+					assert.Equal(t, tc.files, mpkg.Files)
+				}
+			}
+			if tc.errShouldContain == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.errShouldContain)
+			}
+		})
+	}
+}
+
+func TestProxyPackageFetcherChainID(t *testing.T) {
+	mirrorDir := t.TempDir()
+	writeMirrorFile(t, mirrorDir, "gno.land/p/demo/dir/dir.gno", "package dir")
+
+	fetcher, err := New(mirrorDir, nil)
+	require.NoError(t, err)
+	_, err = fetcher.FetchPackage("gno.land/p/demo/dir")
+	require.NoError(t, err)
+
+	chainID, err := fetcher.(*proxyPackageFetcher).ChainID("gno.land/p/demo/dir")
+	require.NoError(t, err)
+	assert.Empty(t, chainID)
+}
+
+func writeMirrorFile(t *testing.T, dir, name, body string) {
+	t.Helper()
+
+	fpath := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(fpath), 0o755))
+	require.NoError(t, os.WriteFile(fpath, []byte(body), 0o644))
+}
+
+func writeTarGz(t *testing.T, fpath string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := gzip.NewWriter(f)
+	defer zw.Close()
+	tw := tar.NewWriter(zw)
+	defer tw.Close()
+
+	for name, body := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(body)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,94 @@
+package proxypkgfetcher
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// tarFetcher fetches packages from a tarball mirror. The archive is read once,
+// on first use.
+type tarFetcher struct {
+	path string
+
+	once sync.Once
+	pkgs map[string][]*std.MemFile // by pkg path
+	err  error
+}
+
+var _ pkgdownload.PackageFetcher = (*tarFetcher)(nil)
+
+func newTarFetcher(path string) *tarFetcher {
+	return &tarFetcher{path: path}
+}
+
+// FetchPackage implements [pkgdownload.PackageFetcher].
+func (tf *tarFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, error) {
+	tf.once.Do(func() {
+		tf.pkgs, tf.err = readTar(tf.path)
+	})
+	if tf.err != nil {
+		return nil, tf.err
+	}
+
+	files, ok := tf.pkgs[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("query files list for pkg %q: package %q is not available", pkgPath, pkgPath)
+	}
+	return files, nil
+}
+
+func readTar(fpath string) (map[string][]*std.MemFile, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(fpath, ".tar") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("read archive %q: %w", fpath, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	pkgs := map[string][]*std.MemFile{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive %q: %w", fpath, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		pkgPath, name := path.Split(path.Clean(strings.TrimPrefix(hdr.Name, "./")))
+		pkgPath = strings.TrimSuffix(pkgPath, "/")
+		if pkgPath == "" || strings.HasPrefix(name, ".") || strings.HasPrefix(pkgPath, ".") {
+			continue
+		}
+
+		body, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read %q in archive %q: %w", hdr.Name, fpath, err)
+		}
+		pkgs[pkgPath] = append(pkgs[pkgPath], &std.MemFile{Name: name, Body: string(body)})
+	}
+	return pkgs, nil
+}
@@ -15,13 +15,15 @@ import (
 
 type gnoPackageFetcher struct {
 	remoteOverrides map[string]string
+	chainIDs        map[string]string // by rpc url
 }
 
-var _ pkgdownload.PackageFetcher = (*gnoPackageFetcher)(nil)
+var _ pkgdownload.ChainPackageFetcher = (*gnoPackageFetcher)(nil)
 
 func New(remoteOverrides map[string]string) pkgdownload.PackageFetcher {
 	return &gnoPackageFetcher{
 		remoteOverrides: remoteOverrides,
+		chainIDs:        map[string]string{},
 	}
 }
 
@@ -57,6 +59,32 @@ func (gpf *gnoPackageFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, erro
 	return res, nil
 }
 
+// ChainID implements [pkgdownload.ChainPackageFetcher].
+func (gpf *gnoPackageFetcher) ChainID(pkgPath string) (string, error) {
+	rpcURL, err := rpcURLFromPkgPath(pkgPath, gpf.remoteOverrides)
+	if err != nil {
+		return "", fmt.Errorf("get rpc url for pkg path %q: %w", pkgPath, err)
+	}
+	if chainID, ok := gpf.chainIDs[rpcURL]; ok {
+		return chainID, nil
+	}
+
+	client, err := client.NewHTTPClient(rpcURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to instantiate tm2 client with remote %q: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	status, err := client.Status(context.Background(), nil)
+	if err != nil {
+		return "", fmt.Errorf("query status: %w", err)
+	}
+
+	chainID := status.NodeInfo.Network
+	gpf.chainIDs[rpcURL] = chainID
+	return chainID, nil
+}
+
 func rpcURLFromPkgPath(pkgPath string, remoteOverrides map[string]string) (string, error) {
 	parts := strings.Split(pkgPath, "/")
 	if len(parts) < 2 {
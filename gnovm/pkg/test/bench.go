@@ -0,0 +1,298 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	storetypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"go.uber.org/multierr"
+)
+
+// DefaultBenchTime is the default value of [TestOptions.BenchTime].
+var DefaultBenchTime = BenchTime{D: time.Second}
+
+// BenchTime is the amount of time, or the exact number of iterations, for
+// which each benchmark is run. It implements [flag.Value], using the same
+// syntax as the -benchtime flag of 'go test' (ie. "1s" or "100x").
+type BenchTime struct {
+	D time.Duration
+	N int // if > 0, run exactly N iterations.
+}
+
+func (bt *BenchTime) String() string {
+	if bt.N > 0 {
+		return fmt.Sprintf("%dx", bt.N)
+	}
+	return bt.D.String()
+}
+
+func (bt *BenchTime) Set(s string) error {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.ParseInt(s[:len(s)-1], 10, 0)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*bt = BenchTime{N: int(n)}
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*bt = BenchTime{D: d}
+	return nil
+}
+
+// benchReport is a mirror of Gno's stdlibs/testing.B.marshalReport().
+type benchReport struct {
+	Failed     bool
+	Skipped    bool
+	Benchmarks []benchResult
+}
+
+// benchResult contains the totals of a single (sub-)benchmark run; each
+// value but N and Extra must be divided by N to get the per-op values.
+type benchResult struct {
+	Name   string
+	N      int
+	T      int64 // wall-clock time (ns)
+	Cycles int64 // VM cpu cycles
+	Gas    int64 // gas consumed by the VM
+	Allocs int64 // bytes allocated by the VM allocator
+	Bytes  int64 // processed bytes per op, as set by B.SetBytes
+	Extra  []struct {
+		Unit  string
+		Value float64
+	}
+}
+
+// String formats r like the results of 'go test -bench', followed by the VM
+// metrics.
+func (r benchResult) String() string {
+	n := float64(r.N)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%8d\t%s ns/op", r.N, prettyFloat(float64(r.T)/n))
+	if r.Bytes > 0 && r.T > 0 {
+		mbs := (float64(r.Bytes) * n / 1e6) / (float64(r.T) / 1e9)
+		fmt.Fprintf(&sb, "\t%7.2f MB/s", mbs)
+	}
+	fmt.Fprintf(&sb, "\t%s cycles/op\t%s gas/op\t%s B/op",
+		prettyFloat(float64(r.Cycles)/n),
+		prettyFloat(float64(r.Gas)/n),
+		prettyFloat(float64(r.Allocs)/n))
+	for _, e := range r.Extra {
+		fmt.Fprintf(&sb, "\t%s %s", prettyFloat(e.Value), e.Unit)
+	}
+	return sb.String()
+}
+
+// prettyFloat formats x with a precision depending on its magnitude, in the
+// same way as Go's testing package.
+func prettyFloat(x float64) string {
+	var format string
+	switch y := math.Abs(x); {
+	case y == 0 || y >= 999.95:
+		format = "%10.0f"
+	case y >= 99.995:
+		format = "%12.1f"
+	case y >= 9.9995:
+		format = "%13.2f"
+	case y >= 0.99995:
+		format = "%14.3f"
+	case y >= 0.099995:
+		format = "%15.4f"
+	case y >= 0.0099995:
+		format = "%16.5f"
+	case y >= 0.00099995:
+		format = "%17.6f"
+	default:
+		format = "%18.7f"
+	}
+	return fmt.Sprintf(format, x)
+}
+
+// runBenchmarks runs the benchmarks in files matching opts.BenchFlag.
+// Each benchmark is run with an increasing b.N until it takes at least
+// opts.BenchTime, and its results are printed to opts.Error.
+func (opts *TestOptions) runBenchmarks(
+	mpkg *std.MemPackage,
+	files *gno.FileSet,
+	tgs gno.TransactionStore,
+	pv *gno.PackageValue,
+) (errs error) {
+	var m *gno.Machine
+	defer func() {
+		if r := recover(); r != nil {
+			if st := m.ExceptionStacktrace(); st != "" {
+				errs = multierr.Append(errors.New(st), errs)
+			}
+			errs = multierr.Append(
+				fmt.Errorf("panic: %v\ngo stacktrace:\n%v\ngno machine: %v\ngno stacktrace:\n%v",
+					r, string(debug.Stack()), m.String(), m.Stacktrace()),
+				errs,
+			)
+		}
+	}()
+
+	benchTime := opts.BenchTime
+	if benchTime.D <= 0 && benchTime.N <= 0 {
+		benchTime = DefaultBenchTime
+	}
+	filter := splitRegexp(opts.BenchFlag)
+	alloc := gno.NewAllocator(math.MaxInt64)
+
+	for _, bf := range loadTestFuncs(mpkg.Name, files, "Benchmark") {
+		if !shouldRun(filter, bf.Name) {
+			continue
+		}
+
+		var rep benchReport
+		n := 1
+		for {
+			m = Machine(tgs, opts.WriterForStore(), mpkg.Path, false)
+			m.Alloc = alloc.Reset()
+			m.GasMeter = storetypes.NewInfiniteGasMeter()
+			m.Coverage = opts.Coverage
+			m.SetActivePackage(pv)
+
+			var err error
+			rep, err = opts.runBenchmarkN(m, mpkg, pv, bf, n)
+			m.Release()
+			if err != nil {
+				return multierr.Append(errs, err)
+			}
+			if rep.Failed || rep.Skipped || len(rep.Benchmarks) == 0 {
+				break
+			}
+			if benchTime.N > 0 {
+				if n >= benchTime.N {
+					break
+				}
+				n = benchTime.N
+				continue
+			}
+			elapsed := totalBenchTime(rep)
+			if elapsed >= benchTime.D.Nanoseconds() || n >= 1e9 {
+				break
+			}
+			n = predictN(benchTime.D.Nanoseconds(), int64(n), elapsed, int64(n))
+		}
+
+		if rep.Failed {
+			errs = multierr.Append(errs, fmt.Errorf("failed: %q", bf.Name))
+			if opts.FailfastFlag {
+				return errs
+			}
+			continue
+		}
+		if rep.Skipped && opts.Verbose {
+			fmt.Fprintf(opts.Error, "--- SKIP: %s\n", bf.Name)
+		}
+		for _, res := range rep.Benchmarks {
+			fmt.Fprintf(opts.Error, "%s\t%s\n", res.Name, res)
+		}
+	}
+
+	return errs
+}
+
+// runBenchmarkN runs the benchmark bf once, with b.N set to n.
+func (opts *TestOptions) runBenchmarkN(
+	m *gno.Machine,
+	mpkg *std.MemPackage,
+	pv *gno.PackageValue,
+	bf testFunc,
+	n int,
+) (benchReport, error) {
+	testingpv := m.Store.GetPackage("testing/base", false)
+	testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
+	testingcx := &gno.ConstExpr{TypedValue: testingtv}
+	benchfv := m.Eval(gno.Nx(bf.Name))[0].GetFunc()
+
+	var runBenchX gno.Expr
+	var runBench gno.TypedValue
+	var runBenchF string
+	var runBenchCur gno.Expr
+	if benchfv.IsCrossing() {
+		// See the corresponding comment in runTestFiles.
+		m.SetActivePackage(testingpv)
+		runBenchX = gno.Nx("runBenchmark_cur")
+		runBench = m.Eval(runBenchX)[0]
+		runBenchF = "F_cur"
+		runBenchCur = gno.NewConstExpr(gno.Nx(".cur"), gno.NewConcreteRealm(mpkg.Path))
+		m.SetActivePackage(pv)
+	} else {
+		runBenchX = gno.Sel(testingcx, "RunBenchmark")
+		runBench = m.Eval(runBenchX)[0]
+		runBenchF = "F"
+		runBenchCur = gno.Nx("nil")
+	}
+	runBenchCX := gno.NewConstExpr(runBenchX, runBench)
+
+	eval := m.Eval(gno.Call(
+		runBenchCX,                               // Call testing.RunBenchmark
+		gno.Str(opts.BenchFlag),                  // bench flag
+		gno.Nx(strconv.FormatBool(opts.Verbose)), // is verbose?
+		gno.Num(strconv.Itoa(n)),                 // b.N
+		&gno.CompositeLitExpr{ // Fourth param, the testing.InternalBenchmark
+			Type: gno.Sel(testingcx, "InternalBenchmark"),
+			Elts: gno.KeyValueExprs{
+				{Key: gno.X("Name"), Value: gno.Str(bf.Name)},
+				{Key: gno.X(runBenchF), Value: gno.Nx(bf.Name)},
+				{Key: gno.X("Cur"), Value: runBenchCur},
+			},
+		},
+	))
+
+	var rep benchReport
+	ret := eval[0].GetString()
+	if ret == "" {
+		return rep, fmt.Errorf("failed to execute benchmark: %q", bf.Name)
+	}
+	if err := json.Unmarshal([]byte(ret), &rep); err != nil {
+		return rep, fmt.Errorf("failed to execute benchmark %q: %w", bf.Name, err)
+	}
+	return rep, nil
+}
+
+// totalBenchTime returns the total wall-clock time of the benchmarks in rep.
+func totalBenchTime(rep benchReport) (t int64) {
+	for _, res := range rep.Benchmarks {
+		t += res.T
+	}
+	return
+}
+
+// predictN predicts the number of iterations needed to run for goalns,
+// given that prevIters iterations took prevns. Adapted from Go's testing
+// package.
+func predictN(goalns int64, prevIters int64, prevns int64, last int64) int {
+	if prevns <= 0 {
+		// Round up to dodge divide by zero.
+		prevns = 1
+	}
+
+	// Order of operations matters.
+	// For very fast benchmarks, prevIters ~= prevns.
+	// If you divide first, you get 0 or 1,
+	// which can hide an order of magnitude in execution time.
+	// So multiply first, then divide.
+	n := goalns * prevIters / prevns
+	// Run more iterations than we think we'll need (1.2x).
+	n += n / 5
+	// Don't grow too fast in case we had timing errors previously.
+	n = min(n, 100*last)
+	// Be sure to run at least one more than last time.
+	n = max(n, last+1)
+	// Don't run more than 1e9 times. (This also keeps n in int range on 32 bit platforms.)
+	n = min(n, 1e9)
+	return int(n)
+}
@@ -75,6 +75,7 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 		MaxAllocBytes: maxAlloc,
 		Debug:         opts.Debug,
 		ReviveEnabled: true,
+		Coverage:      opts.Coverage,
 	})
 	defer m.Release()
 
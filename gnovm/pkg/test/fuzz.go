@@ -0,0 +1,386 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"go.uber.org/multierr"
+)
+
+// fuzzCorpusDir is the directory, relative to the package directory, which
+// contains the corpus of each fuzz test, in a sub-directory named after it.
+const fuzzCorpusDir = "testdata/fuzz"
+
+// fuzzLogInterval is the interval at which the progress of fuzzing is printed.
+const fuzzLogInterval = 3 * time.Second
+
+// runFuzzTests runs the fuzz tests in files.
+//
+// The seed corpus of each fuzz test, made of the values passed to F.Add and
+// of the files in testdata/fuzz/FuzzXxx, is run like a set of subtests. The
+// fuzz test matching opts.FuzzFlag, if any, is then fuzzed: new inputs are
+// generated by mutating the corpus until one of them fails, or until
+// opts.FuzzTime is elapsed. A failing input is written to the corpus, so that
+// it is run by subsequent calls to 'gno test'.
+func (opts *TestOptions) runFuzzTests(
+	mpkg *std.MemPackage,
+	fsDir string,
+	files *gno.FileSet,
+	tgs gno.TransactionStore,
+	pv *gno.PackageValue,
+) (errs error) {
+	var m *gno.Machine
+	defer func() {
+		if r := recover(); r != nil {
+			if st := m.ExceptionStacktrace(); st != "" {
+				errs = multierr.Append(errors.New(st), errs)
+			}
+			errs = multierr.Append(
+				fmt.Errorf("panic: %v\ngo stacktrace:\n%v\ngno machine: %v\ngno stacktrace:\n%v",
+					r, string(debug.Stack()), m.String(), m.Stacktrace()),
+				errs,
+			)
+		}
+	}()
+
+	fuzzTests := loadTestFuncs(mpkg.Name, files, "Fuzz")
+
+	var fuzzTarget string
+	if opts.FuzzFlag != "" {
+		filter := splitRegexp(opts.FuzzFlag)
+		var matched []string
+		for _, ft := range fuzzTests {
+			if shouldRun(filter, ft.Name) {
+				matched = append(matched, ft.Name)
+			}
+		}
+		if len(matched) > 1 {
+			return fmt.Errorf("will not fuzz, -fuzz matches more than one fuzz test: %v", matched)
+		}
+		if len(matched) == 1 {
+			fuzzTarget = matched[0]
+		}
+	}
+
+	filter := splitRegexp(opts.RunFlag)
+	for _, ft := range fuzzTests {
+		fuzz := ft.Name == fuzzTarget
+		if !fuzz && !shouldRun(filter, ft.Name) {
+			continue
+		}
+
+		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, false)
+		m.Coverage = opts.Coverage
+		m.SetActivePackage(pv)
+
+		failed, err := opts.runFuzzTest(m, mpkg, fsDir, pv, ft, fuzz)
+		m.Release()
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		}
+		if failed {
+			errs = multierr.Append(errs, fmt.Errorf("failed: %q", ft.Name))
+			if opts.FailfastFlag {
+				return errs
+			}
+		}
+	}
+
+	return errs
+}
+
+// fuzzTest holds the state of a fuzz test run by runFuzzTest.
+type fuzzTest struct {
+	m         *gno.Machine
+	testingtv gno.TypedValue // testing/base package
+	f         gno.TypedValue // *testing.F returned by RunFuzz
+	fn        gno.TypedValue // the fuzz target
+	types     []gno.Type     // types of the fuzzed arguments of fn
+}
+
+// testingX returns an expression selecting name in testing/base.
+func (ft *fuzzTest) testingX(name string) gno.Expr {
+	return gno.Sel(&gno.ConstExpr{TypedValue: ft.testingtv}, name)
+}
+
+// fx returns an expression evaluating to the *testing.F of the fuzz test.
+func (ft *fuzzTest) fx() gno.Expr {
+	return gno.NewConstExpr(gno.Nx("f"), ft.f)
+}
+
+// callX returns a function literal calling the fuzz target with vals.
+func (ft *fuzzTest) callX(vals []any) gno.Expr {
+	args := make([]any, 0, len(vals)+1)
+	args = append(args, gno.Nx("t"))
+	for i, val := range vals {
+		var tv gno.TypedValue
+		if b, ok := val.([]byte); ok {
+			tv = gno.TypedValue{
+				T: &gno.SliceType{Elt: gno.Uint8Type},
+				V: ft.m.Alloc.NewSliceFromData(append([]byte(nil), b...)),
+			}
+		} else {
+			tv = gno.Go2GnoValue(ft.m.Alloc, ft.m.Store, reflect.ValueOf(val))
+		}
+		args = append(args, gno.NewConstExpr(gno.Nx("arg"+strconv.Itoa(i)), tv))
+	}
+	return gno.Fn(
+		gno.Flds("t", gno.Ptr(ft.testingX("T"))),
+		nil,
+		gno.Ss(gno.S(gno.Call(gno.NewConstExpr(gno.Nx("fuzzFn"), ft.fn), args...))),
+	)
+}
+
+// runFuzzTest runs the fuzz test tf, and fuzzes it if fuzz is set. It
+// reports whether the fuzz test failed.
+func (opts *TestOptions) runFuzzTest(
+	m *gno.Machine,
+	mpkg *std.MemPackage,
+	fsDir string,
+	pv *gno.PackageValue,
+	tf testFunc,
+	fuzz bool,
+) (bool, error) {
+	testingpv := m.Store.GetPackage("testing/base", false)
+	ft := &fuzzTest{
+		m:         m,
+		testingtv: gno.TypedValue{T: &gno.PackageType{}, V: testingpv},
+	}
+
+	fuzzfv := m.Eval(gno.Nx(tf.Name))[0].GetFunc()
+	if fuzzfv.IsCrossing() {
+		return true, fmt.Errorf("%s: crossing fuzz tests are not supported", tf.Name)
+	}
+
+	// Run the body of the fuzz test, registering the seeds and fuzz target.
+	ft.f = m.Eval(gno.Call(
+		ft.testingX("RunFuzz"),                        // Call testing.RunFuzz
+		gno.Str(opts.RunFlag),                         // run flag
+		gno.Nx(strconv.FormatBool(opts.Verbose)),      // is verbose?
+		gno.Nx(strconv.FormatBool(opts.FailfastFlag)), // stop as soon as a test fails
+		&gno.CompositeLitExpr{ // Fourth param, the testing.InternalFuzzTarget
+			Type: ft.testingX("InternalFuzzTarget"),
+			Elts: gno.KeyValueExprs{
+				{Key: gno.X("Name"), Value: gno.Str(tf.Name)},
+				{Key: gno.X("F"), Value: gno.Nx(tf.Name)},
+			},
+		},
+	))[0]
+
+	// Extract the unexported fuzz target and seeds.
+	m.SetActivePackage(testingpv)
+	res := m.Eval(gno.Call(gno.Nx("fuzzTarget"), ft.fx()))
+	m.SetActivePackage(pv)
+	ft.fn = res[0]
+
+	var crasher string
+	if !ft.fn.IsUndefined() {
+		corpus, err := ft.loadCorpus(res[1], filepath.Join(fsDir, fuzzCorpusDir, tf.Name))
+		if err != nil {
+			return true, fmt.Errorf("%s: %w", tf.Name, err)
+		}
+		for _, entry := range corpus {
+			m.Eval(gno.Call(ft.testingX("RunFuzzSeed"), ft.fx(), gno.Str(entry.name), ft.callX(entry.vals)))
+		}
+
+		if fuzz && !ft.failed() {
+			crasher, err = opts.fuzz(ft, mpkg, corpus)
+			if err != nil {
+				return true, err
+			}
+			if crasher != "" {
+				crasher, err = writeCorpusFile(filepath.Join(fsDir, fuzzCorpusDir, tf.Name), crasher)
+				if err != nil {
+					return true, err
+				}
+			}
+		}
+	}
+
+	ret := m.Eval(gno.Call(ft.testingX("ReportFuzz"), ft.fx()))[0].GetString()
+	var rep report
+	if err := json.Unmarshal([]byte(ret), &rep); err != nil {
+		return true, fmt.Errorf("failed to execute fuzz test %q: %w", tf.Name, err)
+	}
+	if crasher != "" {
+		fmt.Fprintf(opts.Error, "\n    Failing input written to %s\n    To re-run:\n    gno test -run=%s/%s\n",
+			filepath.ToSlash(filepath.Join(fuzzCorpusDir, tf.Name, crasher)), tf.Name, crasher)
+	}
+	return rep.Failed, nil
+}
+
+// failed reports whether the fuzz test has failed.
+func (ft *fuzzTest) failed() bool {
+	return ft.m.Eval(gno.Call(gno.Sel(ft.fx(), "Failed")))[0].GetBool()
+}
+
+// fuzzEntry is an input of the corpus of a fuzz test.
+type fuzzEntry struct {
+	name string
+	vals []any
+}
+
+// loadCorpus validates the fuzz target, and returns the seed corpus of the
+// fuzz test: the seeds passed to F.Add, followed by the files in dir.
+func (ft *fuzzTest) loadCorpus(seeds gno.TypedValue, dir string) ([]fuzzEntry, error) {
+	fnt, ok := ft.fn.T.(*gno.FuncType)
+	if !ok {
+		return nil, fmt.Errorf("fuzz target must be a function, got %s", ft.fn.T.String())
+	}
+	if len(fnt.Results) != 0 {
+		return nil, errors.New("fuzz target must not return a value")
+	}
+	if len(fnt.Params) == 0 || fnt.Params[0].Type.String() != "*testing/base.T" {
+		return nil, errors.New("fuzz target must receive at least one argument of type *testing.T")
+	}
+	for _, p := range fnt.Params[1:] {
+		if !isFuzzType(p.Type) {
+			return nil, fmt.Errorf("fuzzing arguments can only have the following types: "+
+				"string, []byte, bool, integer and floating-point types; got %s", p.Type.String())
+		}
+		ft.types = append(ft.types, p.Type)
+	}
+
+	var corpus []fuzzEntry
+	for i, n := 0, seeds.GetLength(); i < n; i++ {
+		seed := seeds.GetPointerAtIndexInt(ft.m.Store, i).Deref()
+		vals := make([]any, seed.GetLength())
+		for j := range vals {
+			tv := seed.GetPointerAtIndexInt(ft.m.Store, j).Deref()
+			vals[j] = gno.Gno2GoValue(&tv, reflect.Value{}).Interface()
+		}
+		if err := ft.checkValues(vals); err != nil {
+			return nil, fmt.Errorf("seed#%d: %w", i, err)
+		}
+		corpus = append(corpus, fuzzEntry{name: fmt.Sprintf("seed#%d", i), vals: vals})
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		vals, err := unmarshalCorpusFile(b)
+		if err == nil {
+			err = ft.checkValues(vals)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, e.Name()), err)
+		}
+		corpus = append(corpus, fuzzEntry{name: e.Name(), vals: vals})
+	}
+	return corpus, nil
+}
+
+// checkValues returns an error if vals can't be passed to the fuzz target.
+func (ft *fuzzTest) checkValues(vals []any) error {
+	if len(vals) != len(ft.types) {
+		return fmt.Errorf("wrong number of values in corpus entry: %d, fuzz target expects %d",
+			len(vals), len(ft.types))
+	}
+	for i, val := range vals {
+		if t := fuzzValueType(val); t == nil || t.TypeID() != ft.types[i].TypeID() {
+			return fmt.Errorf("mismatched types in corpus entry: %T, want %s", val, ft.types[i].String())
+		}
+	}
+	return nil
+}
+
+// fuzz generates new inputs for ft by mutating the inputs of corpus. New
+// inputs which increase the statement coverage of the package are added to
+// the corpus. It returns the content of the corpus file of the first failing
+// input, if any.
+func (opts *TestOptions) fuzz(ft *fuzzTest, mpkg *std.MemPackage, corpus []fuzzEntry) (string, error) {
+	// Inputs are not run like subtests, so they don't count towards the
+	// coverage reported by 'gno test -cover'.
+	cov := gno.NewCoverage()
+	if err := cov.AddMemPackage(mpkg); err != nil {
+		return "", err
+	}
+	ft.m.Coverage = cov
+	defer func() { ft.m.Coverage = opts.Coverage }()
+
+	inputs := make([][]any, 0, len(corpus)+1)
+	for _, entry := range corpus {
+		inputs = append(inputs, entry.vals)
+	}
+	if len(inputs) == 0 {
+		// Start from the zero values of the arguments.
+		vals := make([]any, len(ft.types))
+		for i, t := range ft.types {
+			vals[i] = zeroFuzzValue(t)
+		}
+		inputs = append(inputs, vals)
+	}
+
+	var (
+		mut         = newMutator(rand.New(rand.NewSource(time.Now().UnixNano())))
+		start       = time.Now()
+		lastLog     = start
+		execs       int
+		interesting int
+	)
+	logProgress := func() {
+		elapsed := time.Since(start)
+		fmt.Fprintf(opts.Error, "fuzz: elapsed: %s, execs: %d (%.0f/sec), new interesting: %d (total: %d)\n",
+			elapsed.Round(time.Second), execs, float64(execs)/elapsed.Seconds(), interesting, len(inputs))
+	}
+	defer logProgress()
+
+	for {
+		if opts.FuzzTime.N > 0 && execs >= opts.FuzzTime.N {
+			return "", nil
+		}
+		if opts.FuzzTime.D > 0 && time.Since(start) >= opts.FuzzTime.D {
+			return "", nil
+		}
+		if time.Since(lastLog) >= fuzzLogInterval {
+			logProgress()
+			lastLog = time.Now()
+		}
+
+		vals := mut.mutate(inputs[mut.r.Intn(len(inputs))])
+		before, _ := cov.Stats(mpkg.Path)
+		failed := ft.m.Eval(gno.Call(ft.testingX("RunFuzzInput"), ft.fx(), ft.callX(vals)))[0].GetBool()
+		execs++
+		if failed {
+			return marshalCorpusFile(vals...), nil
+		}
+		if after, _ := cov.Stats(mpkg.Path); after > before {
+			inputs = append(inputs, vals)
+			interesting++
+		}
+	}
+}
+
+// writeCorpusFile writes the corpus file content to dir, and returns its name.
+func writeCorpusFile(dir, content string) (string, error) {
+	sum := sha256.Sum256([]byte(content))
+	name := hex.EncodeToString(sum[:])[:16]
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("could not create fuzz corpus directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("could not write failing input: %w", err)
+	}
+	return name, nil
+}
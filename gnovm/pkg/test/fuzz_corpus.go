@@ -0,0 +1,344 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// corpusFileHeader is the first line of corpus files. Corpus files use the
+// same format as Go, so that they can be shared with Go fuzz tests.
+const corpusFileHeader = "go test fuzz v1"
+
+// isFuzzType reports whether t can be the type of a fuzzed argument.
+func isFuzzType(t gno.Type) bool {
+	switch t := t.(type) {
+	case gno.PrimitiveType:
+		switch t {
+		case gno.StringType, gno.BoolType,
+			gno.IntType, gno.Int8Type, gno.Int16Type, gno.Int32Type, gno.Int64Type,
+			gno.UintType, gno.Uint8Type, gno.Uint16Type, gno.Uint32Type, gno.Uint64Type,
+			gno.Float32Type, gno.Float64Type:
+			return true
+		}
+	case *gno.SliceType:
+		return !t.Vrd && t.Elt == gno.Uint8Type
+	}
+	return false
+}
+
+// fuzzValueType returns the Gno type of the Go value v, or nil if v is not
+// of a supported type.
+func fuzzValueType(v any) gno.Type {
+	switch v.(type) {
+	case string:
+		return gno.StringType
+	case []byte:
+		return &gno.SliceType{Elt: gno.Uint8Type}
+	case bool:
+		return gno.BoolType
+	case int:
+		return gno.IntType
+	case int8:
+		return gno.Int8Type
+	case int16:
+		return gno.Int16Type
+	case int32:
+		return gno.Int32Type
+	case int64:
+		return gno.Int64Type
+	case uint:
+		return gno.UintType
+	case uint8:
+		return gno.Uint8Type
+	case uint16:
+		return gno.Uint16Type
+	case uint32:
+		return gno.Uint32Type
+	case uint64:
+		return gno.Uint64Type
+	case float32:
+		return gno.Float32Type
+	case float64:
+		return gno.Float64Type
+	}
+	return nil
+}
+
+// zeroFuzzValue returns the zero value of t, as a Go value.
+func zeroFuzzValue(t gno.Type) any {
+	switch t {
+	case gno.StringType:
+		return ""
+	case gno.BoolType:
+		return false
+	case gno.IntType:
+		return int(0)
+	case gno.Int8Type:
+		return int8(0)
+	case gno.Int16Type:
+		return int16(0)
+	case gno.Int32Type:
+		return int32(0)
+	case gno.Int64Type:
+		return int64(0)
+	case gno.UintType:
+		return uint(0)
+	case gno.Uint8Type:
+		return uint8(0)
+	case gno.Uint16Type:
+		return uint16(0)
+	case gno.Uint32Type:
+		return uint32(0)
+	case gno.Uint64Type:
+		return uint64(0)
+	case gno.Float32Type:
+		return float32(0)
+	case gno.Float64Type:
+		return float64(0)
+	}
+	return []byte{}
+}
+
+// marshalCorpusFile encodes vals into the content of a corpus file.
+func marshalCorpusFile(vals ...any) string {
+	var b bytes.Buffer
+	b.WriteString(corpusFileHeader + "\n")
+	for _, val := range vals {
+		switch t := val.(type) {
+		case int, int8, int16, int64, uint, uint16, uint32, uint64, bool:
+			fmt.Fprintf(&b, "%T(%v)\n", t, t)
+		case float32:
+			if math.IsNaN(float64(t)) && math.Float32bits(t) != math.Float32bits(float32(math.NaN())) {
+				fmt.Fprintf(&b, "math.Float32frombits(0x%x)\n", math.Float32bits(t))
+			} else {
+				fmt.Fprintf(&b, "%T(%v)\n", t, t)
+			}
+		case float64:
+			if math.IsNaN(t) && math.Float64bits(t) != math.Float64bits(math.NaN()) {
+				fmt.Fprintf(&b, "math.Float64frombits(0x%x)\n", math.Float64bits(t))
+			} else {
+				fmt.Fprintf(&b, "%T(%v)\n", t, t)
+			}
+		case string:
+			fmt.Fprintf(&b, "string(%q)\n", t)
+		case int32:
+			if utf8.ValidRune(t) {
+				fmt.Fprintf(&b, "rune(%q)\n", t)
+			} else {
+				fmt.Fprintf(&b, "int32(%v)\n", t)
+			}
+		case uint8:
+			fmt.Fprintf(&b, "byte(%q)\n", t)
+		case []byte:
+			fmt.Fprintf(&b, "[]byte(%q)\n", t)
+		default:
+			panic(fmt.Sprintf("unsupported type: %T", t))
+		}
+	}
+	return b.String()
+}
+
+// unmarshalCorpusFile decodes the content of a corpus file.
+func unmarshalCorpusFile(b []byte) ([]any, error) {
+	lines := bytes.Split(b, []byte("\n"))
+	if len(lines) < 2 {
+		return nil, errors.New("must include version and at least one value")
+	}
+	if version := string(bytes.TrimSpace(lines[0])); version != corpusFileHeader {
+		return nil, fmt.Errorf("unknown encoding version: %s", version)
+	}
+	var vals []any
+	for _, line := range lines[1:] {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		v, err := parseCorpusValue(string(line))
+		if err != nil {
+			return nil, fmt.Errorf("malformed line %q: %w", line, err)
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+// parseCorpusValue parses a line of a corpus file, which is a conversion of
+// a literal to a supported type, like `int(42)` or `[]byte("hello")`.
+func parseCorpusValue(line string) (any, error) {
+	expr, err := parser.ParseExpr(line)
+	if err != nil {
+		return nil, err
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, errors.New("expected call expression with 1 argument")
+	}
+	arg := call.Args[0]
+
+	switch fn := call.Fun.(type) {
+	case *ast.ArrayType:
+		if elt, ok := fn.Elt.(*ast.Ident); fn.Len != nil || !ok || elt.Name != "byte" {
+			return nil, errors.New("expected []byte or primitive type")
+		}
+		lit, ok := arg.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, errors.New("string literal required for type []byte")
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+
+	case *ast.SelectorExpr:
+		if pkg, ok := fn.X.(*ast.Ident); !ok || pkg.Name != "math" {
+			return nil, errors.New("expected math.Float32frombits or math.Float64frombits")
+		}
+		lit, ok := arg.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return nil, errors.New("integer literal required for math.FloatXXfrombits")
+		}
+		switch fn.Sel.Name {
+		case "Float32frombits":
+			u, err := strconv.ParseUint(lit.Value, 0, 32)
+			if err != nil {
+				return nil, err
+			}
+			return math.Float32frombits(uint32(u)), nil
+		case "Float64frombits":
+			u, err := strconv.ParseUint(lit.Value, 0, 64)
+			if err != nil {
+				return nil, err
+			}
+			return math.Float64frombits(u), nil
+		}
+		return nil, errors.New("expected math.Float32frombits or math.Float64frombits")
+
+	case *ast.Ident:
+		return parseCorpusLiteral(fn.Name, arg)
+	}
+	return nil, errors.New("expected []byte or primitive type")
+}
+
+// parseCorpusLiteral parses arg as a literal of the type typ.
+func parseCorpusLiteral(typ string, arg ast.Expr) (any, error) {
+	if typ == "bool" {
+		id, ok := arg.(*ast.Ident)
+		if !ok || (id.Name != "true" && id.Name != "false") {
+			return nil, errors.New("true or false required for type bool")
+		}
+		return id.Name == "true", nil
+	}
+
+	// Get the literal, allowing for a sign, and +Inf, -Inf and NaN for
+	// floating-point types.
+	sign := ""
+	if u, ok := arg.(*ast.UnaryExpr); ok && (u.Op == token.SUB || u.Op == token.ADD) {
+		sign, arg = u.Op.String(), u.X
+	}
+	var val string
+	var kind token.Token
+	switch x := arg.(type) {
+	case *ast.BasicLit:
+		val, kind = x.Value, x.Kind
+	case *ast.Ident:
+		if (typ != "float32" && typ != "float64") || (x.Name != "Inf" && x.Name != "NaN") {
+			return nil, fmt.Errorf("literal value required for type %s", typ)
+		}
+		val, kind = x.Name, token.FLOAT
+	default:
+		return nil, fmt.Errorf("literal value required for type %s", typ)
+	}
+	if sign != "" && kind != token.INT && kind != token.FLOAT {
+		return nil, fmt.Errorf("invalid signed literal for type %s", typ)
+	}
+	val = sign + val
+
+	switch typ {
+	case "string":
+		if kind != token.STRING {
+			return nil, errors.New("string literal required for type string")
+		}
+		return strconv.Unquote(val)
+	case "byte", "rune":
+		if kind == token.CHAR {
+			r, _, tail, err := strconv.UnquoteChar(val[1:len(val)-1], '\'')
+			if err != nil {
+				return nil, err
+			}
+			if tail != "" {
+				return nil, errors.New("invalid character literal")
+			}
+			if typ == "byte" {
+				if r > math.MaxUint8 {
+					return nil, fmt.Errorf("character literal %s overflows byte", val)
+				}
+				return byte(r), nil
+			}
+			return r, nil
+		}
+		if typ == "byte" {
+			typ = "uint8"
+		} else {
+			typ = "int32"
+		}
+	}
+	if kind != token.INT && kind != token.FLOAT {
+		return nil, fmt.Errorf("numeric literal required for type %s", typ)
+	}
+
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64":
+		bits := map[string]int{"int": 0, "int8": 8, "int16": 16, "int32": 32, "int64": 64}[typ]
+		n, err := strconv.ParseInt(val, 0, bits)
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case "int":
+			return int(n), nil
+		case "int8":
+			return int8(n), nil
+		case "int16":
+			return int16(n), nil
+		case "int32":
+			return int32(n), nil
+		}
+		return n, nil
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		bits := map[string]int{"uint": 0, "uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64}[typ]
+		n, err := strconv.ParseUint(val, 0, bits)
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case "uint":
+			return uint(n), nil
+		case "uint8":
+			return uint8(n), nil
+		case "uint16":
+			return uint16(n), nil
+		case "uint32":
+			return uint32(n), nil
+		}
+		return n, nil
+	case "float32":
+		f, err := strconv.ParseFloat(val, 32)
+		if err != nil {
+			return nil, err
+		}
+		return float32(f), nil
+	case "float64":
+		return strconv.ParseFloat(val, 64)
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpusFile(t *testing.T) {
+	vals := []any{
+		"hello\n\"world\"", []byte("\x00\xff"), true, false,
+		int(-42), int8(math.MinInt8), int16(7), int32('☺'), int32(-1), int64(math.MaxInt64),
+		uint(42), uint8('a'), uint16(7), uint32(7), uint64(math.MaxUint64),
+		float32(1.5), float64(-2.25), math.Inf(1), math.Inf(-1), float32(1e-10),
+	}
+
+	content := marshalCorpusFile(vals...)
+	assert.Contains(t, content, "go test fuzz v1\nstring(\"hello\\n\\\"world\\\"\")\n[]byte(\"\\x00\\xff\")\n")
+	assert.Contains(t, content, "rune('☺')\nint32(-1)\n")
+	assert.Contains(t, content, "byte('a')\n")
+
+	got, err := unmarshalCorpusFile([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, vals, got)
+
+	// NaN is not equal to itself.
+	got, err = unmarshalCorpusFile([]byte(marshalCorpusFile(math.NaN(), math.Float64frombits(0x7ff8000000000001))))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, math.IsNaN(got[0].(float64)))
+	assert.Equal(t, uint64(0x7ff8000000000001), math.Float64bits(got[1].(float64)))
+}
+
+func TestCorpusFile_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		content string
+		err     string
+	}{
+		{"", "must include version"},
+		{"go test fuzz v2\nint(1)\n", "unknown encoding version"},
+		{"go test fuzz v1\nint(\"a\")\n", "numeric literal required for type int"},
+		{"go test fuzz v1\nint8(300)\n", "out of range"},
+		{"go test fuzz v1\n[]int(\"a\")\n", "expected []byte or primitive type"},
+		{"go test fuzz v1\nbool(1)\n", "true or false required"},
+		{"go test fuzz v1\nfoo(1)\n", "unsupported type foo"},
+		{"go test fuzz v1\nint(1, 2)\n", "expected call expression with 1 argument"},
+	} {
+		_, err := unmarshalCorpusFile([]byte(tc.content))
+		assert.ErrorContains(t, err, tc.err, "content: %q", tc.content)
+	}
+}
+
+func TestMutator(t *testing.T) {
+	mu := newMutator(rand.New(rand.NewSource(1)))
+	orig := []any{"hello", []byte("world"), int8(1), uint(2), 3.5, true}
+	vals := orig
+	for range 1000 {
+		got := mu.mutate(vals)
+		require.Len(t, got, len(vals))
+		for i := range got {
+			// mutations must preserve the types of the values.
+			assert.IsType(t, vals[i], got[i])
+		}
+		vals = got
+	}
+	// the original values must not be modified.
+	assert.Equal(t, []byte("world"), orig[1])
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"math"
+	"math/rand"
+)
+
+// maxFuzzBytes is the maximum length of the strings and byte slices
+// generated by the mutator.
+const maxFuzzBytes = 1 << 12
+
+// mutator generates new fuzzing inputs from existing ones.
+type mutator struct {
+	r *rand.Rand
+}
+
+func newMutator(r *rand.Rand) *mutator {
+	return &mutator{r: r}
+}
+
+// mutate returns a copy of vals, where one of the values has been mutated.
+func (mu *mutator) mutate(vals []any) []any {
+	vals = append([]any(nil), vals...)
+	if len(vals) == 0 {
+		return vals
+	}
+	i := mu.r.Intn(len(vals))
+	switch v := vals[i].(type) {
+	case string:
+		vals[i] = string(mu.mutateBytes([]byte(v)))
+	case []byte:
+		vals[i] = mu.mutateBytes(append([]byte(nil), v...))
+	case bool:
+		vals[i] = !v
+	case int:
+		vals[i] = int(mu.mutateInt(int64(v), 64))
+	case int8:
+		vals[i] = int8(mu.mutateInt(int64(v), 8))
+	case int16:
+		vals[i] = int16(mu.mutateInt(int64(v), 16))
+	case int32:
+		vals[i] = int32(mu.mutateInt(int64(v), 32))
+	case int64:
+		vals[i] = mu.mutateInt(v, 64)
+	case uint:
+		vals[i] = uint(mu.mutateInt(int64(v), 64))
+	case uint8:
+		vals[i] = uint8(mu.mutateInt(int64(v), 8))
+	case uint16:
+		vals[i] = uint16(mu.mutateInt(int64(v), 16))
+	case uint32:
+		vals[i] = uint32(mu.mutateInt(int64(v), 32))
+	case uint64:
+		vals[i] = uint64(mu.mutateInt(int64(v), 64))
+	case float32:
+		vals[i] = float32(mu.mutateFloat(float64(v)))
+	case float64:
+		vals[i] = mu.mutateFloat(v)
+	}
+	return vals
+}
+
+// mutateInt mutates an integer of the given size in bits. The result is
+// truncated to the right size by the caller, so overflows wrap around.
+func (mu *mutator) mutateInt(v int64, bits int) int64 {
+	switch mu.r.Intn(4) {
+	case 0:
+		return v + 1 + mu.r.Int63n(100)
+	case 1:
+		return v - 1 - mu.r.Int63n(100)
+	case 2:
+		return v ^ (1 << mu.r.Intn(bits))
+	default:
+		interesting := [...]int64{0, 1, -1, math.MaxInt8, math.MinInt8, math.MaxUint8,
+			math.MaxInt16, math.MinInt16, math.MaxUint16, math.MaxInt32, math.MinInt32,
+			math.MaxUint32, math.MaxInt64, math.MinInt64}
+		return interesting[mu.r.Intn(len(interesting))]
+	}
+}
+
+func (mu *mutator) mutateFloat(v float64) float64 {
+	switch mu.r.Intn(5) {
+	case 0:
+		return v + float64(1+mu.r.Intn(100))
+	case 1:
+		return v - float64(1+mu.r.Intn(100))
+	case 2:
+		return v * float64(1+mu.r.Intn(100))
+	case 3:
+		return v / float64(1+mu.r.Intn(100))
+	default:
+		interesting := [...]float64{0, math.Copysign(0, -1), 1, -1, 0.5,
+			math.MaxFloat64, math.SmallestNonzeroFloat64, math.Inf(1), math.Inf(-1), math.NaN()}
+		return interesting[mu.r.Intn(len(interesting))]
+	}
+}
+
+// mutateBytes applies a few random mutations to b, which it may modify.
+func (mu *mutator) mutateBytes(b []byte) []byte {
+	for n := 1 + mu.r.Intn(3); n > 0; n-- {
+		op := mu.r.Intn(7)
+		if len(b) == 0 {
+			op = 0 // only insertion is possible.
+		}
+		switch op {
+		case 0: // insert random bytes
+			pos := mu.r.Intn(len(b) + 1)
+			ins := make([]byte, 1+mu.r.Intn(8))
+			mu.r.Read(ins)
+			b = append(b[:pos], append(ins, b[pos:]...)...)
+		case 1: // remove a range of bytes
+			pos := mu.r.Intn(len(b))
+			end := pos + 1 + mu.r.Intn(len(b)-pos)
+			b = append(b[:pos], b[end:]...)
+		case 2: // duplicate a range of bytes
+			pos := mu.r.Intn(len(b))
+			end := pos + 1 + mu.r.Intn(len(b)-pos)
+			b = append(b[:end], append(append([]byte(nil), b[pos:end]...), b[end:]...)...)
+		case 3: // flip a bit
+			b[mu.r.Intn(len(b))] ^= 1 << mu.r.Intn(8)
+		case 4: // set a byte to a random value
+			b[mu.r.Intn(len(b))] = byte(mu.r.Intn(256))
+		case 5: // swap two bytes
+			i, j := mu.r.Intn(len(b)), mu.r.Intn(len(b))
+			b[i], b[j] = b[j], b[i]
+		case 6: // set a byte to an interesting value
+			interesting := [...]byte{0, 0x7f, 0x80, 0xff, ' ', '\n', '"', '\\', '0', 'a'}
+			b[mu.r.Intn(len(b))] = interesting[mu.r.Intn(len(interesting))]
+		}
+	}
+	if len(b) > maxFuzzBytes {
+		b = b[:maxFuzzBytes]
+	}
+	return b
+}
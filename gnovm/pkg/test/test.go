@@ -38,8 +38,9 @@ const (
 
 // Context returns a TestExecContext. Usable for test purpose only.
 // The caller should be empty for package initialization.
-// The returned context has a mock banker, params and event logger. It will give
-// the pkgAddr the coins in `send` by default, and only that.
+// The returned context has a mock banker, params, fee allowances and event
+// logger. It will give the pkgAddr the coins in `send` by default, and only
+// that.
 // The Height and Timestamp parameters are set to the [DefaultHeight] and
 // [DefaultTimestamp].
 func Context(caller crypto.Bech32Address, pkgPath string, send std.Coins) *teststd.TestExecContext {
@@ -61,6 +62,7 @@ func Context(caller crypto.Bech32Address, pkgPath string, send std.Coins) *tests
 		OriginSendSpent: new(std.Coins),
 		Banker:          banker,
 		Params:          newTestParams(),
+		FeeAllowances:   newTestFeeAllowances(),
 		EventLogger:     sdk.NewEventLogger(),
 	}
 	return &teststd.TestExecContext{
@@ -99,18 +101,103 @@ func (o outputWithError) StderrWrite(p []byte) (int, error) { return o.errW.Writ
 // ----------------------------------------
 // testParams
 
-type testParams struct{}
+// testParams stores the parameters in memory, for the duration of a test.
+type testParams struct {
+	values map[string]any
+}
 
 func newTestParams() *testParams {
-	return &testParams{}
+	return &testParams{values: map[string]any{}}
+}
+
+func (tp *testParams) SetBool(key string, val bool)     { tp.values[key] = val }
+func (tp *testParams) SetBytes(key string, val []byte)  { tp.values[key] = append([]byte(nil), val...) }
+func (tp *testParams) SetInt64(key string, val int64)   { tp.values[key] = val }
+func (tp *testParams) SetUint64(key string, val uint64) { tp.values[key] = val }
+func (tp *testParams) SetString(key string, val string) { tp.values[key] = val }
+func (tp *testParams) SetStrings(key string, val []string) {
+	tp.values[key] = append([]string(nil), val...)
+}
+
+func (tp *testParams) GetBool(key string, ptr *bool) (bool, error) {
+	return getTestParam(tp, key, ptr)
+}
+
+func (tp *testParams) GetBytes(key string, ptr *[]byte) (bool, error) {
+	return getTestParam(tp, key, ptr)
+}
+
+func (tp *testParams) GetInt64(key string, ptr *int64) (bool, error) {
+	return getTestParam(tp, key, ptr)
+}
+
+func (tp *testParams) GetUint64(key string, ptr *uint64) (bool, error) {
+	return getTestParam(tp, key, ptr)
+}
+
+func (tp *testParams) GetString(key string, ptr *string) (bool, error) {
+	return getTestParam(tp, key, ptr)
+}
+
+func (tp *testParams) GetStrings(key string, ptr *[]string) (bool, error) {
+	return getTestParam(tp, key, ptr)
+}
+
+func getTestParam[T any](tp *testParams, key string, ptr *T) (bool, error) {
+	val, ok := tp.values[key]
+	if !ok {
+		return false, nil
+	}
+	tv, ok := val.(T)
+	if !ok {
+		return false, fmt.Errorf("param %q has another type", key)
+	}
+	*ptr = tv
+	return true, nil
+}
+
+// ----------------------------------------
+// testFeeAllowances
+
+// testFeeAllowances stores the fee allowances in memory, for the duration of a
+// test. Unlike the auth keeper, it doesn't check their expiration.
+type testFeeAllowances struct {
+	values map[[2]crypto.Bech32Address]testFeeAllowance
+}
+
+type testFeeAllowance struct {
+	spendLimit std.Coins
+	expiration time.Time
 }
 
-func (tp *testParams) SetBool(key string, val bool)        { /* noop */ }
-func (tp *testParams) SetBytes(key string, val []byte)     { /* noop */ }
-func (tp *testParams) SetInt64(key string, val int64)      { /* noop */ }
-func (tp *testParams) SetUint64(key string, val uint64)    { /* noop */ }
-func (tp *testParams) SetString(key string, val string)    { /* noop */ }
-func (tp *testParams) SetStrings(key string, val []string) { /* noop */ }
+func newTestFeeAllowances() *testFeeAllowances {
+	return &testFeeAllowances{values: map[[2]crypto.Bech32Address]testFeeAllowance{}}
+}
+
+func (tfa *testFeeAllowances) GetFeeAllowance(granter, grantee crypto.Bech32Address) (std.Coins, time.Time, bool) {
+	a, ok := tfa.values[[2]crypto.Bech32Address{granter, grantee}]
+	return a.spendLimit, a.expiration, ok
+}
+
+func (tfa *testFeeAllowances) GrantFeeAllowance(granter, grantee crypto.Bech32Address, spendLimit std.Coins, expiration time.Time) error {
+	if granter == grantee {
+		return errors.New("granter and grantee must be different")
+	}
+	if !spendLimit.IsValid() {
+		return fmt.Errorf("invalid spend limit %s", spendLimit)
+	}
+	tfa.values[[2]crypto.Bech32Address{granter, grantee}] = testFeeAllowance{spendLimit, expiration}
+	return nil
+}
+
+func (tfa *testFeeAllowances) RevokeFeeAllowance(granter, grantee crypto.Bech32Address) error {
+	key := [2]crypto.Bech32Address{granter, grantee}
+	if _, ok := tfa.values[key]; !ok {
+		return fmt.Errorf("no fee allowance granted by %s to %s", granter, grantee)
+	}
+	delete(tfa.values, key)
+	return nil
+}
 
 // ----------------------------------------
 // main test function
@@ -128,11 +215,25 @@ type TestOptions struct {
 	Error io.Writer
 	// Debug enables the interactive debugger on gno tests.
 	Debug bool
+	// If set, the debugger waits for a remote client, which may use the
+	// Debug Adapter Protocol, to connect to this address.
+	DebugAddr string
 
 	// Not set by NewTestOptions:
 
 	// Flag to filter tests to run.
 	RunFlag string
+	// Flag to filter benchmarks to run; if empty, no benchmarks are run.
+	BenchFlag string
+	// Time or number of iterations for which each benchmark is run;
+	// defaults to [DefaultBenchTime].
+	BenchTime BenchTime
+	// Flag to select the fuzz test to fuzz; if empty, fuzz tests only run
+	// their seed corpus.
+	FuzzFlag string
+	// Time or number of iterations for which the fuzz test is fuzzed; if
+	// zero, fuzzing runs until a failing input is found.
+	FuzzTime BenchTime
 	// Flag to stop executing as soon a test fails.
 	FailfastFlag bool
 	// Whether to update filetest directives.
@@ -144,10 +245,13 @@ type TestOptions struct {
 	Metrics bool
 	// Uses Error to print the events emitted.
 	Events bool
+	// If set, records the statements executed by the tests.
+	Coverage *gno.Coverage
 
 	filetestBuffer bytes.Buffer
 	outWriter      proxyWriter
 	tcCache        gno.TypeCheckCache
+	debugger       *gno.Debugger // remote debugging session, shared by tests
 }
 
 // WriterForStore is the writer that should be passed to [Store], so that
@@ -156,6 +260,16 @@ func (opts *TestOptions) WriterForStore() io.Writer {
 	return &opts.outWriter
 }
 
+// CloseDebugger ends the remote debugging session, if any.
+func (opts *TestOptions) CloseDebugger() error {
+	if opts.debugger == nil {
+		return nil
+	}
+	err := opts.debugger.Close()
+	opts.debugger = nil
+	return err
+}
+
 // NewTestOptions sets up TestOptions, filling out all "required" parameters.
 func NewTestOptions(rootDir string, stdout, stderr io.Writer, pkgs packages.PkgList) *TestOptions {
 	opts := &TestOptions{
@@ -246,6 +360,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 		// new packages by default, which we don't want.  Instead we
 		// will run the mempackage ourselves in the next line.
 		SkipPackage: true,
+		Coverage:    opts.Coverage,
 	})
 	// Filter out xxx_test *_test.gno and *_filetest.gno and run.
 	// If testing with only filetests, there will be no files.
@@ -269,7 +384,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 	if len(tset.Files)+len(itset.Files) > 0 {
 		// Run test files in pkg.
 		if len(tset.Files) > 0 {
-			err := opts.runTestFiles(mpkg, tset, tgs)
+			err := opts.runTestFiles(mpkg, fsDir, tset, tgs)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -290,7 +405,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 				Files: itfiles,
 			}
 
-			err := opts.runTestFiles(itmpkg, itset, tgs)
+			err := opts.runTestFiles(itmpkg, fsDir, itset, tgs)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -349,6 +464,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 // which runs *_filetest.go tests.
 func (opts *TestOptions) runTestFiles(
 	mpkg *std.MemPackage,
+	fsDir string,
 	files *gno.FileSet,
 	tgs gno.TransactionStore,
 ) (errs error) {
@@ -366,7 +482,7 @@ func (opts *TestOptions) runTestFiles(
 		}
 	}()
 
-	tests := loadTestFuncs(mpkg.Name, files)
+	tests := loadTestFuncs(mpkg.Name, files, "Test")
 
 	var alloc *gno.Allocator
 	if opts.Metrics {
@@ -376,8 +492,10 @@ func (opts *TestOptions) runTestFiles(
 	opts.TestStore.SetLogStoreOps(nil)
 
 	// Check if we already have the package - it may have been eagerly loaded.
-	m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug)
+	// The debugger, if any, is only enabled to run the test functions.
+	m = Machine(tgs, opts.WriterForStore(), mpkg.Path, false)
 	m.Alloc = alloc
+	m.Coverage = opts.Coverage
 	if tgs.GetMemPackage(mpkg.Path) == nil {
 		m.RunMemPackage(mpkg, false)
 	} else {
@@ -397,8 +515,9 @@ func (opts *TestOptions) runTestFiles(
 		// - Run the test files before this for loop (but persist it to store;
 		//   RunFiles doesn't do that currently)
 		// - Wrap here.
-		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug)
+		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, false)
 		m.Alloc = alloc.Reset()
+		m.Coverage = opts.Coverage
 		m.SetActivePackage(pv)
 
 		testingpv := m.Store.GetPackage("testing/base", false)
@@ -445,7 +564,11 @@ func (opts *TestOptions) runTestFiles(
 		}
 		runTestCX := gno.NewConstExpr(runTestX, runTest)
 
-		if opts.Debug {
+		switch {
+		case opts.debugger != nil:
+			// Continue the remote debugging session of the previous tests.
+			m.Debugger.Resume(opts.debugger)
+		case opts.Debug:
 			fileContent := func(ppath, name string) string {
 				p := filepath.Join(opts.RootDir, ppath, name)
 				b, err := os.ReadFile(p)
@@ -460,6 +583,11 @@ func (opts *TestOptions) runTestFiles(
 				return string(b)
 			}
 			m.Debugger.Enable(os.Stdin, os.Stdout, fileContent)
+			if opts.DebugAddr != "" {
+				if err := m.Debugger.Serve(opts.DebugAddr); err != nil {
+					return err
+				}
+			}
 		}
 
 		eval := m.Eval(gno.Call(
@@ -479,6 +607,11 @@ func (opts *TestOptions) runTestFiles(
 			},
 		))
 
+		if opts.DebugAddr != "" {
+			dbg := m.Debugger
+			opts.debugger = &dbg
+		}
+
 		if opts.Events {
 			events := m.Context.(*teststd.TestExecContext).EventLogger.Events()
 			if events != nil {
@@ -533,6 +666,19 @@ func (opts *TestOptions) runTestFiles(
 		}
 	}
 
+	if err := opts.runFuzzTests(mpkg, fsDir, files, tgs, pv); err != nil {
+		errs = multierr.Append(errs, err)
+		if opts.FailfastFlag {
+			return errs
+		}
+	}
+
+	if opts.BenchFlag != "" {
+		if err := opts.runBenchmarks(mpkg, files, tgs, pv); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
 	return errs
 }
 
@@ -548,7 +694,9 @@ type testFunc struct {
 	Filename string
 }
 
-func loadTestFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
+// loadTestFuncs returns the functions in tfiles whose name starts with prefix,
+// like "Test" or "Benchmark".
+func loadTestFuncs(pkgName string, tfiles *gno.FileSet, prefix string) (rt []testFunc) {
 	for _, tf := range tfiles.Files {
 		for _, d := range tf.Decls {
 			if fd, ok := d.(*gno.FuncDecl); ok {
@@ -556,7 +704,7 @@ func loadTestFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
 					continue
 				}
 				fname := string(fd.Name)
-				if strings.HasPrefix(fname, "Test") {
+				if strings.HasPrefix(fname, prefix) {
 					tf := testFunc{
 						Package:  pkgName,
 						Name:     fname,
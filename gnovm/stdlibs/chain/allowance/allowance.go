@@ -0,0 +1,57 @@
+package allowance
+
+import (
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+func X_assertCallerIsRealm(m *gno.Machine, rlmPath string) {
+	fr := &m.Frames[m.NumFrames()-2]
+	if path := fr.LastPackage.PkgPath; path == "" || path != rlmPath {
+		panicString(m, "caller is not the current realm")
+	}
+}
+
+func panicString(m *gno.Machine, s string) {
+	tv := gno.TypedValue{T: gno.StringType}
+	tv.SetString(gno.StringValue(s))
+	m.Panic(tv)
+}
+
+// The expiration is passed as Unix seconds, 0 meaning that the allowance never
+// expires.
+
+func X_grant(m *gno.Machine, granter, grantee string, denoms []string, amounts []int64, expiration int64) {
+	var exp time.Time
+	if expiration != 0 {
+		exp = time.Unix(expiration, 0).UTC()
+	}
+	err := std.GetContext(m).FeeAllowances.GrantFeeAllowance(
+		crypto.Bech32Address(granter), crypto.Bech32Address(grantee),
+		std.CompactCoins(denoms, amounts), exp)
+	if err != nil {
+		panicString(m, err.Error())
+	}
+}
+
+func X_revoke(m *gno.Machine, granter, grantee string) {
+	err := std.GetContext(m).FeeAllowances.RevokeFeeAllowance(crypto.Bech32Address(granter), crypto.Bech32Address(grantee))
+	if err != nil {
+		panicString(m, err.Error())
+	}
+}
+
+func X_get(m *gno.Machine, granter, grantee string) (denoms []string, amounts []int64, expiration int64, ok bool) {
+	spendLimit, exp, ok := std.GetContext(m).FeeAllowances.GetFeeAllowance(crypto.Bech32Address(granter), crypto.Bech32Address(grantee))
+	if !ok {
+		return nil, nil, 0, false
+	}
+	if !exp.IsZero() {
+		expiration = exp.Unix()
+	}
+	denoms, amounts = std.ExpandCoins(spendLimit)
+	return denoms, amounts, expiration, true
+}
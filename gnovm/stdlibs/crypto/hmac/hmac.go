@@ -0,0 +1,33 @@
+package hmac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// CPU cycles charged per call and per byte of key and message.
+const (
+	cpuSHA256Base    = 1300
+	cpuSHA256PerByte = 1
+	cpuSHA512Base    = 3500
+	cpuSHA512PerByte = 4
+)
+
+func X_sha256(m *gno.Machine, key, message []byte) (sum [32]byte) {
+	m.IncrCPU(cpuSHA256Base + cpuSHA256PerByte*int64(len(key)+len(message)))
+	h := hmac.New(sha256.New, key)
+	h.Write(message)
+	h.Sum(sum[:0])
+	return sum
+}
+
+func X_sha512(m *gno.Machine, key, message []byte) (sum [64]byte) {
+	m.IncrCPU(cpuSHA512Base + cpuSHA512PerByte*int64(len(key)+len(message)))
+	h := hmac.New(sha512.New, key)
+	h.Write(message)
+	h.Sum(sum[:0])
+	return sum
+}
@@ -0,0 +1,20 @@
+package ripemd160
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck,gosec
+)
+
+// CPU cycles charged per call and per byte hashed.
+const (
+	cpuSumBase    = 700
+	cpuSumPerByte = 10
+)
+
+func X_sum(m *gno.Machine, data []byte) (sum [20]byte) {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	h := ripemd160.New()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
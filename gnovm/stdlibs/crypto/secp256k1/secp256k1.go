@@ -0,0 +1,31 @@
+package secp256k1
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// CPU cycles charged per call.
+const cpuVerify = 190000
+
+func X_verify(m *gno.Machine, publicKey, hash, signature []byte) bool {
+	m.IncrCPU(cpuVerify)
+
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return false // overflow
+	}
+	// Reject malleable signatures.
+	if s.IsOverHalfOrder() {
+		return false
+	}
+
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pub)
+}
@@ -0,0 +1,41 @@
+package sha3
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"golang.org/x/crypto/sha3"
+)
+
+// CPU cycles charged per call and per byte hashed. The cost per byte is
+// that of SHA3-512, whose rate is the lowest.
+const (
+	cpuSumBase    = 700
+	cpuSumPerByte = 10
+)
+
+func X_sum224(m *gno.Machine, data []byte) [28]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha3.Sum224(data)
+}
+
+func X_sum256(m *gno.Machine, data []byte) [32]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha3.Sum256(data)
+}
+
+func X_sum384(m *gno.Machine, data []byte) [48]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha3.Sum384(data)
+}
+
+func X_sum512(m *gno.Machine, data []byte) [64]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha3.Sum512(data)
+}
+
+func X_keccak256(m *gno.Machine, data []byte) (sum [32]byte) {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
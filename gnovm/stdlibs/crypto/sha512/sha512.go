@@ -0,0 +1,28 @@
+package sha512
+
+import (
+	"crypto/sha512"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// CPU cycles charged per call and per byte hashed.
+const (
+	cpuSumBase    = 450
+	cpuSumPerByte = 3
+)
+
+func X_sum512(m *gno.Machine, data []byte) [64]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha512.Sum512(data)
+}
+
+func X_sum384(m *gno.Machine, data []byte) [48]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha512.Sum384(data)
+}
+
+func X_sum512_256(m *gno.Machine, data []byte) [32]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha512.Sum512_256(data)
+}
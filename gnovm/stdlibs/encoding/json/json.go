@@ -0,0 +1,211 @@
+package json
+
+import (
+	"math"
+	"unicode/utf8"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/overflow"
+)
+
+// The natives use the copy of Go's scanner in scanner.go rather than
+// encoding/json, so that their output and syntax error messages don't depend
+// on the Go version the node is built with.
+
+// CPU cycles charged per call and per byte read or written.
+const (
+	cpuBase    = 100
+	cpuPerByte = 4
+)
+
+// charge charges the base cost and the cost of n bytes. The bytes read and
+// the bytes written are charged before doing the work, so that no large
+// output is built without being paid for.
+func charge(m *gno.Machine, n int) {
+	m.IncrCPU(cpuBase + cpuPerByte*int64(n))
+}
+
+// chargeOutput charges the cost of n bytes written, where n is saturated to
+// math.MaxInt64 so that it exhausts any gas limit.
+func chargeOutput(m *gno.Machine, n int64) {
+	cost, ok := overflow.Mul(n, cpuPerByte)
+	if !ok {
+		cost = math.MaxInt64
+	}
+	m.IncrCPU(cost)
+}
+
+func X_checkValid(m *gno.Machine, data []byte) (string, int64) {
+	charge(m, len(data))
+	if err := checkValid(data); err != nil {
+		return err.msg, err.Offset
+	}
+	return "", 0
+}
+
+func X_compact(m *gno.Machine, dst, src []byte, escapeHTML bool) ([]byte, string, int64) {
+	charge(m, len(src))
+	chargeOutput(m, compactLen(src, escapeHTML))
+	out, err := appendCompact(dst, src, escapeHTML)
+	if err != nil {
+		return dst, err.msg, err.Offset
+	}
+	return out, "", 0
+}
+
+func X_indent(m *gno.Machine, dst, src []byte, prefix, indent string) ([]byte, string, int64) {
+	charge(m, len(src))
+	// The output grows with the nesting depth times the length of indent,
+	// both given by the caller, so its length is computed, and charged,
+	// before building it.
+	n, err := indentLen(src, prefix, indent)
+	if err != nil {
+		return dst, err.msg, err.Offset
+	}
+	chargeOutput(m, n)
+	out, err := appendIndent(dst, src, prefix, indent)
+	if err != nil {
+		return dst, err.msg, err.Offset
+	}
+	return out, "", 0
+}
+
+func X_quote(m *gno.Machine, s string, escapeHTML bool) string {
+	charge(m, len(s))
+	chargeOutput(m, quotedLen(s, escapeHTML))
+	return string(appendString(nil, s, escapeHTML))
+}
+
+func X_unquote(m *gno.Machine, data []byte) (string, bool) {
+	charge(m, len(data))
+	return unquote(data)
+}
+
+func X_typeOf(m *gno.Machine, v gno.TypedValue) (string, bool, bool) {
+	if v.IsUndefined() {
+		charge(m, 0)
+		return "", false, true
+	}
+	pt, isPointer := gno.BaseOf(v.T).(*gno.PointerType)
+	if isPointer && pt.Elt == nil {
+		// The VM types a pointer to an interface variable with the type
+		// of its value, which has none if the interface is nil. The name
+		// is nilInterfacePointer of json.gno.
+		const name = "pointer to a nil interface"
+		charge(m, len(name))
+		return name, true, false
+	}
+	name := v.T.String()
+	charge(m, len(name))
+	return name, isPointer, isPointer && v.V == nil
+}
+
+// compactLen returns an upper bound of the length of the output of
+// appendCompact for src: the length of src, plus the escapes of <, >, &,
+// U+2028 and U+2029 if escape is set.
+func compactLen(src []byte, escape bool) int64 {
+	n := int64(len(src))
+	if !escape {
+		return n
+	}
+	for i, c := range src {
+		switch {
+		case c == '<' || c == '>' || c == '&':
+			n += int64(len(`\u003c`)) - 1
+		case c == 0xE2 && i+2 < len(src) && src[i+1] == 0x80 && src[i+2]&^1 == 0xA8:
+			n += int64(len(`\u2028`)) - 3
+		}
+	}
+	return n
+}
+
+// indentLen returns the length of the output of appendIndent for src,
+// saturated to math.MaxInt64, or the syntax error of src.
+func indentLen(src []byte, prefix, indent string) (int64, *syntaxError) {
+	var n int64
+	add := func(k int64) {
+		var ok bool
+		if n, ok = overflow.Add(n, k); !ok {
+			n = math.MaxInt64
+		}
+	}
+	newline := func(depth int) {
+		k, ok := overflow.Mul(int64(depth), int64(len(indent)))
+		if !ok {
+			k = math.MaxInt64
+		}
+		add(1 + int64(len(prefix)))
+		add(k)
+	}
+
+	// Same walk as appendIndent.
+	scan := newScanner()
+	needIndent := false
+	depth := 0
+	for _, c := range src {
+		scan.bytes++
+		v := scan.step(scan, c)
+		if v == scanSkipSpace {
+			continue
+		}
+		if v == scanError {
+			break
+		}
+		if needIndent && v != scanEndObject && v != scanEndArray {
+			needIndent = false
+			depth++
+			newline(depth)
+		}
+		if v == scanContinue {
+			add(1)
+			continue
+		}
+		switch c {
+		case '{', '[':
+			needIndent = true
+		case ',':
+			newline(depth)
+		case ':':
+			add(1) // space
+		case '}', ']':
+			if needIndent {
+				needIndent = false
+			} else {
+				depth--
+				newline(depth)
+			}
+		}
+		add(1)
+	}
+	if scan.eof() == scanError {
+		return 0, scan.err
+	}
+	return n, nil
+}
+
+// quotedLen returns the length of the output of appendString for s.
+func quotedLen(s string, escapeHTML bool) int64 {
+	n := int64(len(`""`))
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			switch {
+			case safeChar(b, escapeHTML):
+				n++
+			case b == '\\' || b == '"' || b == '\b' || b == '\f' || b == '\n' || b == '\r' || b == '\t':
+				n += 2
+			default:
+				n += int64(len(`\u0000`))
+			}
+			i++
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if (c == utf8.RuneError && size == 1) || c == '\u2028' || c == '\u2029' {
+			n += int64(len(`\ufffd`))
+		} else {
+			n += int64(size)
+		}
+		i += size
+	}
+	return n
+}
@@ -0,0 +1,46 @@
+package json
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputLen(t *testing.T) {
+	inputs := []string{
+		`null`, ` false `, `"é😀\n<&>"`, "\"  \"", `[]`, `{}`,
+		`[1, 2, {"a": [true, null]}]`, "{\"a\":{\"b\":\"c\"},\"d\":[]}\n",
+	}
+	for _, input := range inputs {
+		for _, escape := range []bool{false, true} {
+			out, err := appendCompact(nil, []byte(input), escape)
+			assert.Nil(t, err, input)
+			assert.LessOrEqual(t, int64(len(out)), compactLen([]byte(input), escape), input)
+		}
+
+		out, err := appendIndent(nil, []byte(input), ">", "\t\t")
+		assert.Nil(t, err, input)
+		n, err := indentLen([]byte(input), ">", "\t\t")
+		assert.Nil(t, err, input)
+		assert.Equal(t, int64(len(out)), n, input)
+	}
+
+	_, err := indentLen([]byte(`[1,]`), "", " ")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid character ']' looking for beginning of value", err.msg)
+	}
+
+	// The length of nested input with a long indent is computed without
+	// building the output, which would take terabytes.
+	deep := []byte(strings.Repeat("[", 5000) + strings.Repeat("]", 5000))
+	n, err := indentLen(deep, "", strings.Repeat(" ", 1<<17))
+	assert.Nil(t, err)
+	assert.Greater(t, n, int64(1<<40))
+
+	for _, s := range []string{"", "abc", "é😀\n<&>\"\\\x01\x7f", "  ", "\xff"} {
+		for _, escape := range []bool{false, true} {
+			assert.Equal(t, int64(len(appendString(nil, s, escape))), quotedLen(s, escape), s)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The expected values are those of Go's encoding/json as of go1.24, which the
+// natives are pinned to.
+
+func TestCheckValid(t *testing.T) {
+	for _, input := range []string{
+		`null`, `true`, ` false `, `-12.5e+3`, `0`, `"str"`, `"é😀\n<&>"`,
+		`[]`, `{}`, `[1, 2, {"a": [true, null]}]`, "{\"a\":{\"b\":\"c\"},\"d\":[]}\n",
+	} {
+		assert.Nil(t, checkValid([]byte(input)), input)
+	}
+
+	cases := []struct {
+		input  string
+		msg    string
+		offset int64
+	}{
+		{"", "unexpected end of JSON input", 0},
+		{" ", "unexpected end of JSON input", 1},
+		{"[1,]", "invalid character ']' looking for beginning of value", 4},
+		{"{\"a\" 1}", "invalid character '1' after object key", 6},
+		{"{\"a\":1,}", "invalid character '}' looking for beginning of object key string", 8},
+		{"01", "invalid character '1' after top-level value", 2},
+		{"1.", "invalid character ' ' after decimal point in numeric literal", 2},
+		{"1e", "invalid character ' ' in exponent of numeric literal", 2},
+		{"-", "invalid character ' ' in numeric literal", 1},
+		{"tru", "invalid character ' ' in literal true (expecting 'e')", 3},
+		{"nul", "invalid character ' ' in literal null (expecting 'l')", 3},
+		{"\"\\x\"", "invalid character 'x' in string escape code", 3},
+		{"\"a\tb\"", "invalid character '\\t' in string literal", 3},
+		{"[1] 2", "invalid character '2' after top-level value", 5},
+		{"\"unterminated", "unexpected end of JSON input", 13},
+		{"{\"a\":1}}", "invalid character '}' after top-level value", 8},
+		{"\"\\u12\"", "invalid character '\"' in \\u hexadecimal character escape", 6},
+	}
+	for _, tc := range cases {
+		err := checkValid([]byte(tc.input))
+		if assert.NotNil(t, err, tc.input) {
+			assert.Equal(t, tc.msg, err.msg, tc.input)
+			assert.Equal(t, tc.offset, err.Offset, tc.input)
+		}
+	}
+}
+
+func TestAppendCompactIndent(t *testing.T) {
+	const input = " {\"a\": [1, \"<b>\", {}], \"c\": {\"d\": null}, \"e\": []} \n"
+
+	out, err := appendCompact([]byte("x"), []byte(input), false)
+	assert.Nil(t, err)
+	assert.Equal(t, `x{"a":[1,"<b>",{}],"c":{"d":null},"e":[]}`, string(out))
+
+	out, err = appendCompact(nil, []byte(input), true)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":[1,"\u003cb\u003e",{}],"c":{"d":null},"e":[]}`, string(out))
+
+	out, err = appendIndent(nil, []byte(input), "> ", "\t")
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n> \t\"a\": [\n> \t\t1,\n> \t\t\"<b>\",\n> \t\t{}\n> \t],\n> \t\"c\": {\n> \t\t\"d\": null\n> \t},\n> \t\"e\": []\n> } \n", string(out))
+
+	out, err = appendIndent([]byte("x"), []byte(`[1,]`), "", "  ")
+	assert.Equal(t, "x", string(out))
+	assert.Equal(t, &syntaxError{"invalid character ']' looking for beginning of value", 4}, err)
+}
+
+func TestAppendStringUnquote(t *testing.T) {
+	cases := []struct {
+		s          string
+		quoted     string
+		quotedHTML string
+	}{
+		{"", `""`, `""`},
+		{"a\"b\\c/", `"a\"b\\c/"`, `"a\"b\\c/"`},
+		{"\x00\x1f\b\f\n\r\t", `"\u0000\u001f\b\f\n\r\t"`, `"\u0000\u001f\b\f\n\r\t"`},
+		{"<a & b>", `"<a & b>"`, `"\u003ca \u0026 b\u003e"`},
+		{"é😀\u2028", `"é😀\u2028"`, `"é😀\u2028"`},
+		{"a\xffb", `"a\ufffdb"`, `"a\ufffdb"`},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.quoted, string(appendString(nil, tc.s, false)), tc.s)
+		assert.Equal(t, tc.quotedHTML, string(appendString(nil, tc.s, true)), tc.s)
+
+		s, ok := unquote([]byte(tc.quotedHTML))
+		assert.True(t, ok, tc.quotedHTML)
+		if tc.s != "a\xffb" {
+			assert.Equal(t, tc.s, s, tc.quotedHTML)
+		}
+	}
+
+	s, ok := unquote([]byte(`"\ud83d\ude00 \ud800"`))
+	assert.True(t, ok)
+	assert.Equal(t, "😀 \ufffd", s)
+
+	for _, invalid := range []string{`abc`, `"abc`, `"a"b"`, `"\x"`, "\"\n\""} {
+		_, ok := unquote([]byte(invalid))
+		assert.False(t, ok, invalid)
+	}
+}
@@ -0,0 +1,194 @@
+package big
+
+import (
+	"math"
+	"math/big"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/overflow"
+)
+
+// CPU cycles charged by the natives. Each call has a base cost, covering the
+// conversion from and to the Gno representation, plus a cost per 64-bit limb
+// of the operands for linear operations, or per product of limb counts for
+// quadratic operations like multiplication and division.
+const (
+	cpuBase          = 200
+	cpuPerLimb       = 8
+	cpuPerLimbSquare = 2
+)
+
+// limbs returns the number of 64-bit limbs needed to represent abs.
+func limbs(abs []byte) int64 {
+	return (int64(len(abs)) + 7) / 8
+}
+
+// charge charges the base cost, the linear cost for the given limb count and
+// the quadratic cost for the given limb product. Costs which overflow are
+// saturated, so that they exhaust any gas limit.
+func charge(m *gno.Machine, linear, square int64) {
+	cost := satAdd(satMul(square, cpuPerLimbSquare), satMul(linear, cpuPerLimb))
+	m.IncrCPU(satAdd(cost, cpuBase))
+}
+
+// satMul returns a*b for non-negative a and b, saturated to math.MaxInt64.
+func satMul(a, b int64) int64 {
+	c, ok := overflow.Mul(a, b)
+	if !ok {
+		return math.MaxInt64
+	}
+	return c
+}
+
+// satAdd returns a+b for non-negative a and b, saturated to math.MaxInt64.
+func satAdd(a, b int64) int64 {
+	c, ok := overflow.Add(a, b)
+	if !ok {
+		return math.MaxInt64
+	}
+	return c
+}
+
+func toInt(neg bool, abs []byte) *big.Int {
+	z := new(big.Int).SetBytes(abs)
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
+func fromInt(z *big.Int) (neg bool, abs []byte) {
+	return z.Sign() < 0, z.Bytes()
+}
+
+func X_intAdd(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	charge(m, limbs(x)+limbs(y), 0)
+	return fromInt(new(big.Int).Add(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intSub(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	charge(m, limbs(x)+limbs(y), 0)
+	return fromInt(new(big.Int).Sub(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intMul(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	charge(m, limbs(x)+limbs(y), limbs(x)*limbs(y))
+	return fromInt(new(big.Int).Mul(toInt(xneg, x), toInt(yneg, y)))
+}
+
+// X_intQuoRem implements truncated division if euclid is false, and
+// Euclidean division otherwise. y must not be zero.
+func X_intQuoRem(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte, euclid bool) (qneg bool, q []byte, rneg bool, r []byte) {
+	charge(m, limbs(x)+limbs(y), max(limbs(x)-limbs(y)+1, 1)*limbs(y))
+	bq, br := new(big.Int), new(big.Int)
+	if euclid {
+		bq.DivMod(toInt(xneg, x), toInt(yneg, y), br)
+	} else {
+		bq.QuoRem(toInt(xneg, x), toInt(yneg, y), br)
+	}
+	qneg, q = fromInt(bq)
+	rneg, r = fromInt(br)
+	return
+}
+
+// X_intExp returns x**y mod m, or x**y if m is zero. It returns false if y is
+// negative and x has no inverse modulo m.
+func X_intExp(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte, mod []byte) (bool, []byte, bool) {
+	bx, by := toInt(xneg, x), toInt(yneg, y)
+	var bm *big.Int
+	if len(mod) > 0 {
+		// Each bit of the exponent costs a modular multiplication.
+		sq := satMul(int64(by.BitLen())+limbs(x), satMul(limbs(mod), limbs(mod)))
+		charge(m, limbs(x)+limbs(y)+limbs(mod), sq)
+		bm = new(big.Int).SetBytes(mod)
+	} else {
+		// The cost is dominated by squaring the result, whose size is
+		// bounded by the caller.
+		n := int64(0)
+		if !yneg && len(y) <= 8 && bx.BitLen() > 1 {
+			n = satAdd(satMul(int64(bx.BitLen()), by.Int64()), 63) / 64
+		}
+		charge(m, satAdd(limbs(x)+limbs(y), n), satMul(n, n))
+	}
+	z := new(big.Int).Exp(bx, by, bm)
+	if z == nil {
+		return false, nil, false
+	}
+	neg, abs := fromInt(z)
+	return neg, abs, true
+}
+
+func X_intSqrt(m *gno.Machine, x []byte) []byte {
+	charge(m, limbs(x), limbs(x)*limbs(x))
+	return new(big.Int).Sqrt(new(big.Int).SetBytes(x)).Bytes()
+}
+
+func X_intLsh(m *gno.Machine, xneg bool, x []byte, n uint) (bool, []byte) {
+	charge(m, limbs(x)+int64(n/64), 0)
+	return fromInt(new(big.Int).Lsh(toInt(xneg, x), n))
+}
+
+func X_intRsh(m *gno.Machine, xneg bool, x []byte, n uint) (bool, []byte) {
+	charge(m, limbs(x), 0)
+	return fromInt(new(big.Int).Rsh(toInt(xneg, x), n))
+}
+
+// X_intBitwise implements the bitwise operations on the two's complement
+// representations of x and y. op is one of '&', '|', '^' or '~' for and not.
+func X_intBitwise(m *gno.Machine, op int, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	charge(m, limbs(x)+limbs(y), 0)
+	bx, by, z := toInt(xneg, x), toInt(yneg, y), new(big.Int)
+	switch op {
+	case '&':
+		z.And(bx, by)
+	case '|':
+		z.Or(bx, by)
+	case '^':
+		z.Xor(bx, by)
+	case '~':
+		z.AndNot(bx, by)
+	default:
+		panic("invalid bitwise operation")
+	}
+	return fromInt(z)
+}
+
+// X_intGCD returns the greatest common divisor d of a and b, along with x and
+// y such that d = a*x + b*y.
+func X_intGCD(m *gno.Machine, aneg bool, a []byte, bneg bool, b []byte) (d []byte, xneg bool, x []byte, yneg bool, y []byte) {
+	n := limbs(a) + limbs(b)
+	charge(m, n, n*n)
+	bx, by := new(big.Int), new(big.Int)
+	d = new(big.Int).GCD(bx, by, toInt(aneg, a), toInt(bneg, b)).Bytes()
+	xneg, x = fromInt(bx)
+	yneg, y = fromInt(by)
+	return
+}
+
+// X_intModInverse returns the inverse of g modulo n, or false if it doesn't
+// exist. n must not be zero.
+func X_intModInverse(m *gno.Machine, gneg bool, g []byte, nneg bool, n []byte) ([]byte, bool) {
+	l := limbs(g) + limbs(n)
+	charge(m, l, l*l)
+	z := new(big.Int).ModInverse(toInt(gneg, g), toInt(nneg, n))
+	if z == nil {
+		return nil, false
+	}
+	return z.Bytes(), true
+}
+
+func X_intText(m *gno.Machine, neg bool, abs []byte, base int) string {
+	charge(m, limbs(abs), limbs(abs)*limbs(abs))
+	return toInt(neg, abs).Text(base)
+}
+
+func X_intSetString(m *gno.Machine, s string, base int) (neg bool, abs []byte, ok bool) {
+	n := (int64(len(s)) + 15) / 16 // at most 16 hexadecimal digits per limb
+	charge(m, n, n*n)
+	z, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return false, nil, false
+	}
+	neg, abs = fromInt(z)
+	return neg, abs, true
+}
@@ -0,0 +1,31 @@
+package big
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+func TestChargeSaturates(t *testing.T) {
+	m := &gno.Machine{}
+	charge(m, 1, math.MaxInt64/2)
+	assert.Equal(t, int64(math.MaxInt64), m.Cycles)
+
+	m = &gno.Machine{}
+	charge(m, math.MaxInt64/4, 0)
+	assert.Equal(t, int64(math.MaxInt64), m.Cycles)
+}
+
+func TestIntExpChargeSaturates(t *testing.T) {
+	// The size of 3**(2**62) overflows: its cost must exhaust the gas
+	// before the exponentiation starts, rather than wrap around.
+	m := &gno.Machine{GasMeter: types.NewGasMeter(10_000_000)}
+	y := []byte{0x40, 0, 0, 0, 0, 0, 0, 0}
+	assert.PanicsWithValue(t, types.OutOfGasError{Descriptor: "CPUCycles"}, func() {
+		X_intExp(m, false, []byte{3}, false, y, nil)
+	})
+}
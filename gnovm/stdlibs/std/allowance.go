@@ -0,0 +1,21 @@
+package std
+
+import (
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// FeeAllowancesInterface is the interface through which Gno is capable of
+// granting fee allowances, which let the granter pay for the fees of the
+// transactions of the grantee. It is used by "chain/allowance".
+//
+// A zero expiration means that the allowance never expires, and an empty
+// spend limit that it is unlimited. The errors of GrantFeeAllowance and
+// RevokeFeeAllowance are raised as panics in the calling realm.
+type FeeAllowancesInterface interface {
+	GetFeeAllowance(granter, grantee crypto.Bech32Address) (spendLimit std.Coins, expiration time.Time, ok bool)
+	GrantFeeAllowance(granter, grantee crypto.Bech32Address, spendLimit std.Coins, expiration time.Time) error
+	RevokeFeeAllowance(granter, grantee crypto.Bech32Address) error
+}
@@ -18,6 +18,7 @@ type ExecContext struct {
 	OriginSendSpent *std.Coins // mutable
 	Banker          BankerInterface
 	Params          ParamsInterface
+	FeeAllowances   FeeAllowancesInterface
 	EventLogger     *sdk.EventLogger
 }
 
@@ -9,6 +9,7 @@ import (
 
 // std.SetParam*() can only be used to set realm-local VM parameters.  All
 // parameters stored in ExecContext.Params will be prefixed by "vm:<realm>:".
+// std.GetParam*() can read any parameter, with a full key.
 // TODO rename to SetRealmParam*().
 
 type ParamsInterface interface {
@@ -18,6 +19,15 @@ type ParamsInterface interface {
 	SetUint64(key string, val uint64)
 	SetBytes(key string, val []byte)
 	SetStrings(key string, val []string)
+
+	// The getters set *ptr and return true if the parameter exists, or an
+	// error if it has another type.
+	GetString(key string, ptr *string) (bool, error)
+	GetBool(key string, ptr *bool) (bool, error)
+	GetInt64(key string, ptr *int64) (bool, error)
+	GetUint64(key string, ptr *uint64) (bool, error)
+	GetBytes(key string, ptr *[]byte) (bool, error)
+	GetStrings(key string, ptr *[]string) (bool, error)
 }
 
 func X_setParamString(m *gno.Machine, key, val string) {
@@ -63,3 +73,76 @@ func pkey(m *gno.Machine, key string) string {
 	_, rlmPath := currentRealm(m)
 	return fmt.Sprintf("vm:%s:%s", rlmPath, key)
 }
+
+// getParamKey returns the key of a realm-local parameter like pkey, unless key
+// is a full "<module>:<submodule>:<name>" key, which is returned as is: any
+// realm can read any parameter.
+func getParamKey(m *gno.Machine, key string) string {
+	if !strings.Contains(key, ":") {
+		return pkey(m, key)
+	}
+	parts := strings.Split(key, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		m.Panic(typedString("invalid param key: " + key))
+		return ""
+	}
+	return key
+}
+
+// CPU cycles charged per parameter read and per byte of its key and value,
+// like the gas of a store read.
+const (
+	cpuGetParamBase    = 1000
+	cpuGetParamPerByte = 3
+)
+
+// getParam reads the parameter of the given key with read, charging the gas
+// of the read, and panics if it has another type. size returns the size of
+// its value in bytes.
+func getParam[T any](
+	m *gno.Machine,
+	key string,
+	read func(ParamsInterface, string, *T) (bool, error),
+	size func(T) int,
+) (T, bool) {
+	pk := getParamKey(m, key)
+	m.IncrCPU(cpuGetParamBase + cpuGetParamPerByte*int64(len(pk)))
+
+	var val T
+	found, err := read(GetContext(m).Params, pk, &val)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+	}
+	m.IncrCPU(cpuGetParamPerByte * int64(size(val)))
+	return val, found
+}
+
+func X_getParamString(m *gno.Machine, key string) (string, bool) {
+	return getParam(m, key, ParamsInterface.GetString, func(v string) int { return len(v) })
+}
+
+func X_getParamBool(m *gno.Machine, key string) (bool, bool) {
+	return getParam(m, key, ParamsInterface.GetBool, func(bool) int { return 1 })
+}
+
+func X_getParamInt64(m *gno.Machine, key string) (int64, bool) {
+	return getParam(m, key, ParamsInterface.GetInt64, func(int64) int { return 8 })
+}
+
+func X_getParamUint64(m *gno.Machine, key string) (uint64, bool) {
+	return getParam(m, key, ParamsInterface.GetUint64, func(uint64) int { return 8 })
+}
+
+func X_getParamBytes(m *gno.Machine, key string) ([]byte, bool) {
+	return getParam(m, key, ParamsInterface.GetBytes, func(v []byte) int { return len(v) })
+}
+
+func X_getParamStrings(m *gno.Machine, key string) ([]string, bool) {
+	return getParam(m, key, ParamsInterface.GetStrings, func(v []string) int {
+		n := 0
+		for _, s := range v {
+			n += len(s)
+		}
+		return n
+	})
+}
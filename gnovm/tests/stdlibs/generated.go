@@ -750,6 +750,38 @@ var nativeFuncs = [...]NativeFunc{
 			))
 		},
 	},
+	{
+		"testing/base",
+		"benchVMStats",
+		[]gno.FieldTypeExpr{},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("r2"), Type: gno.X("int64")},
+		},
+		true,
+		func(m *gno.Machine) {
+			r0, r1, r2 := testlibs_testing_base.X_benchVMStats(
+				m,
+			)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r2).Elem(),
+			))
+		},
+	},
 	{
 		"unicode",
 		"IsPrint",
@@ -26,3 +26,14 @@ func X_recoverWithStacktrace(m *gnolang.Machine) (gnolang.TypedValue, string) {
 	}
 	return exception.Value, exception.Stacktrace.String()
 }
+
+func X_benchVMStats(m *gnolang.Machine) (int64, int64, int64) {
+	var gas, allocs int64
+	if m.GasMeter != nil {
+		gas = m.GasMeter.GasConsumed()
+	}
+	if m.Alloc != nil {
+		_, allocs = m.Alloc.Status()
+	}
+	return m.Cycles, gas, allocs
+}
@@ -178,6 +178,8 @@ type ResponseCheckTx struct {
 	ResponseBase
 	GasWanted int64 // nondeterministic
 	GasUsed   int64
+	Priority  int64  // nondeterministic, used by the mempool to order txs
+	Sender    string // nondeterministic, used by the mempool to keep the txs of a sender in order
 }
 
 type ResponseDeliverTx struct {
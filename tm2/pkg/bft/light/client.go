@@ -0,0 +1,354 @@
+// Package light implements a light client, which verifies block headers
+// fetched from untrusted RPC servers, starting from a trusted header.
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// RPCClient is the subset of the RPC client used by the light client.
+type RPCClient interface {
+	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	Validators(ctx context.Context, height *int64) (*ctypes.ResultValidators, error)
+}
+
+// ErrHeaderExpired is returned when verifying a header from a verified header
+// older than the trusting period.
+var ErrHeaderExpired = errors.New("verified header expired")
+
+// TrustOptions are the root of trust of the light client: a block known to
+// be part of the chain, usually obtained out of band.
+type TrustOptions struct {
+	// Period is the trusting period: how long after its time a verified
+	// header can be used to verify other headers. It should be shorter than
+	// the time a misbehaving validator remains accountable, for instance the
+	// unbonding period of a proof of stake chain.
+	Period time.Duration
+
+	Height int64
+	Hash   []byte
+}
+
+// ValidateBasic performs basic validation of the trust options.
+func (o TrustOptions) ValidateBasic() error {
+	if o.Period <= 0 {
+		return fmt.Errorf("invalid trusting period %s", o.Period)
+	}
+	if o.Height <= 0 {
+		return fmt.Errorf("invalid trusted height %d", o.Height)
+	}
+	if len(o.Hash) == 0 {
+		return errors.New("trusted hash is empty")
+	}
+	return nil
+}
+
+// VerifiedHeader is a signed header whose hash is trusted, along with its
+// validator set and the validator set designated by its NextValidatorsHash.
+type VerifiedHeader struct {
+	*types.SignedHeader
+	Validators     *types.ValidatorSet
+	NextValidators *types.ValidatorSet
+}
+
+// Client is a light client. It verifies the headers fetched from its RPC
+// clients from the trusted header, by checking that enough of the trusted
+// validators signed them. Only the verified headers within the trusting period
+// are kept and used to verify other headers; once they all expired, a new
+// client must be created from a recent trusted block.
+//
+// Adjacent headers are verified with the validator set designated by the
+// previous header. Other headers are verified by skipping verification: they
+// are trusted if more than 2/3 of the voting power of the trusted validators
+// signed them. When the validator set changed too much for that, the header
+// halfway is verified first (bisection).
+type Client struct {
+	chainID string
+	trust   TrustOptions
+	clients []RPCClient
+	now     func() time.Time // current time, for the trusting period
+
+	mtx      sync.Mutex
+	root     *VerifiedHeader           // header at the trusted height
+	verified map[int64]*VerifiedHeader // headers verified from the root, not expired
+	heights  []int64                   // heights of the verified headers, sorted
+	latest   *VerifiedHeader
+}
+
+// NewClient returns a light client for the given chain, which fetches
+// headers from the given RPC clients, trying them in turn, and trusts the
+// block described by the trust options.
+func NewClient(chainID string, trust TrustOptions, clients ...RPCClient) (*Client, error) {
+	if chainID == "" {
+		return nil, errors.New("chain ID is empty")
+	}
+	if err := trust.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, errors.New("at least one RPC client is required")
+	}
+	return &Client{
+		chainID:  chainID,
+		trust:    trust,
+		clients:  clients,
+		now:      time.Now,
+		verified: make(map[int64]*VerifiedHeader),
+	}, nil
+}
+
+// NewHTTPClient returns a light client fetching its headers from the given
+// RPC servers.
+func NewHTTPClient(chainID string, trust TrustOptions, servers ...string) (*Client, error) {
+	clients := make([]RPCClient, 0, len(servers))
+	for _, server := range servers {
+		client, err := rpcclient.NewHTTPClient(server)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create RPC client for %q: %w", server, err)
+		}
+		clients = append(clients, client)
+	}
+	return NewClient(chainID, trust, clients...)
+}
+
+// ChainID returns the ID of the chain verified by the light client.
+func (c *Client) ChainID() string {
+	return c.chainID
+}
+
+// VerifyHeight returns the verified header at the given height, which can't
+// be lower than the trusted height.
+func (c *Client) VerifyHeight(ctx context.Context, height int64) (*VerifiedHeader, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.prune()
+	trusted, err := c.trusted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height < trusted.Height {
+		return nil, fmt.Errorf("height %d is lower than the trusted height %d", height, trusted.Height)
+	}
+	return c.verify(ctx, c.closest(trusted, height), height)
+}
+
+// VerifyLatest returns the verified header of the latest block of the RPC
+// clients.
+func (c *Client) VerifyLatest(ctx context.Context) (*VerifiedHeader, error) {
+	var height int64
+	err := c.query(func(rc RPCClient) error {
+		res, err := rc.Commit(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if res.SignedHeader.Header == nil {
+			return errors.New("latest commit has no header")
+		}
+		height = res.Height
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := c.VerifyHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.latest == nil || header.Height > c.latest.Height {
+		c.latest = header
+	}
+	return c.latest, nil
+}
+
+// trusted returns the header at the trusted height, checked against the
+// trusted hash.
+func (c *Client) trusted(ctx context.Context) (*VerifiedHeader, error) {
+	if c.root != nil {
+		return c.root, nil
+	}
+
+	header, err := c.fetch(ctx, c.trust.Height)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header.Hash(), c.trust.Hash) {
+		return nil, fmt.Errorf("header at trusted height %d has hash %X, expected %X",
+			c.trust.Height, header.Hash(), c.trust.Hash)
+	}
+	c.root = header
+	return header, nil
+}
+
+// closest returns the highest verified header at or below the given height,
+// from which to verify it.
+func (c *Client) closest(trusted *VerifiedHeader, height int64) *VerifiedHeader {
+	i, found := slices.BinarySearch(c.heights, height)
+	if !found {
+		i--
+	}
+	if i < 0 || c.heights[i] <= trusted.Height {
+		return trusted
+	}
+	return c.verified[c.heights[i]]
+}
+
+// add adds a verified header.
+func (c *Client) add(header *VerifiedHeader) {
+	i, found := slices.BinarySearch(c.heights, header.Height)
+	if !found {
+		c.heights = slices.Insert(c.heights, i, header.Height)
+	}
+	c.verified[header.Height] = header
+}
+
+// prune drops the verified headers whose trusting period expired. As the time
+// of the headers increases with their height, they are the lowest ones.
+func (c *Client) prune() {
+	n := 0
+	for n < len(c.heights) && c.expired(c.verified[c.heights[n]]) {
+		delete(c.verified, c.heights[n])
+		n++
+	}
+	c.heights = slices.Delete(c.heights, 0, n)
+}
+
+// expired reports whether the trusting period of a verified header is over.
+func (c *Client) expired(header *VerifiedHeader) bool {
+	return !c.now().Before(header.Time.Add(c.trust.Period))
+}
+
+// verify verifies the header at the given height from a trusted header at a
+// lower height, which must be within the trusting period. When the trusted
+// validators did not sign the header, as their set changed too much, the
+// headers in between are verified first.
+func (c *Client) verify(ctx context.Context, trusted *VerifiedHeader, height int64) (*VerifiedHeader, error) {
+	if height == trusted.Height {
+		return trusted, nil
+	}
+	if header, ok := c.verified[height]; ok {
+		return header, nil
+	}
+
+	if c.expired(trusted) {
+		return nil, fmt.Errorf("%w: header at height %d expired at %s",
+			ErrHeaderExpired, trusted.Height, trusted.Time.Add(c.trust.Period))
+	}
+
+	header, err := c.fetch(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := header.Commit
+	if height == trusted.Height+1 {
+		if !bytes.Equal(header.ValidatorsHash, trusted.NextValidatorsHash) {
+			return nil, fmt.Errorf("header at height %d does not have the validators of the trusted header", height)
+		}
+		err = header.Validators.VerifyCommit(c.chainID, commit.BlockID, height, commit)
+	} else {
+		err = trusted.NextValidators.VerifyFutureCommit(header.Validators, c.chainID, commit.BlockID, height, commit)
+		if types.IsErrTooMuchChange(err) {
+			mid := trusted.Height + (height-trusted.Height)/2
+			midHeader, err := c.verify(ctx, trusted, mid)
+			if err != nil {
+				return nil, err
+			}
+			return c.verify(ctx, midHeader, height)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify header at height %d: %w", height, err)
+	}
+
+	c.add(header)
+	return header, nil
+}
+
+// fetch fetches the signed header at the given height, and its validator
+// sets, which are checked against the header.
+func (c *Client) fetch(ctx context.Context, height int64) (*VerifiedHeader, error) {
+	var sh types.SignedHeader
+	err := c.query(func(rc RPCClient) error {
+		res, err := rc.Commit(ctx, &height)
+		if err != nil {
+			return err
+		}
+		sh = res.SignedHeader
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := sh.ValidateBasic(c.chainID); err != nil {
+		return nil, fmt.Errorf("invalid header at height %d: %w", height, err)
+	}
+	if sh.Height != height {
+		return nil, fmt.Errorf("expected header at height %d, got %d", height, sh.Height)
+	}
+
+	vals, err := c.validators(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vals.Hash(), sh.ValidatorsHash) {
+		return nil, fmt.Errorf("validators at height %d do not match the header", height)
+	}
+	nextVals, err := c.validators(ctx, height+1)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(nextVals.Hash(), sh.NextValidatorsHash) {
+		return nil, fmt.Errorf("next validators at height %d do not match the header", height)
+	}
+	return &VerifiedHeader{SignedHeader: &sh, Validators: vals, NextValidators: nextVals}, nil
+}
+
+// validators fetches the validator set at the given height. The validators
+// are kept as is, with the proposer priorities of the height.
+func (c *Client) validators(ctx context.Context, height int64) (*types.ValidatorSet, error) {
+	var vals []*types.Validator
+	err := c.query(func(rc RPCClient) error {
+		res, err := rc.Validators(ctx, &height)
+		if err != nil {
+			return err
+		}
+		vals = res.Validators
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, fmt.Errorf("no validators at height %d", height)
+	}
+	return &types.ValidatorSet{Validators: vals}, nil
+}
+
+// query calls fn with each client, until one succeeds.
+func (c *Client) query(fn func(RPCClient) error) error {
+	var errs []error
+	for _, rc := range c.clients {
+		err := fn(rc)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("all RPC servers failed: %w", errors.Join(errs...))
+}
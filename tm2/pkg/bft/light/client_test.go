@@ -0,0 +1,293 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+const (
+	testChainID     = "light-test"
+	testTrustPeriod = time.Hour
+)
+
+// testChain is a chain of signed blocks, served by its RPC client.
+type testChain struct {
+	height  int64
+	blocks  map[int64]*types.Block
+	commits map[int64]*types.Commit
+	vals    map[int64]*types.ValidatorSet
+}
+
+// makeTestChain makes a chain of the given height, whose validator set is
+// entirely replaced at the given height.
+func makeTestChain(t *testing.T, height, changeAt int64) *testChain {
+	t.Helper()
+
+	c := &testChain{
+		height:  height,
+		blocks:  make(map[int64]*types.Block),
+		commits: make(map[int64]*types.Commit),
+		vals:    make(map[int64]*types.ValidatorSet),
+	}
+
+	valsA, privsA := types.RandValidatorSet(4, 10)
+	valsB, privsB := types.RandValidatorSet(4, 10)
+	valsAt := func(h int64) (*types.ValidatorSet, []types.PrivValidator) {
+		if h < changeAt {
+			return valsA, privsA
+		}
+		return valsB, privsB
+	}
+
+	// The blocks are a second apart, the last one being a second ago.
+	start := time.Now().UTC().Truncate(time.Second).Add(-time.Duration(height+1) * time.Second)
+
+	params := types.DefaultConsensusParams()
+	var (
+		lastBlockID types.BlockID
+		lastCommit  = &types.Commit{}
+	)
+	for h := int64(1); h <= height+1; h++ {
+		vals, privs := valsAt(h)
+		nextVals, _ := valsAt(h + 1)
+		c.vals[h] = vals
+
+		block := types.MakeBlock(h, nil, lastCommit)
+		block.Header.Populate(
+			testChainID, start.Add(time.Duration(h)*time.Second), lastBlockID, 0, "",
+			vals.Hash(), nextVals.Hash(),
+			params.Hash(), fmt.Appendf(nil, "apphash%d", h), nil,
+			vals.Validators[0].Address,
+		)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+
+		voteSet := types.NewVoteSet(testChainID, h, 0, types.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, h, 0, voteSet, privs)
+		require.NoError(t, err)
+
+		c.blocks[h] = block
+		c.commits[h] = commit
+		lastBlockID, lastCommit = blockID, commit
+	}
+	return c
+}
+
+func (c *testChain) Commit(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	h := c.height
+	if height != nil {
+		h = *height
+	}
+	if h > c.height {
+		return nil, fmt.Errorf("no block at height %d", h)
+	}
+	block := c.blocks[h]
+	return &ctypes.ResultCommit{
+		SignedHeader:    types.SignedHeader{Header: &block.Header, Commit: c.commits[h]},
+		CanonicalCommit: true,
+	}, nil
+}
+
+func (c *testChain) Validators(_ context.Context, height *int64) (*ctypes.ResultValidators, error) {
+	vals, ok := c.vals[*height]
+	if !ok {
+		return nil, fmt.Errorf("no validators at height %d", *height)
+	}
+	return &ctypes.ResultValidators{BlockHeight: *height, Validators: vals.Copy().Validators}, nil
+}
+
+// failingClient is an RPC client whose calls all fail.
+type failingClient struct{}
+
+func (failingClient) Commit(context.Context, *int64) (*ctypes.ResultCommit, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (failingClient) Validators(context.Context, *int64) (*ctypes.ResultValidators, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	chain := makeTestChain(t, 3, 10)
+	trust := TrustOptions{Period: testTrustPeriod, Height: 1, Hash: chain.blocks[1].Hash()}
+
+	_, err := NewClient("", trust, chain)
+	assert.ErrorContains(t, err, "chain ID is empty")
+
+	_, err = NewClient(testChainID, TrustOptions{Height: 1, Hash: trust.Hash}, chain)
+	assert.ErrorContains(t, err, "invalid trusting period")
+
+	_, err = NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 0, Hash: trust.Hash}, chain)
+	assert.ErrorContains(t, err, "invalid trusted height")
+
+	_, err = NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 1}, chain)
+	assert.ErrorContains(t, err, "trusted hash is empty")
+
+	_, err = NewClient(testChainID, trust)
+	assert.ErrorContains(t, err, "at least one RPC client")
+
+	c, err := NewClient(testChainID, trust, chain)
+	require.NoError(t, err)
+	assert.Equal(t, testChainID, c.ChainID())
+}
+
+func TestClient_VerifyHeight(t *testing.T) {
+	t.Parallel()
+
+	chain := makeTestChain(t, 20, 10)
+	c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[2].Hash()}, chain)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// The validator set changed since the trusted height, so the headers in
+	// between must be verified.
+	header, err := c.VerifyHeight(ctx, 15)
+	require.NoError(t, err)
+	assert.Equal(t, chain.blocks[15].Hash(), header.Hash())
+	assert.Equal(t, chain.vals[15].Hash(), header.Validators.Hash())
+	assert.Equal(t, chain.vals[16].Hash(), header.NextValidators.Hash())
+
+	// Adjacent headers, and headers before the change.
+	for _, height := range []int64{2, 3, 5, 16} {
+		header, err := c.VerifyHeight(ctx, height)
+		require.NoError(t, err)
+		assert.Equal(t, chain.blocks[height].Hash(), header.Hash())
+	}
+
+	latest, err := c.VerifyLatest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), latest.Height)
+	assert.Equal(t, chain.blocks[20].Hash(), latest.Hash())
+}
+
+func TestClient_Failover(t *testing.T) {
+	t.Parallel()
+
+	chain := makeTestChain(t, 10, 5)
+	c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 1, Hash: chain.blocks[1].Hash()}, failingClient{}, chain)
+	require.NoError(t, err)
+
+	header, err := c.VerifyHeight(context.Background(), 8)
+	require.NoError(t, err)
+	assert.Equal(t, chain.blocks[8].Hash(), header.Hash())
+
+	c, err = NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 1, Hash: chain.blocks[1].Hash()}, failingClient{})
+	require.NoError(t, err)
+
+	_, err = c.VerifyLatest(context.Background())
+	assert.ErrorContains(t, err, "all RPC servers failed")
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wrong trusted hash", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[3].Hash()}, chain)
+		require.NoError(t, err)
+		_, err = c.VerifyHeight(context.Background(), 5)
+		assert.ErrorContains(t, err, "header at trusted height 2")
+	})
+
+	t.Run("below the trusted height", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 5, Hash: chain.blocks[5].Hash()}, chain)
+		require.NoError(t, err)
+		_, err = c.VerifyHeight(context.Background(), 3)
+		assert.ErrorContains(t, err, "lower than the trusted height")
+	})
+
+	t.Run("forged header", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[2].Hash()}, chain)
+		require.NoError(t, err)
+		chain.blocks[6].AppHash = []byte("forged")
+		_, err = c.VerifyHeight(context.Background(), 6)
+		assert.Error(t, err)
+	})
+
+	t.Run("validators of another chain", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		other := makeTestChain(t, 10, 20)
+		c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[2].Hash()}, chain)
+		require.NoError(t, err)
+
+		// A header signed by other validators, whose hashes match the
+		// served validator sets, is rejected.
+		chain.blocks[8], chain.commits[8], chain.vals[8], chain.vals[9] = other.blocks[8], other.commits[8], other.vals[8], other.vals[9]
+		_, err = c.VerifyHeight(context.Background(), 8)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired header", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[2].Hash()}, chain)
+		require.NoError(t, err)
+		_, err = c.VerifyHeight(context.Background(), 5)
+		require.NoError(t, err)
+
+		// Once the trusting period of the verified headers is over, they
+		// can't be used to verify the following ones.
+		c.now = func() time.Time { return chain.blocks[5].Time.Add(testTrustPeriod) }
+		_, err = c.VerifyHeight(context.Background(), 8)
+		assert.ErrorIs(t, err, ErrHeaderExpired)
+
+		// The expired headers are dropped.
+		_, err = c.VerifyHeight(context.Background(), 5)
+		assert.ErrorIs(t, err, ErrHeaderExpired)
+		assert.Empty(t, c.verified)
+		assert.Empty(t, c.heights)
+	})
+
+	t.Run("pruned headers", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		c, err := NewClient(testChainID, TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[2].Hash()}, chain)
+		require.NoError(t, err)
+		for _, height := range []int64{8, 5} {
+			_, err = c.VerifyHeight(context.Background(), height)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []int64{5, 8}, c.heights)
+
+		// Only the headers which didn't expire are kept, and used.
+		c.now = func() time.Time { return chain.blocks[5].Time.Add(testTrustPeriod) }
+		header, err := c.VerifyHeight(context.Background(), 9)
+		require.NoError(t, err)
+		assert.Equal(t, chain.blocks[9].Hash(), header.Hash())
+		assert.Equal(t, []int64{8, 9}, c.heights)
+		assert.Len(t, c.verified, 2)
+	})
+
+	t.Run("unknown chain", func(t *testing.T) {
+		t.Parallel()
+
+		chain := makeTestChain(t, 10, 20)
+		c, err := NewClient("other-chain", TrustOptions{Period: testTrustPeriod, Height: 2, Hash: chain.blocks[2].Hash()}, chain)
+		require.NoError(t, err)
+		_, err = c.VerifyHeight(context.Background(), 5)
+		assert.ErrorContains(t, err, "another chain")
+	})
+}
@@ -0,0 +1,42 @@
+package light
+
+import (
+	"errors"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/merkle"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+)
+
+// StoreKeyPath returns the key path of the given key of the given store, in
+// the proofs of the multistore.
+func StoreKeyPath(storeName string, key []byte) string {
+	var kp merkle.KeyPath
+	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(key, merkle.KeyEncodingHex)
+	return kp.String()
+}
+
+// VerifyValue verifies the proof, returned by a store query with the prove
+// flag, that the given key of the given store has the given value in the app
+// state with the given hash.
+//
+// The app state after the block at height H is committed has its hash in
+// the header of the block H+1.
+func VerifyValue(proof *merkle.Proof, appHash []byte, storeName string, key, value []byte) error {
+	if proof == nil {
+		return errors.New("missing proof")
+	}
+	prt := rootmulti.DefaultProofRuntime()
+	return prt.VerifyValue(proof, appHash, StoreKeyPath(storeName, key), value)
+}
+
+// VerifyAbsence verifies the proof, returned by a store query with the prove
+// flag, that the given key of the given store has no value in the app state
+// with the given hash.
+func VerifyAbsence(proof *merkle.Proof, appHash []byte, storeName string, key []byte) error {
+	if proof == nil {
+		return errors.New("missing proof")
+	}
+	prt := rootmulti.DefaultProofRuntime()
+	return prt.VerifyAbsence(proof, appHash, StoreKeyPath(storeName, key))
+}
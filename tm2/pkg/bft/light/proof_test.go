@@ -0,0 +1,59 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+func TestVerifyStoreProofs(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	mainKey := store.NewStoreKey("main")
+	otherKey := store.NewStoreKey("other")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, nil)
+	ms.MountStoreWithDB(otherKey, iavl.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	key, value := []byte{0x01, 'k', 0xff}, []byte("value")
+	ms.GetStore(mainKey).Set(key, value)
+	ms.GetStore(otherKey).Set([]byte("other"), []byte("other"))
+	appHash := ms.Commit().Hash
+
+	query := func(key []byte) abci.ResponseQuery {
+		t.Helper()
+
+		res := ms.(store.Queryable).Query(abci.RequestQuery{
+			Path:  "/main/key",
+			Data:  key,
+			Prove: true,
+		})
+		require.Nil(t, res.Error)
+		require.NotNil(t, res.Proof)
+		return res
+	}
+
+	res := query(key)
+	assert.Equal(t, value, res.Value)
+	assert.NoError(t, VerifyValue(res.Proof, appHash, "main", key, value))
+	assert.Error(t, VerifyValue(res.Proof, appHash, "main", key, []byte("forged")))
+	assert.Error(t, VerifyValue(res.Proof, appHash, "other", key, value))
+	assert.Error(t, VerifyValue(res.Proof, []byte("forged"), "main", key, value))
+	assert.Error(t, VerifyAbsence(res.Proof, appHash, "main", key))
+	assert.ErrorContains(t, VerifyValue(nil, appHash, "main", key, value), "missing proof")
+
+	missing := []byte("missing")
+	res = query(missing)
+	assert.Nil(t, res.Value)
+	assert.NoError(t, VerifyAbsence(res.Proof, appHash, "main", missing))
+	assert.Error(t, VerifyAbsence(res.Proof, appHash, "main", key))
+	assert.Error(t, VerifyValue(res.Proof, appHash, "main", missing, value))
+}
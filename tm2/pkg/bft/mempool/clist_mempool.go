@@ -31,8 +31,12 @@ import (
 // CheckTx abci message before the transaction is added to the pool. The
 // mempool uses a concurrent list structure for storing transactions that can
 // be efficiently accessed by multiple concurrent readers.
+//
+// Transactions are reaped in the order they were added, unless the mempool
+// type is [cfg.TypePriority], see priority_mempool.go.
 type CListMempool struct {
-	config *cfg.MempoolConfig
+	config      *cfg.MempoolConfig
+	prioritized bool // order txs by priority rather than by arrival
 
 	mtx          sync.Mutex
 	proxyAppConn appconn.Mempool
@@ -59,6 +63,10 @@ type CListMempool struct {
 	txsBytes   int64 // total size of mempool, in bytes
 	rechecking int32 // for re-checking filtered txs on Update()
 
+	// Number of txs of each sender, in a prioritized mempool.
+	senderTxsMtx sync.Mutex
+	senderTxs    map[string]int
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache txCache
@@ -95,6 +103,8 @@ func NewCListMempool(
 		recheckCursor: nil,
 		recheckEnd:    nil,
 		logger:        log.NewNoopLogger(),
+		prioritized:   config.Type == cfg.TypePriority,
+		senderTxs:     map[string]int{},
 	}
 	if config.CacheSize > 0 {
 		mempool.cache = newMapTxCache(config.CacheSize)
@@ -188,6 +198,7 @@ func (mem *CListMempool) Flush() {
 
 	mem.txsMap = sync.Map{}
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+	mem.resetSenderTxs()
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -225,9 +236,10 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(abci.Response), tx
 		txSize   = len(tx)
 	)
 
-	// Check max pending txs bytes
-	if memSize >= mem.config.Size ||
-		int64(txSize)+txsBytes > mem.config.MaxPendingTxsBytes {
+	// Check max pending txs bytes.
+	// A prioritized mempool may make room for the tx once its priority is known.
+	if (!mem.prioritized || int64(txSize) > mem.config.MaxPendingTxsBytes) &&
+		mem.isFull(txSize) {
 		return MempoolIsFullError{
 			memSize, mem.config.Size,
 			txsBytes, mem.config.MaxPendingTxsBytes,
@@ -320,7 +332,7 @@ func (mem *CListMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, res)
+		res = mem.resCbFirstTime(tx, peerID, res)
 
 		// Passed in by the caller of CheckTx, eg. the RPC.
 		// The external callback cannot modify the result.
@@ -336,6 +348,7 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(txKey(memTx.tx), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	mem.countSenderTx(memTx.sender, 1)
 
 	// Update the telemetry
 	mem.logTelemetry()
@@ -362,6 +375,7 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	elem.DetachPrev()
 	mem.txsMap.Delete(txKey(tx))
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	mem.countSenderTx(elem.Value.(*mempoolTx).sender, -1)
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
@@ -372,18 +386,30 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 }
 
 // callback, which is called after the app checked the tx for the first time.
+// It returns the response, with an error set if the tx was not added after
+// all because the mempool is full.
 //
 // The case where the app checks the tx for the second and subsequent times is
 // handled by the resCbRecheck callback.
-func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) {
+func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) abci.Response {
 	switch res := res.(type) {
 	case abci.ResponseCheckTx:
 		if res.Error == nil {
 			memTx := &mempoolTx{
 				height:    mem.height,
 				gasWanted: res.GasWanted,
+				priority:  res.Priority,
+				sender:    res.Sender,
 				tx:        tx,
 			}
+			if mem.prioritized {
+				if err := mem.admit(memTx); err != nil {
+					mem.logger.Info("Rejected prioritized transaction", "tx", txID(tx), "priority", memTx.priority, "err", err)
+					mem.cache.Remove(tx)
+					res.Error = abci.StringError(err.Error())
+					return res
+				}
+			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Info("Added good transaction",
@@ -399,8 +425,10 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Respo
 			// remove from cache (it might be good later)
 			mem.cache.Remove(tx)
 		}
+		return res
 	default:
 		// ignore other messages
+		return res
 	}
 }
 
@@ -484,21 +512,30 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Tx
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, min(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-		// Check total size requirement
-		if maxDataBytes > -1 && totalBytes+int64(len(memTx.tx)) > maxDataBytes {
-			return txs
+	// senders whose remaining txs are skipped, in a prioritized mempool
+	skipped := map[string]struct{}{}
+	for _, memTx := range mem.orderedTxs() {
+		if _, ok := skipped[memTx.sender]; ok {
+			continue
 		}
-		totalBytes += int64(len(memTx.tx))
-		// Check total gas requirement.
-		// If maxGas is negative, skip this check.
+		// Check total size and gas requirements.
+		// If maxDataBytes or maxGas is negative, skip the check.
 		// Since newTotalGas < masGas, which
 		// must be non-negative, it follows that this won't overflow.
 		newTotalGas := totalGas + memTx.gasWanted
-		if maxGas > -1 && newTotalGas > maxGas {
-			return txs
+		if (maxDataBytes > -1 && totalBytes+int64(len(memTx.tx)) > maxDataBytes) ||
+			(maxGas > -1 && newTotalGas > maxGas) {
+			if !mem.prioritized {
+				return txs
+			}
+			// Lower priority txs may still fit, but not the next txs of
+			// the same sender, whose sequence depends on this one.
+			if memTx.sender != "" {
+				skipped[memTx.sender] = struct{}{}
+			}
+			continue
 		}
+		totalBytes += int64(len(memTx.tx))
 		totalGas = newTotalGas
 		txs = append(txs, memTx.tx)
 	}
@@ -519,8 +556,10 @@ func (mem *CListMempool) ReapMaxTxs(maxVal int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, min(mem.txs.Len(), maxVal))
-	for e := mem.txs.Front(); e != nil && len(txs) <= maxVal; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.orderedTxs() {
+		if len(txs) > maxVal {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
@@ -626,6 +665,8 @@ func (mem *CListMempool) recheckTxs() {
 type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
+	priority  int64    // priority of this tx, as returned by the app
+	sender    string   // sender of this tx, as returned by the app
 	tx        types.Tx //
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
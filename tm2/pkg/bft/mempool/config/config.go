@@ -5,20 +5,32 @@ import "github.com/gnolang/gno/tm2/pkg/errors"
 // -----------------------------------------------------------------------------
 // MempoolConfig
 
+// Mempool types, selecting how transactions are ordered.
+const (
+	// TypeFIFO orders transactions by arrival.
+	TypeFIFO = "fifo"
+	// TypePriority orders transactions by gas price.
+	TypePriority = "priority"
+)
+
 // MempoolConfig defines the configuration options for the Tendermint mempool
 type MempoolConfig struct {
 	RootDir            string `json:"home" toml:"home"`
+	Type               string `json:"type" toml:"type" comment:"Mempool type, one of \"fifo\" (transactions are ordered by arrival) or \"priority\"\n (transactions are ordered by gas price, and the lowest priced ones are evicted when full)"`
 	Recheck            bool   `json:"recheck" toml:"recheck"`
 	Broadcast          bool   `json:"broadcast" toml:"broadcast"`
 	WalPath            string `json:"wal_dir" toml:"wal_dir"`
 	Size               int    `json:"size" toml:"size" comment:"Maximum number of transactions in the mempool"`
 	MaxPendingTxsBytes int64  `json:"max_pending_txs_bytes" toml:"max_pending_txs_bytes" comment:"Limit the total size of all txs in the mempool.\n This only accounts for raw transactions (e.g. given 1MB transactions and\n max_txs_bytes=5MB, mempool will only accept 5 transactions)."`
 	CacheSize          int    `json:"cache_size" toml:"cache_size" comment:"Size of the cache (used to filter transactions we saw earlier) in transactions"`
+	PriceBump          int    `json:"price_bump" toml:"price_bump" comment:"Minimum priority increase, in percent, of a transaction over the ones it evicts\n from a full \"priority\" mempool"`
+	MaxSenderTxs       int    `json:"max_sender_txs" toml:"max_sender_txs" comment:"Maximum number of transactions of a same sender in a \"priority\" mempool,\n or 0 for no limit"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
+		Type:      TypeFIFO,
 		Recheck:   true,
 		Broadcast: true,
 		WalPath:   "",
@@ -27,6 +39,8 @@ func DefaultMempoolConfig() *MempoolConfig {
 		Size:               5000,
 		MaxPendingTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:          10000,
+		PriceBump:          10,
+		MaxSenderTxs:       100,
 	}
 }
 
@@ -50,6 +64,11 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	switch cfg.Type {
+	case "", TypeFIFO, TypePriority:
+	default:
+		return errors.New("type must be one of %q or %q", TypeFIFO, TypePriority)
+	}
 	if cfg.Size < 0 {
 		return errors.New("size can't be negative")
 	}
@@ -59,5 +78,11 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.CacheSize < 0 {
 		return errors.New("cache_size can't be negative")
 	}
+	if cfg.PriceBump < 0 {
+		return errors.New("price_bump can't be negative")
+	}
+	if cfg.MaxSenderTxs < 0 {
+		return errors.New("max_sender_txs can't be negative")
+	}
 	return nil
 }
@@ -34,3 +34,14 @@ func (e MempoolIsFullError) Error() string {
 		e.numTxs, e.maxTxs,
 		e.txsBytes, e.maxTxsBytes)
 }
+
+// SenderTxsLimitError means a sender already has the maximum number of txs in a
+// prioritized mempool
+type SenderTxsLimitError struct {
+	sender string
+	maxTxs int
+}
+
+func (e SenderTxsLimitError) Error() string {
+	return fmt.Sprintf("sender %s has too many txs in the mempool (max: %d)", e.sender, e.maxTxs)
+}
@@ -0,0 +1,261 @@
+package mempool
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/clist"
+)
+
+// A prioritized mempool ([cfg.TypePriority]) reaps txs by decreasing
+// priority, as returned by the app in ResponseCheckTx, and evicts the lowest
+// priority txs to make room for higher priority ones when it is full.
+//
+// The txs of a same sender, as returned by the app, are always kept in the
+// order they were added, since their sequence numbers depend on each other:
+// a sender's tx is only reaped after its previous ones, and only the last tx
+// of a sender can be evicted.
+//
+// As the priority only depends on the fee, two limits keep a sender from
+// churning or filling up the mempool at little cost: a tx only evicts txs whose
+// priority is lower than its own by at least the configured price bump, and a
+// sender can't have more than the configured number of txs in the mempool.
+
+// admit checks whether memTx can be added to the mempool, which may evict
+// lower priority txs to make room for it.
+func (mem *CListMempool) admit(memTx *mempoolTx) error {
+	if err := mem.checkSenderTxs(memTx); err != nil {
+		return err
+	}
+	return mem.makeRoomFor(memTx)
+}
+
+// checkSenderTxs returns an error if the sender of memTx already has the
+// maximum number of txs in the mempool.
+func (mem *CListMempool) checkSenderTxs(memTx *mempoolTx) error {
+	maxTxs := mem.config.MaxSenderTxs
+	if maxTxs == 0 || memTx.sender == "" {
+		return nil
+	}
+
+	mem.senderTxsMtx.Lock()
+	n := mem.senderTxs[memTx.sender]
+	mem.senderTxsMtx.Unlock()
+	if n >= maxTxs {
+		return SenderTxsLimitError{memTx.sender, maxTxs}
+	}
+	return nil
+}
+
+// countSenderTx adds delta to the number of txs of sender in the mempool.
+// Only the txs of a prioritized mempool, with a sender, are counted.
+func (mem *CListMempool) countSenderTx(sender string, delta int) {
+	if !mem.prioritized || sender == "" {
+		return
+	}
+	mem.senderTxsMtx.Lock()
+	defer mem.senderTxsMtx.Unlock()
+	if n := mem.senderTxs[sender] + delta; n > 0 {
+		mem.senderTxs[sender] = n
+	} else {
+		delete(mem.senderTxs, sender)
+	}
+}
+
+// resetSenderTxs clears the number of txs of each sender, when the mempool is
+// flushed.
+func (mem *CListMempool) resetSenderTxs() {
+	mem.senderTxsMtx.Lock()
+	defer mem.senderTxsMtx.Unlock()
+	clear(mem.senderTxs)
+}
+
+// isFull returns true if there is no room left in the mempool for a tx of the
+// given size.
+func (mem *CListMempool) isFull(txSize int) bool {
+	return mem.Size() >= mem.config.Size ||
+		int64(txSize)+mem.TxsBytes() > mem.config.MaxPendingTxsBytes
+}
+
+// orderedTxs returns the txs of the mempool, in the order they should be
+// included in a block.
+func (mem *CListMempool) orderedTxs() []*mempoolTx {
+	elems := mem.orderedElements()
+	txs := make([]*mempoolTx, 0, len(elems))
+	if !mem.prioritized {
+		for _, qtx := range elems {
+			txs = append(txs, qtx.memTx)
+		}
+		return txs
+	}
+
+	queues := senderQueues(elems)
+	h := make(queueHeap, 0, len(queues))
+	for _, q := range queues {
+		h = append(h, q)
+	}
+	heap.Init(&h)
+
+	for h.Len() > 0 {
+		q := h[0]
+		txs = append(txs, q.head().memTx)
+		q.txs = q.txs[1:]
+		if len(q.txs) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return txs
+}
+
+// makeRoomFor evicts the lowest priority txs to make room for memTx, if the
+// mempool is full. It returns an error, without evicting anything, if enough
+// room can only be made by evicting txs whose priority isn't lower than the
+// priority of memTx by the price bump.
+func (mem *CListMempool) makeRoomFor(memTx *mempoolTx) error {
+	txSize := len(memTx.tx)
+	if !mem.isFull(txSize) {
+		return nil
+	}
+
+	var (
+		size    = mem.Size()
+		bytes   = mem.TxsBytes()
+		evicted []queuedTx
+	)
+	queues := senderQueues(mem.orderedElements())
+	for size >= mem.config.Size || int64(txSize)+bytes > mem.config.MaxPendingTxsBytes {
+		// Pick the lowest priority tx among the last tx of each sender,
+		// the most recent one if several.
+		var lowest *senderQueue
+		for _, q := range queues {
+			if len(q.txs) == 0 || (memTx.sender != "" && q.sender == memTx.sender) {
+				continue
+			}
+			if lowest == nil || lowerPriority(q.tail(), lowest.tail()) {
+				lowest = q
+			}
+		}
+		if lowest == nil || !mem.canEvict(memTx.priority, lowest.tail().memTx.priority) {
+			return fmt.Errorf("%w: no tx to evict with a priority lower than %d by %d%%", MempoolIsFullError{
+				mem.Size(), mem.config.Size,
+				mem.TxsBytes(), mem.config.MaxPendingTxsBytes,
+			}, memTx.priority, mem.config.PriceBump)
+		}
+
+		qtx := lowest.txs[len(lowest.txs)-1]
+		lowest.txs = lowest.txs[:len(lowest.txs)-1]
+		evicted = append(evicted, qtx)
+		size--
+		bytes -= int64(len(qtx.memTx.tx))
+	}
+
+	for _, qtx := range evicted {
+		mem.logger.Info("Evicted low priority transaction",
+			"tx", txID(qtx.memTx.tx),
+			"priority", qtx.memTx.priority,
+			"replaced_by", txID(memTx.tx),
+		)
+		// Remove from the cache as well, so the tx can be submitted again.
+		mem.removeTx(qtx.memTx.tx, qtx.elem, true)
+	}
+	return nil
+}
+
+// canEvict reports whether a tx of the given priority can evict a tx of the
+// evicted priority: it must be higher, by at least the price bump.
+func (mem *CListMempool) canEvict(priority, evicted int64) bool {
+	if priority <= evicted {
+		return false
+	}
+	// evicted * (100 + bump) / 100 may overflow
+	minPriority := new(big.Int).Mul(big.NewInt(evicted), big.NewInt(100+int64(mem.config.PriceBump)))
+	minPriority.Quo(minPriority, big.NewInt(100))
+	if !minPriority.IsInt64() {
+		return priority == math.MaxInt64
+	}
+	return priority >= minPriority.Int64()
+}
+
+// orderedElements returns the mempool txs in the order they were added.
+func (mem *CListMempool) orderedElements() []queuedTx {
+	txs := make([]queuedTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, queuedTx{memTx: e.Value.(*mempoolTx), elem: e, index: len(txs)})
+	}
+	return txs
+}
+
+type queuedTx struct {
+	memTx *mempoolTx
+	elem  *clist.CElement
+	index int // arrival order
+}
+
+// lowerPriority reports whether a should be evicted before b: it has a lower
+// priority, or the same priority and was added later.
+func lowerPriority(a, b *queuedTx) bool {
+	if a.memTx.priority != b.memTx.priority {
+		return a.memTx.priority < b.memTx.priority
+	}
+	return a.index > b.index
+}
+
+// senderQueue holds the txs of a sender, in the order they were added.
+type senderQueue struct {
+	sender string
+	txs    []queuedTx
+}
+
+func (q *senderQueue) head() *queuedTx { return &q.txs[0] }
+func (q *senderQueue) tail() *queuedTx { return &q.txs[len(q.txs)-1] }
+
+// senderQueues groups txs by sender, preserving their order. Txs without a
+// sender get a queue of their own.
+func senderQueues(txs []queuedTx) []*senderQueue {
+	var (
+		queues   = make([]*senderQueue, 0, len(txs))
+		bySender = map[string]*senderQueue{}
+	)
+	for _, qtx := range txs {
+		sender := qtx.memTx.sender
+		if q, ok := bySender[sender]; ok {
+			q.txs = append(q.txs, qtx)
+			continue
+		}
+		q := &senderQueue{sender: sender, txs: []queuedTx{qtx}}
+		queues = append(queues, q)
+		if sender != "" {
+			bySender[sender] = q
+		}
+	}
+	return queues
+}
+
+// queueHeap is a max-heap of sender queues, by priority of their first tx.
+// Queues whose first tx was added earlier come first on equal priorities.
+type queueHeap []*senderQueue
+
+func (h queueHeap) Len() int { return len(h) }
+
+func (h queueHeap) Less(i, j int) bool {
+	a, b := h[i].head(), h[j].head()
+	if a.memTx.priority != b.memTx.priority {
+		return a.memTx.priority > b.memTx.priority
+	}
+	return a.index < b.index
+}
+
+func (h queueHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *queueHeap) Push(x any) { *h = append(*h, x.(*senderQueue)) }
+
+func (h *queueHeap) Pop() any {
+	old := *h
+	q := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return q
+}
@@ -0,0 +1,267 @@
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// priorityApp reads the sender and priority of a tx from its first two bytes.
+// Each tx wants as much gas as its length.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{
+		GasWanted: int64(len(req.Tx)),
+		Sender:    string(req.Tx[:1]),
+		Priority:  int64(req.Tx[1]),
+	}
+}
+
+// priorityTx returns a tx of the given sender and priority. id makes it unique.
+func priorityTx(sender byte, priority, id byte) types.Tx {
+	return types.Tx{sender, priority, id}
+}
+
+func newPriorityMempool(t *testing.T, size int) *CListMempool {
+	t.Helper()
+
+	config := cfg.TestMempoolConfig()
+	config.Type = cfg.TypePriority
+	config.Size = size
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+	t.Cleanup(cleanup)
+	return mempool
+}
+
+func TestPriorityMempoolReap(t *testing.T) {
+	mempool := newPriorityMempool(t, 100)
+
+	txs := types.Txs{
+		priorityTx('a', 1, 0),
+		priorityTx('b', 5, 0),
+		priorityTx('a', 9, 1), // after a's first tx
+		priorityTx('c', 3, 0),
+		priorityTx('b', 2, 1),
+		priorityTx('c', 3, 1),
+		priorityTx('d', 5, 0), // after b's first tx, on equal priority
+	}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+
+	expected := types.Txs{
+		priorityTx('b', 5, 0),
+		priorityTx('d', 5, 0),
+		priorityTx('c', 3, 0),
+		priorityTx('c', 3, 1),
+		priorityTx('b', 2, 1),
+		priorityTx('a', 1, 0),
+		priorityTx('a', 9, 1),
+	}
+	assert.Equal(t, expected, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, expected, mempool.ReapMaxTxs(-1))
+
+	// Txs that don't fit are skipped, along with the next txs of their sender.
+	big := types.Tx{'e', 4, 0, 0, 0, 0, 0, 0, 0, 0}
+	require.NoError(t, mempool.CheckTx(big, nil))
+	require.NoError(t, mempool.CheckTx(priorityTx('e', 8, 1), nil))
+	assert.Equal(t, types.Txs{
+		priorityTx('b', 5, 0),
+		priorityTx('d', 5, 0),
+		priorityTx('c', 3, 0),
+		priorityTx('c', 3, 1),
+	}, mempool.ReapMaxBytesMaxGas(-1, 14))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	mempool := newPriorityMempool(t, 3)
+
+	for _, tx := range []types.Tx{
+		priorityTx('a', 2, 0),
+		priorityTx('a', 1, 1),
+		priorityTx('b', 3, 0),
+	} {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+
+	checkTx := func(tx types.Tx) abci.Error {
+		t.Helper()
+
+		var res abci.ResponseCheckTx
+		require.NoError(t, mempool.CheckTx(tx, func(r abci.Response) {
+			res = r.(abci.ResponseCheckTx)
+		}))
+		return res.Error
+	}
+
+	// Not higher than the lowest priority tx.
+	err := checkTx(priorityTx('c', 1, 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mempool is full")
+	assert.Equal(t, 3, mempool.Size())
+
+	// Only the last tx of a sender can be evicted, a's tx with priority 2
+	// stays before it.
+	require.Nil(t, checkTx(priorityTx('c', 2, 0)))
+	assert.Equal(t, types.Txs{
+		priorityTx('b', 3, 0),
+		priorityTx('a', 2, 0),
+		priorityTx('c', 2, 0),
+	}, mempool.ReapMaxTxs(-1))
+
+	// The txs of the same sender can't be evicted.
+	err = checkTx(priorityTx('c', 9, 1))
+	require.Nil(t, err)
+	assert.Equal(t, types.Txs{
+		priorityTx('b', 3, 0),
+		priorityTx('c', 2, 0),
+		priorityTx('c', 9, 1),
+	}, mempool.ReapMaxTxs(-1))
+
+	// Evicted txs are removed from the cache, so they are checked again.
+	err = checkTx(priorityTx('a', 2, 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no tx to evict with a priority lower than 2 by 10%")
+}
+
+func TestPriorityMempoolPriceBump(t *testing.T) {
+	mempool := newPriorityMempool(t, 2)
+
+	require.NoError(t, mempool.CheckTx(priorityTx('a', 100, 0), nil))
+	require.NoError(t, mempool.CheckTx(priorityTx('b', 200, 0), nil))
+
+	checkTx := func(tx types.Tx) abci.Error {
+		t.Helper()
+
+		var res abci.ResponseCheckTx
+		require.NoError(t, mempool.CheckTx(tx, func(r abci.Response) {
+			res = r.(abci.ResponseCheckTx)
+		}))
+		return res.Error
+	}
+
+	// A slightly higher priority doesn't evict a's tx, the default bump is
+	// 10%.
+	err := checkTx(priorityTx('c', 109, 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no tx to evict with a priority lower than 109 by 10%")
+
+	require.Nil(t, checkTx(priorityTx('c', 110, 1)))
+	assert.Equal(t, types.Txs{
+		priorityTx('b', 200, 0),
+		priorityTx('c', 110, 1),
+	}, mempool.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolCanEvict(t *testing.T) {
+	mempool := newPriorityMempool(t, 1)
+
+	tests := []struct {
+		priority, evicted int64
+		bump              int
+		expected          bool
+	}{
+		{2, 1, 10, true},
+		{1, 1, 10, false},
+		{1, 1, 0, false},
+		{11, 10, 0, true},
+		{10, 0, 10, true},
+		{109, 100, 10, false},
+		{110, 100, 10, true},
+		{200, 100, 100, true},
+		{199, 100, 100, false},
+		{math.MaxInt64, math.MaxInt64 - 1, 10, true},
+		{math.MaxInt64 - 1, math.MaxInt64 / 2, 100, true},
+		{math.MaxInt64 - 1, math.MaxInt64/2 + 1, 100, false}, // overflows
+		{math.MaxInt64, math.MaxInt64/2 + 1, 100, true},
+		{math.MaxInt64, math.MaxInt64, 10, false},
+	}
+	for _, tt := range tests {
+		mempool.config.PriceBump = tt.bump
+		assert.Equal(t, tt.expected, mempool.canEvict(tt.priority, tt.evicted),
+			"priority %d, evicted %d, bump %d%%", tt.priority, tt.evicted, tt.bump)
+	}
+}
+
+func TestPriorityMempoolMaxSenderTxs(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	config.Type = cfg.TypePriority
+	config.Size = 3
+	config.MaxSenderTxs = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+	defer cleanup()
+
+	checkTx := func(tx types.Tx) abci.Error {
+		t.Helper()
+
+		var res abci.ResponseCheckTx
+		require.NoError(t, mempool.CheckTx(tx, func(r abci.Response) {
+			res = r.(abci.ResponseCheckTx)
+		}))
+		return res.Error
+	}
+
+	require.Nil(t, checkTx(priorityTx('a', 1, 0)))
+	require.Nil(t, checkTx(priorityTx('a', 1, 1)))
+
+	// a can't add more txs, whatever their priority.
+	err := checkTx(priorityTx('a', 9, 2))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has too many txs in the mempool (max: 2)")
+
+	// Other senders can still fill the mempool, then evict the last tx of a.
+	require.Nil(t, checkTx(priorityTx('b', 2, 0)))
+	require.Nil(t, checkTx(priorityTx('c', 5, 0)))
+	assert.Equal(t, types.Txs{
+		priorityTx('c', 5, 0),
+		priorityTx('b', 2, 0),
+		priorityTx('a', 1, 0),
+	}, mempool.ReapMaxTxs(-1))
+
+	// Which lets a add another tx, evicting the tx of b in turn.
+	require.Nil(t, checkTx(priorityTx('a', 9, 2)))
+	assert.Equal(t, types.Txs{
+		priorityTx('c', 5, 0),
+		priorityTx('a', 1, 0),
+		priorityTx('a', 9, 2),
+	}, mempool.ReapMaxTxs(-1))
+
+	// Once a tx of a is committed, a can add another one.
+	mempool.Update(1, types.Txs{priorityTx('a', 1, 0)}, abciResponses(1, nil), nil, 0)
+	require.Nil(t, checkTx(priorityTx('a', 9, 3)))
+	require.Error(t, checkTx(priorityTx('a', 9, 4)))
+
+	// Flushing the mempool resets the count.
+	mempool.Flush()
+	require.Nil(t, checkTx(priorityTx('a', 9, 4)))
+}
+
+func TestPriorityMempoolMaxPendingTxsBytes(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	config.Type = cfg.TypePriority
+	config.MaxPendingTxsBytes = 6
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx(priorityTx('a', 1, 0), nil))
+	require.NoError(t, mempool.CheckTx(priorityTx('b', 2, 0), nil))
+
+	// Larger than the mempool itself.
+	err := mempool.CheckTx(types.Tx{'c', 9, 0, 0, 0, 0, 0}, nil)
+	assert.IsType(t, MempoolIsFullError{}, err)
+
+	// Evicts enough txs to fit.
+	require.NoError(t, mempool.CheckTx(types.Tx{'c', 9, 0, 0, 0}, nil))
+	assert.Equal(t, types.Txs{{'c', 9, 0, 0, 0}}, mempool.ReapMaxTxs(-1))
+	assert.EqualValues(t, 5, mempool.TxsBytes())
+}
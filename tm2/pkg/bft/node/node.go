@@ -15,6 +15,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/discovery"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
@@ -207,6 +208,7 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger *slog
 
 func createAndStartEventStoreService(
 	cfg *cfg.Config,
+	dbProvider DBProvider,
 	evsw events.EventSwitch,
 	logger *slog.Logger,
 ) (*eventstore.Service, eventstore.TxEventStore, error) {
@@ -223,6 +225,13 @@ func createAndStartEventStoreService(
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to create file tx event store, %w", err)
 		}
+	case kv.EventStoreType:
+		// Transactions and blocks should be indexed, for searching
+		db, err := dbProvider(&DBContext{"tx_index", cfg})
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create kv tx event store, %w", err)
+		}
+		txEventStore = kv.NewTxEventStore(db)
 	default:
 		// Transaction event storing should be omitted
 		txEventStore = null.NewNullEventStore()
@@ -388,7 +397,7 @@ func NewNode(config *cfg.Config,
 	})
 
 	// Transaction event storing
-	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, evsw, logger)
+	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, dbProvider, evsw, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -697,6 +706,7 @@ func (n *Node) configureRPC() {
 	rpccore.SetGetFastSync(n.consensusReactor.FastSync)
 	rpccore.SetLogger(n.Logger.With("module", "rpc"))
 	rpccore.SetEventSwitch(n.evsw)
+	rpccore.SetTxEventStore(n.txEventStore)
 	rpccore.SetConfig(*n.config.RPC)
 }
 
@@ -732,10 +742,7 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
 		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
-			rpcserver.OnDisconnect(func(remoteAddr string) {
-				// any cleanup...
-				// (we used to unsubscribe from all event subscriptions)
-			}),
+			rpcserver.OnDisconnect(rpccore.UnsubscribeClient),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 		)
 		wm.SetLogger(wmLogger)
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -38,7 +39,12 @@ const (
 	blockResultsMethod       = "block_results"
 	commitMethod             = "commit"
 	txMethod                 = "tx"
+	txSearchMethod           = "tx_search"
+	blockSearchMethod        = "block_search"
 	validatorsMethod         = "validators"
+	subscribeMethod          = "subscribe"
+	unsubscribeMethod        = "unsubscribe"
+	unsubscribeAllMethod     = "unsubscribe_all"
 )
 
 // RPCClient encompasses common RPC client methods
@@ -46,6 +52,9 @@ type RPCClient struct {
 	requestTimeout time.Duration
 
 	caller rpcclient.Client
+
+	subscriptions    map[string]*Subscription
+	subscriptionsMux sync.Mutex
 }
 
 // NewRPCClient creates a new RPC client instance with the given caller
@@ -53,6 +62,7 @@ func NewRPCClient(caller rpcclient.Client, opts ...Option) *RPCClient {
 	c := &RPCClient{
 		requestTimeout: defaultTimeout,
 		caller:         caller,
+		subscriptions:  make(map[string]*Subscription),
 	}
 
 	for _, opt := range opts {
@@ -96,6 +106,12 @@ func NewWSClient(rpcURL string, opts ...Option) (*RPCClient, error) {
 
 // Close attempts to gracefully close the RPC client
 func (c *RPCClient) Close() error {
+	c.subscriptionsMux.Lock()
+	for _, sub := range c.subscriptions {
+		c.stopSubscription(sub)
+	}
+	c.subscriptionsMux.Unlock()
+
 	return c.caller.Close()
 }
 
@@ -331,6 +347,36 @@ func (c *RPCClient) Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx, erro
 	)
 }
 
+func (c *RPCClient) TxSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return sendRequestCommon[ctypes.ResultTxSearch](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		txSearchMethod,
+		map[string]any{
+			"query":    query,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+}
+
+func (c *RPCClient) BlockSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	return sendRequestCommon[ctypes.ResultBlockSearch](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		blockSearchMethod,
+		map[string]any{
+			"query":    query,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+}
+
 func (c *RPCClient) Validators(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
 	params := map[string]any{}
 	if height != nil {
@@ -368,13 +414,23 @@ func sendRequestCommon[T any](
 		return nil, err
 	}
 
+	return sendRequest[T](ctx, timeout, caller, request)
+}
+
+// sendRequest sends the given request, and parses the response
+func sendRequest[T any](
+	ctx context.Context,
+	timeout time.Duration,
+	caller rpcclient.Client,
+	request rpctypes.RPCRequest,
+) (*T, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Send the request with the provided context
 	response, err := caller.SendRequest(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("unable to call RPC method %s, %w", method, err)
+		return nil, fmt.Errorf("unable to call RPC method %s, %w", request.Method, err)
 	}
 
 	// Parse the response
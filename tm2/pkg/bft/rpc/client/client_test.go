@@ -761,6 +761,82 @@ func TestRPCClient_Tx(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+func TestRPCClient_TxSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query = "tx.height = 10"
+
+		expectedResult = &ctypes.ResultTxSearch{
+			Txs: []*ctypes.ResultTx{
+				{
+					Height: 10,
+				},
+			},
+			HasMore: true,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, query, params["query"])
+			assert.Equal(t, "2", params["page"])
+			assert.Equal(t, "30", params["per_page"])
+			assert.Equal(t, "desc", params["order_by"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			txSearchMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.TxSearch(context.Background(), query, 2, 30, "desc")
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
+func TestRPCClient_BlockSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query = "block.height > 10"
+
+		expectedResult = &ctypes.ResultBlockSearch{
+			HasMore: false,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, query, params["query"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			blockSearchMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.BlockSearch(context.Background(), query, 0, 0, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
 func TestRPCClient_Validators(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+)
+
+// subscriptionBufferSize is the number of events a subscription buffers,
+// before it stops reading from the connection
+const subscriptionBufferSize = 100
+
+var ErrSubscriptionsUnsupported = errors.New("events can only be subscribed to over WebSocket")
+
+// Subscription receives the events matching a query, as they happen
+type Subscription struct {
+	Query string
+
+	events chan ctypes.ResultEvent
+	done   chan struct{}
+	once   sync.Once
+	id     rpctypes.JSONRPCID // of the responses holding the events
+	err    error
+}
+
+// Events returns the events of the subscription. The channel is closed when
+// the subscription ends, see Err.
+//
+// While the events are not read, no other response is read from the
+// connection. If the node has to buffer too many events, because they are
+// not read fast enough, it cancels the subscription.
+func (s *Subscription) Events() <-chan ctypes.ResultEvent {
+	return s.events
+}
+
+// Err returns the reason the subscription ended, once the channel returned by
+// Events is closed. It is nil if the subscription ended by unsubscribing.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Subscribe subscribes to the events matching the given query, such as
+// "tm.event = 'NewBlock'". The subscription ends when unsubscribing, when
+// the node cancels it, or when the client is closed.
+//
+// Subscriptions are only supported by WebSocket clients
+func (c *RPCClient) Subscribe(ctx context.Context, query string) (*Subscription, error) {
+	listener, ok := c.caller.(rpcclient.Listener)
+	if !ok {
+		return nil, ErrSubscriptionsUnsupported
+	}
+
+	request, err := newRequest(subscribeMethod, map[string]any{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		Query:  query,
+		events: make(chan ctypes.ResultEvent),
+		done:   make(chan struct{}),
+		id:     rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", request.ID)),
+	}
+
+	// Listen before subscribing, events can be sent before the response
+	responses := make(chan rpctypes.RPCResponse, subscriptionBufferSize)
+	listener.AddListener(sub.id, responses)
+
+	if _, err := sendRequest[ctypes.ResultSubscribe](ctx, c.requestTimeout, c.caller, request); err != nil {
+		listener.RemoveListener(sub.id)
+
+		return nil, err
+	}
+
+	c.subscriptionsMux.Lock()
+	c.subscriptions[query] = sub
+	c.subscriptionsMux.Unlock()
+
+	go c.runSubscription(sub, responses)
+
+	return sub, nil
+}
+
+// Unsubscribe ends the subscription to the given query
+func (c *RPCClient) Unsubscribe(ctx context.Context, query string) error {
+	if _, ok := c.caller.(rpcclient.Listener); !ok {
+		return ErrSubscriptionsUnsupported
+	}
+
+	_, err := sendRequestCommon[ctypes.ResultUnsubscribe](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		unsubscribeMethod,
+		map[string]any{"query": query},
+	)
+
+	c.subscriptionsMux.Lock()
+	if sub, ok := c.subscriptions[query]; ok {
+		c.stopSubscription(sub)
+	}
+	c.subscriptionsMux.Unlock()
+
+	return err
+}
+
+// UnsubscribeAll ends all the subscriptions of the client
+func (c *RPCClient) UnsubscribeAll(ctx context.Context) error {
+	if _, ok := c.caller.(rpcclient.Listener); !ok {
+		return ErrSubscriptionsUnsupported
+	}
+
+	_, err := sendRequestCommon[ctypes.ResultUnsubscribe](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		unsubscribeAllMethod,
+		map[string]any{},
+	)
+
+	c.subscriptionsMux.Lock()
+	for _, sub := range c.subscriptions {
+		c.stopSubscription(sub)
+	}
+	c.subscriptionsMux.Unlock()
+
+	return err
+}
+
+// runSubscription passes the events of the subscription to its channel,
+// until the subscription is stopped or the node sends an error
+func (c *RPCClient) runSubscription(sub *Subscription, responses <-chan rpctypes.RPCResponse) {
+	defer close(sub.events)
+	defer func() {
+		c.subscriptionsMux.Lock()
+		c.stopSubscription(sub)
+		c.subscriptionsMux.Unlock()
+	}()
+
+	for {
+		select {
+		case <-sub.done:
+			return
+		case response := <-responses:
+			if response.Error != nil {
+				sub.err = response.Error
+
+				return
+			}
+
+			event, err := unmarshalResponseBytes[ctypes.ResultEvent](response.Result)
+			if err != nil {
+				sub.err = err
+
+				return
+			}
+
+			select {
+			case <-sub.done:
+				return
+			case sub.events <- *event:
+			}
+		}
+	}
+}
+
+// stopSubscription stops the subscription, and forgets it.
+// The subscriptions mutex must be held
+func (c *RPCClient) stopSubscription(sub *Subscription) {
+	sub.once.Do(func() {
+		// The listener is always set for a subscription
+		c.caller.(rpcclient.Listener).RemoveListener(sub.id)
+
+		if c.subscriptions[sub.Query] == sub {
+			delete(c.subscriptions, sub.Query)
+		}
+
+		close(sub.done)
+	})
+}
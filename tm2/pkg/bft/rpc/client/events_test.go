@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	bfttypes "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCClient_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported client", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewRPCClient(&mockClient{})
+
+		_, err := c.Subscribe(context.Background(), "tm.event = 'NewBlock'")
+		assert.ErrorIs(t, err, ErrSubscriptionsUnsupported)
+	})
+
+	t.Run("events received", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			query = "tm.event = 'NewBlock'"
+
+			expectedEvent = &ctypes.ResultEvent{
+				Query: query,
+				Event: bfttypes.EventNewBlock{
+					Block: &bfttypes.Block{Header: bfttypes.Header{Height: 10}},
+				},
+			}
+
+			verifyFn = func(t *testing.T, params map[string]any) {
+				t.Helper()
+
+				assert.Equal(t, query, params["query"])
+			}
+
+			mockClient = &mockListenerClient{
+				mockClient: generateMockRequestClient(t, subscribeMethod, verifyFn, &ctypes.ResultSubscribe{}),
+				listeners:  make(map[string]chan<- types.RPCResponse),
+			}
+		)
+
+		// Create the client
+		c := NewRPCClient(mockClient)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		sub, err := c.Subscribe(ctx, query)
+		require.NoError(t, err)
+
+		listener, ok := mockClient.listeners[sub.id.String()]
+		require.True(t, ok)
+
+		// Send an event, then cancel the subscription
+		result, err := amino.MarshalJSON(expectedEvent)
+		require.NoError(t, err)
+
+		listener <- types.RPCResponse{JSONRPC: "2.0", ID: sub.id, Result: result}
+		listener <- types.RPCInternalError(sub.id, assert.AnError)
+
+		event, ok := <-sub.Events()
+		require.True(t, ok)
+		assert.Equal(t, *expectedEvent, event)
+
+		_, ok = <-sub.Events()
+		assert.False(t, ok)
+		assert.ErrorContains(t, sub.Err(), assert.AnError.Error())
+	})
+}
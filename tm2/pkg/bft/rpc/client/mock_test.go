@@ -41,3 +41,19 @@ func (m *mockClient) Close() error {
 
 	return nil
 }
+
+// mockListenerClient is a mock client that receives the
+// responses the server sends on its own
+type mockListenerClient struct {
+	*mockClient
+
+	listeners map[string]chan<- types.RPCResponse
+}
+
+func (m *mockListenerClient) AddListener(id types.JSONRPCID, ch chan<- types.RPCResponse) {
+	m.listeners[id.String()] = ch
+}
+
+func (m *mockListenerClient) RemoveListener(id types.JSONRPCID) {
+	delete(m.listeners, id.String())
+}
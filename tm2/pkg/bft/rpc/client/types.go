@@ -19,10 +19,7 @@ var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
 
 // Client wraps most important rpc calls a client would make.
 //
-// NOTE: Events cannot be subscribed to from the RPC APIs. For events
-// subscriptions and filters and queries, an external API must be used that
-// first synchronously consumes the events from the node's synchronous event
-// switch, or reads logged events from the filesystem.
+// NOTE: Events can only be subscribed to over WebSocket, see EventsClient.
 type Client interface {
 	ABCIClient
 	HistoryClient
@@ -87,6 +84,17 @@ type MempoolClient interface {
 	NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 }
 
+// TxClient provides access to the committed transactions.
 type TxClient interface {
 	Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx, error)
+	// TxSearch and BlockSearch require indexing to be enabled on the node.
+	TxSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	BlockSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
+}
+
+// EventsClient subscribes to the events of the node, over WebSocket.
+type EventsClient interface {
+	Subscribe(ctx context.Context, query string) (*Subscription, error)
+	Unsubscribe(ctx context.Context, query string) error
+	UnsubscribeAll(ctx context.Context) error
 }
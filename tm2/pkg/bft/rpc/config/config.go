@@ -62,6 +62,18 @@ type RPCConfig struct {
 	// See https://github.com/gnolang/gno/tm2/pkg/bft/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `json:"timeout_broadcast_tx_commit" toml:"timeout_broadcast_tx_commit" comment:"How long to wait for a tx to be committed during /broadcast_tx_commit.\n WARNING: Using a value larger than 10s will result in increasing the\n global HTTP write timeout, which applies to all connections and endpoints.\n See https://github.com/tendermint/classic/issues/3435"`
 
+	// Maximum number of unique clients that can /subscribe over WebSocket.
+	MaxSubscriptionClients int `json:"max_subscription_clients" toml:"max_subscription_clients" comment:"Maximum number of unique clients that can /subscribe over WebSocket"`
+
+	// Maximum number of unique queries a given client can /subscribe to.
+	MaxSubscriptionsPerClient int `json:"max_subscriptions_per_client" toml:"max_subscriptions_per_client" comment:"Maximum number of unique queries a given client can /subscribe to"`
+
+	// Maximum number of events buffered for a subscription, waiting to be sent
+	// to the client. If the client doesn't read its events fast enough and
+	// the buffer fills up, the subscription is cancelled and the client is
+	// notified with an error.
+	SubscriptionBufferSize int `json:"subscription_buffer_size" toml:"subscription_buffer_size" comment:"Maximum number of events buffered for a subscription, waiting to be sent to the client.\n If the client doesn't read its events fast enough and the buffer fills up,\n the subscription is cancelled and the client is notified with an error"`
+
 	// Maximum size of request body, in bytes
 	MaxBodyBytes int64 `json:"max_body_bytes" toml:"max_body_bytes" comment:"Maximum size of request body, in bytes"`
 
@@ -98,6 +110,10 @@ func DefaultRPCConfig() *RPCConfig {
 		Unsafe:             false,
 		MaxOpenConnections: 900,
 
+		MaxSubscriptionClients:    100,
+		MaxSubscriptionsPerClient: 5,
+		SubscriptionBufferSize:    200,
+
 		TimeoutBroadcastTxCommit: 10 * time.Second,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
@@ -126,6 +142,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.MaxSubscriptionClients < 0 {
+		return errors.New("max_subscription_clients can't be negative")
+	}
+	if cfg.MaxSubscriptionsPerClient < 0 {
+		return errors.New("max_subscriptions_per_client can't be negative")
+	}
+	if cfg.SubscriptionBufferSize < 1 {
+		return errors.New("subscription_buffer_size must be positive")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout_broadcast_tx_commit can't be negative")
 	}
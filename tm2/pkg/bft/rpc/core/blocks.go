@@ -246,6 +246,57 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 	return &ctypes.ResultBlock{BlockMeta: blockMeta, Block: block}, nil
 }
 
+// BlockSearch allows you to query the blocks matching the given query,
+// when indexing is enabled (the "kv" event store).
+//
+// The query is a list of conditions joined by AND, over the indexed attributes
+// of the blocks: their height and the events emitted when beginning and
+// ending them.
+//
+//	block.height > 10 AND ValidatorAdded.address EXISTS
+//
+// The blocks are ordered by height, ascending ("asc", the default) or
+// descending ("desc") depending on orderBy. The pagination and the limits of
+// the search are the same as in TxSearch.
+//
+// ```shell
+// curl 'localhost:26657/block_search?query="block.height>10"&page=1&per_page=30'
+// ```
+func BlockSearch(_ *rpctypes.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	searcher, q, err := parseSearch(query, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	heights, err := searcher.SearchBlocks(q, orderBy == orderDesc, searchLimit(page, perPage))
+	if err != nil {
+		return nil, searchError(err)
+	}
+
+	start, end, err := paginate(page, perPage, len(heights))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*ctypes.ResultBlock, 0, end-start)
+	for _, height := range heights[start:end] {
+		blockMeta := blockStore.LoadBlockMeta(height)
+		if blockMeta == nil {
+			return nil, fmt.Errorf("unable to load block %d", height)
+		}
+
+		blocks = append(blocks, &ctypes.ResultBlock{
+			BlockMeta: blockMeta,
+			Block:     blockStore.LoadBlock(height),
+		})
+	}
+
+	return &ctypes.ResultBlockSearch{
+		Blocks:  blocks,
+		HasMore: len(heights) > end,
+	}, nil
+}
+
 // Get block commit at a given height.
 // If no height is provided, it will fetch the commit for the latest block.
 //
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -92,3 +96,34 @@ func TestGetHeight(t *testing.T) {
 func int64Ptr(v int64) *int64 {
 	return &v
 }
+
+func TestBlockSearch(t *testing.T) {
+	// Not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	store := kv.NewTxEventStore(memdb.NewMemDB())
+	for height := int64(1); height <= 5; height++ {
+		require.NoError(t, store.AppendBlock(types.EventNewBlock{
+			Block: &types.Block{Header: types.Header{Height: height}},
+		}))
+	}
+
+	SetTxEventStore(store)
+	SetBlockStore(&mockBlockStore{
+		loadBlockMetaFn: func(h int64) *types.BlockMeta {
+			return &types.BlockMeta{Header: types.Header{Height: h}}
+		},
+		loadBlockFn: func(h int64) *types.Block {
+			return &types.Block{Header: types.Header{Height: h}}
+		},
+	})
+
+	result, err := BlockSearch(nil, "block.height >= 2 AND block.height < 5", 0, 0, "desc")
+	require.NoError(t, err)
+
+	assert.False(t, result.HasMore)
+	require.Len(t, result.Blocks, 3)
+	for i, block := range result.Blocks {
+		assert.Equal(t, int64(4-i), block.Block.Height)
+		assert.Equal(t, int64(4-i), block.BlockMeta.Header.Height)
+	}
+}
@@ -64,6 +64,8 @@ JSONRPC requests can be POST'd to the root RPC endpoint via HTTP (e.g. `http://l
 
 JSONRPC requests can be made via websocket. The websocket endpoint is at `/websocket`, e.g. `localhost:26657/websocket`.
 
+Asynchronous events, such as new blocks and txs, are only available via websocket, using `subscribe`, `unsubscribe` and `unsubscribe_all`.
+
 ## More Examples
 
 See the various bash tests using curl in `test/`, and examples using the `Go` API in `rpc/client/`.
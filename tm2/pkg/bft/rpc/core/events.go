@@ -0,0 +1,263 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/random"
+)
+
+// EventKey is the attribute holding the kind of the events that can be
+// subscribed to, along with the attributes they are indexed by: those of
+// [eventstore.BlockAttributes] for EventNewBlock, and those of
+// [eventstore.TxAttributes] for EventTx.
+const (
+	EventKey      = "tm.event"
+	EventNewBlock = "NewBlock"
+	EventTx       = "Tx"
+)
+
+var (
+	errNotSubscribed  = errors.New("subscription not found")
+	errSlowSubscriber = errors.New("subscription cancelled: the client is not reading the events fast enough")
+)
+
+// subscriptions holds the active subscriptions, by client and by query.
+var (
+	subscriptionsMtx sync.Mutex
+	subscriptions    = map[string]map[string]*subscription{}
+)
+
+// Subscribe for events via WebSocket.
+//
+// The query selects the events to send to the client, using the syntax of
+// tx_search; see the query package. Events are either new blocks or txs,
+// as given by the tm.event attribute:
+//
+//	tm.event = 'NewBlock'
+//	tm.event = 'Tx' AND message.signer = 'g1...'
+//	tm.event = 'Tx' AND event.pkg_path = 'gno.land/r/demo/foo' AND event.type = 'Transfer'
+//
+// Each event is sent to the client as a ResultEvent, in a JSON-RPC response
+// whose ID is the ID of the subscribe request followed by "#event".
+//
+// Events are buffered for each subscription, up to subscription_buffer_size.
+// If the buffer is full, because the client doesn't read its events fast
+// enough, the subscription is cancelled and a final error response is sent
+// with the ID of the events. The number of clients and of subscriptions per
+// client are limited by max_subscription_clients and
+// max_subscriptions_per_client.
+//
+// ```shell
+// wscat -c ws://localhost:26657/websocket
+// > { "jsonrpc": "2.0", "method": "subscribe", "params": ["tm.event = 'NewBlock'"], "id": 1 }
+// ```
+func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+	q, err := parseSubscriptionQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	clientID := ctx.RemoteAddr()
+
+	subscriptionsMtx.Lock()
+	defer subscriptionsMtx.Unlock()
+
+	clientSubs, ok := subscriptions[clientID]
+	if !ok && config.MaxSubscriptionClients > 0 && len(subscriptions) >= config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
+	}
+	if config.MaxSubscriptionsPerClient > 0 && len(clientSubs) >= config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", config.MaxSubscriptionsPerClient)
+	}
+	if _, ok := clientSubs[q.String()]; ok {
+		return nil, fmt.Errorf("already subscribed to %q", q.String())
+	}
+
+	sub := &subscription{
+		id:         rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
+		clientID:   clientID,
+		query:      q,
+		listenerID: fmt.Sprintf("rpc-subscription#%v", random.RandStr(6)),
+		events:     make(chan events.Event, config.SubscriptionBufferSize),
+		done:       make(chan struct{}),
+	}
+	if !ok {
+		clientSubs = map[string]*subscription{}
+		subscriptions[clientID] = clientSubs
+	}
+	clientSubs[q.String()] = sub
+
+	evsw.AddListener(sub.listenerID, sub.push)
+	go sub.forward(ctx.WSConn)
+
+	logger.Info("Subscribed to events", "remote", clientID, "query", q.String())
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// Unsubscribe from events via WebSocket. The query must be equivalent to the
+// query of the subscription.
+//
+// ```shell
+// wscat -c ws://localhost:26657/websocket
+// > { "jsonrpc": "2.0", "method": "unsubscribe", "params": ["tm.event = 'NewBlock'"], "id": 1 }
+// ```
+func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
+	q, err := parseSubscriptionQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionsMtx.Lock()
+	sub, ok := subscriptions[ctx.RemoteAddr()][q.String()]
+	subscriptionsMtx.Unlock()
+	if !ok {
+		return nil, errNotSubscribed
+	}
+	sub.cancel(nil)
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// Unsubscribe from all events via WebSocket.
+//
+// ```shell
+// wscat -c ws://localhost:26657/websocket
+// > { "jsonrpc": "2.0", "method": "unsubscribe_all", "params": [], "id": 1 }
+// ```
+func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
+	UnsubscribeClient(ctx.RemoteAddr())
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeClient cancels all the subscriptions of the given client,
+// identified by its remote address. It is called when a WebSocket client
+// disconnects.
+func UnsubscribeClient(clientID string) {
+	subscriptionsMtx.Lock()
+	clientSubs := make([]*subscription, 0, len(subscriptions[clientID]))
+	for _, sub := range subscriptions[clientID] {
+		clientSubs = append(clientSubs, sub)
+	}
+	subscriptionsMtx.Unlock()
+
+	for _, sub := range clientSubs {
+		sub.cancel(nil)
+	}
+}
+
+func parseSubscriptionQuery(s string) (*query.Query, error) {
+	q, err := query.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse query: %w", err)
+	}
+	return q, nil
+}
+
+// subscriptionAttributes returns the attributes the subscription queries are
+// matched against, or nil if ev can't be subscribed to.
+func subscriptionAttributes(ev events.Event) []query.Attribute {
+	switch ev := ev.(type) {
+	case types.EventNewBlock:
+		return append(
+			[]query.Attribute{{Key: EventKey, Value: EventNewBlock}},
+			eventstore.BlockAttributes(ev)...,
+		)
+	case types.EventTx:
+		return append(
+			[]query.Attribute{{Key: EventKey, Value: EventTx}},
+			eventstore.TxAttributes(ev.Result)...,
+		)
+	default:
+		return nil
+	}
+}
+
+// subscription buffers the events matching a query, until they are written
+// to the connection of the client.
+type subscription struct {
+	id         rpctypes.JSONRPCID // of the responses holding the events
+	clientID   string
+	query      *query.Query
+	listenerID string
+
+	events chan events.Event
+	done   chan struct{}
+	once   sync.Once
+	err    error // why the subscription was cancelled, if not unsubscribed
+}
+
+// push is the event switch callback of the subscription. It must not block,
+// so the subscription is cancelled if its buffer is full.
+func (sub *subscription) push(ev events.Event) {
+	attrs := subscriptionAttributes(ev)
+	if attrs == nil || !sub.query.Matches(attrs) {
+		return
+	}
+
+	select {
+	case sub.events <- ev:
+	default:
+		sub.cancel(errSlowSubscriber)
+	}
+}
+
+// forward writes the buffered events to conn until the subscription is
+// cancelled. Writes block while the write buffer of conn is full, so slow
+// clients fill up the buffer of the subscription. The subscription is
+// cancelled as soon as a write fails, as the client is gone.
+func (sub *subscription) forward(conn rpctypes.WSRPCConnection) {
+	for {
+		select {
+		case ev := <-sub.events:
+			err := conn.WriteRPCResponses(rpctypes.RPCResponses{
+				rpctypes.NewRPCSuccessResponse(sub.id, &ctypes.ResultEvent{
+					Query: sub.query.String(),
+					Event: ev,
+				}),
+			})
+			if err != nil {
+				logger.Info("Unable to write event", "remote", sub.clientID, "query", sub.query.String(), "err", err)
+				sub.cancel(nil)
+				return
+			}
+		case <-sub.done:
+			if sub.err != nil {
+				// Nothing is left to free if the client is gone.
+				_ = conn.WriteRPCResponses(rpctypes.RPCResponses{
+					rpctypes.RPCInternalError(sub.id, sub.err),
+				})
+			}
+			return
+		}
+	}
+}
+
+// cancel removes the subscription, with err being the reason sent to the
+// client, if any.
+func (sub *subscription) cancel(err error) {
+	sub.once.Do(func() {
+		evsw.RemoveListener(sub.listenerID)
+
+		subscriptionsMtx.Lock()
+		clientSubs := subscriptions[sub.clientID]
+		delete(clientSubs, sub.query.String())
+		if len(clientSubs) == 0 {
+			delete(subscriptions, sub.clientID)
+		}
+		subscriptionsMtx.Unlock()
+
+		if err != nil {
+			logger.Info("Cancelled subscription", "remote", sub.clientID, "query", sub.query.String(), "err", err)
+		}
+		sub.err = err
+		close(sub.done)
+	})
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+// mockWSConn is a websocket connection whose responses are written to a
+// channel, or fail if the connection is closed
+type mockWSConn struct {
+	remoteAddr string
+	responses  chan rpctypes.RPCResponses
+	closed     bool
+}
+
+func (c *mockWSConn) GetRemoteAddr() string { return c.remoteAddr }
+
+func (c *mockWSConn) WriteRPCResponses(resp rpctypes.RPCResponses) error {
+	if c.closed {
+		return errors.New("connection closed")
+	}
+	c.responses <- resp
+	return nil
+}
+
+func (c *mockWSConn) TryWriteRPCResponses(resp rpctypes.RPCResponses) bool {
+	select {
+	case c.responses <- resp:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *mockWSConn) Context() context.Context { return context.Background() }
+
+func setupSubscriptions(t *testing.T, configure func(*cfg.RPCConfig)) events.EventSwitch {
+	t.Helper()
+
+	sw := events.NewEventSwitch()
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() { sw.Stop() })
+
+	config := cfg.DefaultRPCConfig()
+	if configure != nil {
+		configure(config)
+	}
+
+	SetEventSwitch(sw)
+	SetConfig(*config)
+	SetLogger(log.NewNoopLogger())
+	t.Cleanup(func() { gTxDispatcher.Stop() })
+
+	return sw
+}
+
+func subscribeCtx(conn *mockWSConn, id string) *rpctypes.Context {
+	return &rpctypes.Context{
+		JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCStringID(id)},
+		WSConn:  conn,
+	}
+}
+
+func receive(t *testing.T, conn *mockWSConn) rpctypes.RPCResponse {
+	t.Helper()
+
+	select {
+	case resp := <-conn.responses:
+		require.Len(t, resp, 1)
+		return resp[0]
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a response")
+		return rpctypes.RPCResponse{}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	sw := setupSubscriptions(t, nil)
+
+	conn := &mockWSConn{remoteAddr: "client", responses: make(chan rpctypes.RPCResponses, 10)}
+	ctx := subscribeCtx(conn, "1")
+
+	_, err := Subscribe(ctx, "tm.event = 'Tx' AND tx.height >= 2")
+	require.NoError(t, err)
+	t.Cleanup(func() { UnsubscribeClient("client") })
+
+	// Equivalent queries are the same subscription
+	_, err = Subscribe(ctx, "tm.event='Tx' AND tx.height>=2")
+	assert.ErrorContains(t, err, "already subscribed")
+
+	_, err = Subscribe(ctx, "tm.event =")
+	assert.ErrorContains(t, err, "unable to parse query")
+
+	sw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 2}}})
+	sw.FireEvent(types.EventTx{Result: types.TxResult{Height: 1, Tx: types.Tx("first")}})
+	sw.FireEvent(types.EventTx{Result: types.TxResult{Height: 2, Tx: types.Tx("second")}})
+
+	resp := receive(t, conn)
+	require.Nil(t, resp.Error)
+	assert.Equal(t, rpctypes.JSONRPCStringID("1#event"), resp.ID)
+
+	var result ctypes.ResultEvent
+	require.NoError(t, amino.UnmarshalJSON(resp.Result, &result))
+	assert.Equal(t, "tm.event = 'Tx' AND tx.height >= 2", result.Query)
+	require.IsType(t, types.EventTx{}, result.Event)
+	assert.Equal(t, types.Tx("second"), result.Event.(types.EventTx).Result.Tx)
+
+	// No events after unsubscribing
+	_, err = Unsubscribe(ctx, "tm.event = 'Tx' AND tx.height >= 2")
+	require.NoError(t, err)
+
+	_, err = Unsubscribe(ctx, "tm.event = 'Tx' AND tx.height >= 2")
+	assert.ErrorIs(t, err, errNotSubscribed)
+
+	sw.FireEvent(types.EventTx{Result: types.TxResult{Height: 3, Tx: types.Tx("third")}})
+	assert.Empty(t, conn.responses)
+}
+
+func TestSubscribe_Limits(t *testing.T) {
+	setupSubscriptions(t, func(config *cfg.RPCConfig) {
+		config.MaxSubscriptionClients = 1
+		config.MaxSubscriptionsPerClient = 2
+	})
+
+	var (
+		first  = &mockWSConn{remoteAddr: "first", responses: make(chan rpctypes.RPCResponses, 10)}
+		second = &mockWSConn{remoteAddr: "second", responses: make(chan rpctypes.RPCResponses, 10)}
+	)
+	t.Cleanup(func() { UnsubscribeClient("first") })
+
+	_, err := Subscribe(subscribeCtx(first, "1"), "tm.event = 'NewBlock'")
+	require.NoError(t, err)
+	_, err = Subscribe(subscribeCtx(first, "2"), "tm.event = 'Tx'")
+	require.NoError(t, err)
+
+	_, err = Subscribe(subscribeCtx(first, "3"), "block.height > 10")
+	assert.ErrorContains(t, err, "max_subscriptions_per_client 2 reached")
+
+	_, err = Subscribe(subscribeCtx(second, "1"), "tm.event = 'NewBlock'")
+	assert.ErrorContains(t, err, "max_subscription_clients 1 reached")
+
+	// Room is made by unsubscribing
+	_, err = UnsubscribeAll(subscribeCtx(first, "4"))
+	require.NoError(t, err)
+
+	_, err = Subscribe(subscribeCtx(second, "1"), "tm.event = 'NewBlock'")
+	require.NoError(t, err)
+	UnsubscribeClient("second")
+}
+
+func TestSubscribe_SlowClient(t *testing.T) {
+	sw := setupSubscriptions(t, func(config *cfg.RPCConfig) {
+		config.SubscriptionBufferSize = 1
+	})
+
+	// Responses are not read until the subscription is cancelled
+	conn := &mockWSConn{remoteAddr: "client", responses: make(chan rpctypes.RPCResponses)}
+	_, err := Subscribe(subscribeCtx(conn, "1"), "tm.event = 'NewBlock'")
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 3; height++ {
+		sw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: height}}})
+	}
+
+	subscriptionsMtx.Lock()
+	assert.Empty(t, subscriptions)
+	subscriptionsMtx.Unlock()
+
+	// The buffered events are sent, then the error
+	var resp rpctypes.RPCResponse
+	for resp.Error == nil {
+		resp = receive(t, conn)
+		assert.Equal(t, rpctypes.JSONRPCStringID("1#event"), resp.ID)
+	}
+	assert.Contains(t, resp.Error.Data, errSlowSubscriber.Error())
+}
+
+func TestSubscribe_ClosedConnection(t *testing.T) {
+	sw := setupSubscriptions(t, func(config *cfg.RPCConfig) {
+		config.MaxSubscriptionClients = 1
+	})
+
+	conn := &mockWSConn{remoteAddr: "client", closed: true}
+	_, err := Subscribe(subscribeCtx(conn, "1"), "tm.event = 'NewBlock'")
+	require.NoError(t, err)
+	t.Cleanup(func() { UnsubscribeClient("client") })
+
+	// The subscription is cancelled on the first failed write
+	sw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 1}}})
+	require.Eventually(t, func() bool {
+		subscriptionsMtx.Lock()
+		defer subscriptionsMtx.Unlock()
+		return len(subscriptions) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Its slot is free for another client
+	other := &mockWSConn{remoteAddr: "other", responses: make(chan rpctypes.RPCResponses, 10)}
+	_, err = Subscribe(subscribeCtx(other, "1"), "tm.event = 'NewBlock'")
+	require.NoError(t, err)
+	UnsubscribeClient("other")
+}
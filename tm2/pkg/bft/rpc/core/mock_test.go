@@ -1,6 +1,11 @@
 package core
 
-import "github.com/gnolang/gno/tm2/pkg/bft/types"
+import (
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
 
 type (
 	heightDelegate          func() int64
@@ -76,3 +81,18 @@ func (m *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet
 		m.saveBlockFn(block, blockParts, seenCommit)
 	}
 }
+
+// mockSearcher is an event store whose searches fail with err
+type mockSearcher struct {
+	eventstore.TxEventStore
+
+	err error
+}
+
+func (m *mockSearcher) SearchTxs(*query.Query, bool, int) ([]sm.TxResultIndex, error) {
+	return nil, m.err
+}
+
+func (m *mockSearcher) SearchBlocks(*query.Query, bool, int) ([]int64, error) {
+	return nil, m.err
+}
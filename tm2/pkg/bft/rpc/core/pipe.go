@@ -9,7 +9,10 @@ import (
 	cstypes "github.com/gnolang/gno/tm2/pkg/bft/consensus/types"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -57,6 +60,7 @@ var (
 	// interfaces defined in types and above
 	stateDB        dbm.DB
 	blockStore     sm.BlockStore
+	txEventStore   eventstore.TxEventStore
 	consensusState Consensus
 	p2pPeers       peers
 	p2pTransport   transport
@@ -118,6 +122,10 @@ func SetLogger(l *slog.Logger) {
 	logger = l
 }
 
+func SetTxEventStore(es eventstore.TxEventStore) {
+	txEventStore = es
+}
+
 func SetEventSwitch(sw events.EventSwitch) {
 	evsw = sw
 	gTxDispatcher = newTxDispatcher(evsw)
@@ -146,7 +154,10 @@ func validatePage(page, perPage, totalCount int) (int, error) {
 		pages = 1 // one page (even if it's empty)
 	}
 	if page < 0 || page > pages {
-		return 1, fmt.Errorf("page should be within [0, %d] range, given %d", pages, page)
+		return 1, rpctypes.NewRPCCodeError(
+			ctypes.CodePageOutOfRange,
+			fmt.Errorf("page should be within [0, %d] range, given %d", pages, page),
+		)
 	}
 
 	return page, nil
@@ -1,11 +1,15 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"math"
 
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	eventquery "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
 )
 
 // Tx allows you to query the transaction results. `nil` could mean the
@@ -18,6 +22,12 @@ func Tx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTx, error) {
 		return nil, err
 	}
 
+	return loadTx(*resultIndex)
+}
+
+// loadTx loads the transaction at the given location,
+// along with its results
+func loadTx(resultIndex sm.TxResultIndex) (*ctypes.ResultTx, error) {
 	// Sanity check the block height
 	height, err := getHeight(blockStore.Height(), &resultIndex.BlockNum)
 	if err != nil {
@@ -28,7 +38,7 @@ func Tx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTx, error) {
 	block := blockStore.LoadBlock(height)
 	numTxs := len(block.Txs)
 
-	if int(resultIndex.TxIndex) > numTxs || numTxs == 0 {
+	if int(resultIndex.TxIndex) >= numTxs || numTxs == 0 {
 		return nil, fmt.Errorf(
 			"unable to get block transaction for block %d, index %d",
 			resultIndex.BlockNum,
@@ -45,7 +55,7 @@ func Tx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTx, error) {
 	}
 
 	// Grab the block deliver response
-	if len(blockResults.DeliverTxs) < int(resultIndex.TxIndex) {
+	if len(blockResults.DeliverTxs) <= int(resultIndex.TxIndex) {
 		return nil, fmt.Errorf(
 			"unable to get deliver result for block %d, index %d",
 			resultIndex.BlockNum,
@@ -57,10 +67,130 @@ func Tx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTx, error) {
 
 	// Craft the response
 	return &ctypes.ResultTx{
-		Hash:     hash,
+		Hash:     rawTx.Hash(),
 		Height:   resultIndex.BlockNum,
 		Index:    resultIndex.TxIndex,
 		TxResult: deliverResponse,
 		Tx:       rawTx,
 	}, nil
 }
+
+// TxSearch allows you to query the transactions matching the given query,
+// when transaction indexing is enabled (the "kv" event store).
+//
+// The query is a list of conditions joined by AND, over the indexed attributes:
+//
+//	tx.height >= 10 AND message.pkg_path = 'gno.land/r/demo/foo'
+//
+// The transactions are ordered by height and index, ascending ("asc",
+// the default) or descending ("desc") depending on orderBy. The search stops
+// after the requested page, so the total number of txs isn't returned, only
+// whether more txs follow the page.
+//
+// Each page is searched from the start of the results, and a search fails,
+// instead of returning partial results, when it reads too many entries of the
+// index. Deep pages should rather be reached by resuming the search after the
+// last tx received, with a condition on tx.height.
+//
+// ```shell
+// curl 'localhost:26657/tx_search?query="message.signer=%27g1...%27"&page=1&per_page=30'
+// ```
+func TxSearch(_ *rpctypes.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	searcher, q, err := parseSearch(query, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := searcher.SearchTxs(q, orderBy == orderDesc, searchLimit(page, perPage))
+	if err != nil {
+		return nil, searchError(err)
+	}
+
+	start, end, err := paginate(page, perPage, len(results))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*ctypes.ResultTx, 0, end-start)
+	for _, resultIndex := range results[start:end] {
+		tx, err := loadTx(resultIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return &ctypes.ResultTxSearch{
+		Txs:     txs,
+		HasMore: len(results) > end,
+	}, nil
+}
+
+const (
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// parseSearch returns the searchable event store, and the parsed query
+func parseSearch(query, orderBy string) (eventstore.Searcher, *eventquery.Query, error) {
+	searcher, ok := txEventStore.(eventstore.Searcher)
+	if !ok {
+		return nil, nil, rpctypes.NewRPCCodeError(
+			ctypes.CodeIndexingDisabled,
+			errors.New("indexing is disabled, set the event store type to \"kv\" to enable it"),
+		)
+	}
+
+	switch orderBy {
+	case "", orderAsc, orderDesc:
+	default:
+		return nil, nil, fmt.Errorf("invalid order %q, expected %q or %q", orderBy, orderAsc, orderDesc)
+	}
+
+	q, err := eventquery.Parse(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return searcher, q, nil
+}
+
+// searchLimit returns the number of results to search for the requested page:
+// the results up to the end of the page, and one more, to tell if more
+// results follow
+func searchLimit(page, perPage int) int {
+	perPage = validatePerPage(perPage)
+	page = max(page, 1)
+
+	if page > (math.MaxInt-1)/perPage {
+		return math.MaxInt
+	}
+
+	return page*perPage + 1
+}
+
+// searchError returns the error of a search, with its code if clients may
+// need to tell it apart
+func searchError(err error) error {
+	if errors.Is(err, eventstore.ErrSearchLimit) {
+		return rpctypes.NewRPCCodeError(ctypes.CodeSearchLimitExceeded, err)
+	}
+
+	return err
+}
+
+// paginate returns the bounds of the requested page of results
+func paginate(page, perPage, totalCount int) (int, int, error) {
+	perPage = validatePerPage(perPage)
+
+	page, err := validatePage(page, perPage, totalCount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := min((page-1)*perPage, totalCount)
+	end := min(start+perPage, totalCount)
+
+	return start, end, nil
+}
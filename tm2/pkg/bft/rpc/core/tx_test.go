@@ -1,11 +1,17 @@
 package core
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -220,3 +226,120 @@ func TestTxHandler(t *testing.T) {
 		assert.ErrorContains(t, err, "unable to load block results")
 	})
 }
+
+func TestTxSearchHandler(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	const (
+		numBlocks   = 3
+		txsPerBlock = 2
+	)
+
+	var (
+		sdb    = memdb.NewMemDB()
+		store  = kv.NewTxEventStore(memdb.NewMemDB())
+		blocks = make(map[int64]*types.Block)
+	)
+
+	for height := int64(1); height <= numBlocks; height++ {
+		block := &types.Block{}
+		responses := &state.ABCIResponses{}
+
+		for index := range txsPerBlock {
+			tx := types.Tx(fmt.Sprintf("tx %d/%d", height, index))
+			block.Txs = append(block.Txs, tx)
+			responses.DeliverTxs = append(responses.DeliverTxs, abci.ResponseDeliverTx{
+				GasWanted: height*10 + int64(index),
+			})
+
+			require.NoError(t, store.Append(types.TxResult{
+				Height: height,
+				Index:  uint32(index),
+				Tx:     tx,
+			}))
+		}
+
+		blocks[height] = block
+		sdb.Set(state.CalcABCIResponsesKey(height), responses.Bytes())
+	}
+
+	SetStateDB(sdb)
+	SetBlockStore(&mockBlockStore{
+		heightFn: func() int64 {
+			return numBlocks
+		},
+		loadBlockFn: func(h int64) *types.Block {
+			return blocks[h]
+		},
+	})
+
+	t.Run("indexing disabled", func(t *testing.T) {
+		SetTxEventStore(null.NewNullEventStore())
+
+		_, err := TxSearch(nil, "tx.height = 1", 0, 0, "")
+		assert.ErrorContains(t, err, "indexing is disabled")
+		assert.Equal(t, ctypes.CodeIndexingDisabled, rpctypes.RPCInternalError(rpctypes.JSONRPCIntID(1), err).Error.Code)
+	})
+
+	SetTxEventStore(store)
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := TxSearch(nil, "tx.height", 0, 0, "")
+		assert.ErrorContains(t, err, "expected operator")
+
+		_, err = TxSearch(nil, "tx.height = 1", 0, 0, "random")
+		assert.ErrorContains(t, err, "invalid order")
+	})
+
+	t.Run("matching txs", func(t *testing.T) {
+		result, err := TxSearch(nil, "tx.height >= 2", 0, 0, "")
+		require.NoError(t, err)
+
+		assert.False(t, result.HasMore)
+		require.Len(t, result.Txs, 4)
+
+		first := result.Txs[0]
+		assert.Equal(t, int64(2), first.Height)
+		assert.Equal(t, uint32(0), first.Index)
+		assert.Equal(t, types.Tx("tx 2/0"), first.Tx)
+		assert.Equal(t, first.Tx.Hash(), first.Hash)
+		assert.Equal(t, int64(20), first.TxResult.GasWanted)
+	})
+
+	t.Run("paginated txs", func(t *testing.T) {
+		result, err := TxSearch(nil, "tx.height > 0", 2, 4, "desc")
+		require.NoError(t, err)
+
+		assert.False(t, result.HasMore)
+		require.Len(t, result.Txs, 2)
+		assert.Equal(t, types.Tx("tx 1/1"), result.Txs[0].Tx)
+		assert.Equal(t, types.Tx("tx 1/0"), result.Txs[1].Tx)
+
+		_, err = TxSearch(nil, "tx.height > 0", 3, 4, "desc")
+		assert.ErrorContains(t, err, "page should be within")
+		assert.Equal(t, ctypes.CodePageOutOfRange, rpctypes.RPCInternalError(rpctypes.JSONRPCIntID(1), err).Error.Code)
+	})
+
+	t.Run("more txs after the page", func(t *testing.T) {
+		// The search stops after one more tx than the page
+		result, err := TxSearch(nil, "tx.height > 0", 1, 2, "")
+		require.NoError(t, err)
+
+		assert.True(t, result.HasMore)
+		require.Len(t, result.Txs, 2)
+		assert.Equal(t, types.Tx("tx 1/0"), result.Txs[0].Tx)
+		assert.Equal(t, types.Tx("tx 1/1"), result.Txs[1].Tx)
+	})
+
+	t.Run("search limit exceeded", func(t *testing.T) {
+		SetTxEventStore(&mockSearcher{
+			TxEventStore: null.NewNullEventStore(),
+			err:          fmt.Errorf("%w: too many entries", eventstore.ErrSearchLimit),
+		})
+		defer SetTxEventStore(store)
+
+		_, err := TxSearch(nil, "tx.height > 0", 0, 0, "")
+		assert.ErrorIs(t, err, eventstore.ErrSearchLimit)
+		assert.Equal(t, ctypes.CodeSearchLimitExceeded, rpctypes.RPCInternalError(rpctypes.JSONRPCIntID(1), err).Error.Code)
+	})
+}
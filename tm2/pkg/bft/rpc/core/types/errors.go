@@ -0,0 +1,17 @@
+package core_types
+
+// JSON-RPC error codes of the errors that clients may need to tell apart, in
+// the range reserved for server errors.
+const (
+	// CodeIndexingDisabled is the code of the search errors, when the node
+	// doesn't index the transactions and blocks.
+	CodeIndexingDisabled = -32001
+
+	// CodePageOutOfRange is the code of the search errors, when the requested
+	// page of results doesn't exist.
+	CodePageOutOfRange = -32002
+
+	// CodeSearchLimitExceeded is the code of the search errors, when the
+	// query would read too many entries of the index.
+	CodeSearchLimitExceeded = -32003
+)
@@ -171,10 +171,18 @@ type ResultTx struct {
 	Proof    types.TxProof          `json:"proof,omitempty"`
 }
 
-// Result of searching for txs
+// Result of searching for txs. The search stops after the requested page, so
+// the total number of matching txs isn't known: HasMore tells whether more
+// txs follow the page.
 type ResultTxSearch struct {
-	Txs        []*ResultTx `json:"txs"`
-	TotalCount int         `json:"total_count"`
+	Txs     []*ResultTx `json:"txs"`
+	HasMore bool        `json:"has_more"`
+}
+
+// Result of searching for blocks, see ResultTxSearch for HasMore
+type ResultBlockSearch struct {
+	Blocks  []*ResultBlock `json:"blocks"`
+	HasMore bool           `json:"has_more"`
 }
 
 // List of mempool txs
@@ -200,9 +208,12 @@ type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
 	ResultHealth             struct{}
+	ResultSubscribe          struct{}
+	ResultUnsubscribe        struct{}
 )
 
 // Event data from a subscription
 type ResultEvent struct {
+	Query string        `json:"query"`
 	Event types.TMEvent `json:"event"`
 }
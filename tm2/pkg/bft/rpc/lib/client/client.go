@@ -18,6 +18,16 @@ type Client interface {
 	Close() error
 }
 
+// Listener is implemented by the JSON-RPC clients that receive the responses
+// the server sends on its own, such as the events of subscriptions
+type Listener interface {
+	// AddListener forwards the responses with the given ID to the channel
+	AddListener(types.JSONRPCID, chan<- types.RPCResponse)
+
+	// RemoveListener stops forwarding the responses with the given ID
+	RemoveListener(types.JSONRPCID)
+}
+
 // Batch is the JSON-RPC batch abstraction
 type Batch interface {
 	// AddRequest adds a single request to the RPC batch
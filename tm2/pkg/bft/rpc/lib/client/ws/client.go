@@ -34,6 +34,9 @@ type Client struct {
 
 	requestMap    map[string]responseCh
 	requestMapMux sync.Mutex
+
+	listeners    map[string]chan<- types.RPCResponse
+	listenersMux sync.Mutex
 }
 
 // NewClient initializes and creates a new WS RPC client
@@ -47,6 +50,7 @@ func NewClient(rpcURL string, opts ...Option) (*Client, error) {
 	c := &Client{
 		conn:       conn,
 		requestMap: make(map[string]responseCh),
+		listeners:  make(map[string]chan<- types.RPCResponse),
 		backlog:    make(chan any, 1),
 		logger:     log.NewNoopLogger(),
 	}
@@ -153,6 +157,24 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 	}
 }
 
+// AddListener forwards the responses with the given ID, sent by the server on
+// its own, such as subscription events, to ch. Responses are only read from
+// the server once ch accepts the previous one
+func (c *Client) AddListener(id types.JSONRPCID, ch chan<- types.RPCResponse) {
+	c.listenersMux.Lock()
+	defer c.listenersMux.Unlock()
+
+	c.listeners[id.String()] = ch
+}
+
+// RemoveListener stops forwarding the responses with the given ID
+func (c *Client) RemoveListener(id types.JSONRPCID) {
+	c.listenersMux.Lock()
+	defer c.listenersMux.Unlock()
+
+	delete(c.listeners, id.String())
+}
+
 // generateIDHash generates a unique hash from the given IDs
 func generateIDHash(ids ...string) string {
 	hash := fnv.New128()
@@ -231,6 +253,23 @@ func (c *Client) runReadRoutine(ctx context.Context) {
 				continue
 			}
 
+			// Forward the responses the server sent on its own
+			if response.ID != nil {
+				c.listenersMux.Lock()
+				listener := c.listeners[response.ID.String()]
+				c.listenersMux.Unlock()
+
+				if listener != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case listener <- response:
+					}
+
+					continue
+				}
+			}
+
 			// This is a single response, generate the unique ID
 			responseHash = generateIDHash(response.ID.String())
 			responses = types.RPCResponses{response}
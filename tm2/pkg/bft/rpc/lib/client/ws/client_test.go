@@ -300,3 +300,85 @@ func TestClient_SendBatch(t *testing.T) {
 		assert.Equal(t, response.Error, resp[0].Error)
 	})
 }
+
+func TestClient_AddListener(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader = websocket.Upgrader{}
+
+		request = types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCStringID("id"),
+		}
+
+		events = []types.RPCResponse{
+			{JSONRPC: "2.0", ID: types.JSONRPCStringID("id#event"), Result: []byte(`1`)},
+			{JSONRPC: "2.0", ID: types.JSONRPCStringID("id#event"), Result: []byte(`2`)},
+		}
+	)
+
+	// Create the server, which sends the events
+	// before the response to the request
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		for {
+			mt, message, err := c.ReadMessage()
+			if websocket.IsUnexpectedCloseError(err) {
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the message
+			var req types.RPCRequest
+			require.NoError(t, json.Unmarshal(message, &req))
+
+			for _, response := range append(events, types.RPCResponse{JSONRPC: "2.0", ID: req.ID}) {
+				marshalledResponse, err := json.Marshal(response)
+				require.NoError(t, err)
+
+				require.NoError(t, c.WriteMessage(mt, marshalledResponse))
+			}
+		}
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	// Create the client
+	c, err := NewClient(url)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	eventsCh := make(chan types.RPCResponse, len(events))
+	c.AddListener(types.JSONRPCStringID("id#event"), eventsCh)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	resp, err := c.SendRequest(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, request.ID, resp.ID)
+
+	for _, event := range events {
+		received := <-eventsCh
+		assert.Equal(t, event.ID, received.ID)
+		assert.Equal(t, event.Result, received.Result)
+	}
+
+	// Removed listeners don't receive the events anymore
+	c.RemoveListener(types.JSONRPCStringID("id#event"))
+
+	resp, err = c.SendRequest(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, request.ID, resp.ID)
+	assert.Empty(t, eventsCh)
+}
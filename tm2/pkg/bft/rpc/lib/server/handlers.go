@@ -429,6 +429,8 @@ const (
 	defaultWSPingPeriod        = (defaultWSReadWait * 9) / 10
 )
 
+var errWSConnectionStopped = goerrors.New("websocket connection stopped")
+
 // A single websocket connection contains listener id, underlying ws
 // connection.
 //
@@ -574,12 +576,14 @@ func (wsc *wsConnection) GetRemoteAddr() string {
 }
 
 // WriteRPCResponse pushes a response to the writeChan, and blocks until it is accepted.
+// It returns an error if the connection is stopped first.
 // It implements WSRPCConnection. It is Goroutine-safe.
-func (wsc *wsConnection) WriteRPCResponses(resp types.RPCResponses) {
+func (wsc *wsConnection) WriteRPCResponses(resp types.RPCResponses) error {
 	select {
 	case <-wsc.Quit():
-		return
+		return errWSConnectionStopped
 	case wsc.writeChan <- resp:
+		return nil
 	}
 }
 
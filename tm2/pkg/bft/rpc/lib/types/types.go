@@ -3,6 +3,7 @@ package rpctypes
 import (
 	"context"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -235,8 +236,15 @@ func RPCInvalidParamsError(id JSONRPCID, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32602, "Invalid params", err.Error())
 }
 
+// RPCInternalError returns an internal error response, with the code of the
+// RPCCodeError if err wraps one.
 func RPCInternalError(id JSONRPCID, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32603, "Internal error", err.Error())
+	code := -32603
+	var codeErr *RPCCodeError
+	if goerrors.As(err, &codeErr) {
+		code = codeErr.Code
+	}
+	return NewRPCErrorResponse(id, code, "Internal error", err.Error())
 }
 
 // ----------------------------------------
@@ -246,7 +254,8 @@ type WSRPCConnection interface {
 	// GetRemoteAddr returns a remote address of the connection.
 	GetRemoteAddr() string
 	// WriteRPCResponses writes the resp onto connection (BLOCKING).
-	WriteRPCResponses(resp RPCResponses)
+	// It returns an error if the connection is closed.
+	WriteRPCResponses(resp RPCResponses) error
 	// TryWriteRPCResponses tries to write the resp onto connection (NON-BLOCKING).
 	TryWriteRPCResponses(resp RPCResponses) bool
 	// Context returns the connection's context.
@@ -322,3 +331,26 @@ type HTTPStatusError struct {
 func (h *HTTPStatusError) Error() string {
 	return fmt.Sprintf("%d: %s", h.Code, h.Message)
 }
+
+// NewRPCCodeError returns an error meant to be used in the rpc handlers to signal to the server
+// that it must answer with a specific JSON-RPC error code, so that clients can tell it apart
+func NewRPCCodeError(code int, err error) error {
+	return &RPCCodeError{Code: code, Err: err}
+}
+
+// RPCCodeError is an error meant to be returned in the rpc handlers to signal to the server
+// that it must answer with a specific JSON-RPC error code, so that clients can tell it apart
+type RPCCodeError struct {
+	Code int
+	Err  error
+}
+
+// Error implements error.
+func (e *RPCCodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *RPCCodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,218 @@
+package eventstore
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Indexed attribute keys
+const (
+	TxHeightKey       = "tx.height"        // height of the block containing the tx
+	TxHashKey         = "tx.hash"          // base64 encoded hash of the tx
+	MessageSignerKey  = "message.signer"   // address of a signer of a message
+	MessageRouteKey   = "message.route"    // route of a message, e.g. "vm" or "bank"
+	MessageTypeKey    = "message.type"     // type of a message, e.g. "exec" or "send"
+	MessagePkgPathKey = "message.pkg_path" // path of the realm or package targeted by a message
+	BlockHeightKey    = "block.height"     // height of the block
+	EventTypeKey      = "event.type"       // type of an emitted event
+	EventPkgPathKey   = "event.pkg_path"   // path of the realm that emitted a Gno event
+)
+
+// TxAttributes returns the attributes a transaction is indexed by.
+//
+// Along with the tx.* keys, messages are indexed by the message.* keys, if the
+// tx is a [std.Tx]. Each emitted event is indexed as <type>.<attribute>, see
+// [EventAttributes].
+func TxAttributes(result types.TxResult) []query.Attribute {
+	attrs := []query.Attribute{
+		{Key: TxHeightKey, Value: strconv.FormatInt(result.Height, 10)},
+		{Key: TxHashKey, Value: base64.StdEncoding.EncodeToString(result.Tx.Hash())},
+	}
+
+	var tx std.Tx
+	if err := amino.Unmarshal(result.Tx, &tx); err == nil {
+		for _, msg := range tx.GetMsgs() {
+			if msg == nil {
+				continue
+			}
+			for _, signer := range msg.GetSigners() {
+				attrs = append(attrs, query.Attribute{Key: MessageSignerKey, Value: signer.String()})
+			}
+			attrs = append(attrs,
+				query.Attribute{Key: MessageRouteKey, Value: msg.Route()},
+				query.Attribute{Key: MessageTypeKey, Value: msg.Type()},
+			)
+			if pkgPath := msgPkgPath(msg); pkgPath != "" {
+				attrs = append(attrs, query.Attribute{Key: MessagePkgPathKey, Value: pkgPath})
+			}
+		}
+	}
+
+	for _, ev := range result.Response.Events {
+		attrs = append(attrs, EventAttributes(ev)...)
+	}
+	return dedupAttributes(attrs)
+}
+
+// BlockAttributes returns the attributes a block is indexed by: its height
+// and the events emitted when beginning and ending it.
+func BlockAttributes(ev types.EventNewBlock) []query.Attribute {
+	attrs := []query.Attribute{
+		{Key: BlockHeightKey, Value: strconv.FormatInt(ev.Block.Height, 10)},
+	}
+
+	events := append([]abci.Event{}, ev.ResultBeginBlock.Events...)
+	events = append(events, ev.ResultEndBlock.ResponseBase.Events...)
+	events = append(events, ev.ResultEndBlock.Events...)
+	for _, e := range events {
+		attrs = append(attrs, EventAttributes(e)...)
+	}
+	return dedupAttributes(attrs)
+}
+
+// EventAttributes returns the attributes of an event, keyed by
+// <type>.<attribute>.
+//
+// The event is decoded by [DecodeEvent]. Each event is also indexed by its
+// type and, if it has one, its package path, regardless of its other
+// attributes. For example, the Gno event emitted by
+// std.Emit("Transfer", "to", "g1...") in gno.land/r/demo/foo is indexed as:
+//
+//	event.type = 'Transfer'
+//	event.pkg_path = 'gno.land/r/demo/foo'
+//	Transfer.to = 'g1...'
+//	Transfer.pkg_path = 'gno.land/r/demo/foo'
+func EventAttributes(ev abci.Event) []query.Attribute {
+	dev, ok := DecodeEvent(ev)
+	if !ok {
+		return nil
+	}
+
+	attrs := []query.Attribute{{Key: EventTypeKey, Value: dev.Type}}
+	if dev.PkgPath != "" {
+		attrs = append(attrs, query.Attribute{Key: EventPkgPathKey, Value: dev.PkgPath})
+	}
+	for _, attr := range dev.Attrs {
+		attrs = append(attrs, query.Attribute{Key: dev.Type + "." + attr.Key, Value: attr.Value})
+	}
+	return attrs
+}
+
+// DecodedEvent is an event, as seen by the indexer.
+type DecodedEvent struct {
+	Type    string
+	PkgPath string            // path of the realm that emitted a Gno event
+	Attrs   []query.Attribute // keyed by attribute name, without the type
+}
+
+// DecodeEvent decodes an event from its JSON representation. It returns false
+// if the event isn't a JSON object.
+//
+// The type of an event is the value of its "type" field if it has one, such
+// as Gno events, or the name of its Go type. Its attributes are its scalar
+// fields, including its "pkg_path", as well as the key/value pairs of its
+// "attrs" field, if any, ordered by field name.
+func DecodeEvent(ev abci.Event) (DecodedEvent, bool) {
+	bz, err := json.Marshal(ev)
+	if err != nil {
+		return DecodedEvent{}, false
+	}
+	var fields map[string]any
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return DecodedEvent{}, false // not an object
+	}
+
+	var dev DecodedEvent
+	if dev.Type, _ = fields["type"].(string); dev.Type == "" {
+		dev.Type = reflect.Indirect(reflect.ValueOf(ev)).Type().Name()
+	}
+	dev.PkgPath, _ = fields["pkg_path"].(string)
+
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		value := fields[name]
+		if name == "type" {
+			continue
+		}
+		if name == "attrs" {
+			list, _ := value.([]any)
+			for _, item := range list {
+				kv, _ := item.(map[string]any)
+				key, _ := kv["key"].(string)
+				if value, ok := ScalarString(kv["value"]); ok && key != "" {
+					dev.Attrs = append(dev.Attrs, query.Attribute{Key: key, Value: value})
+				}
+			}
+			continue
+		}
+		if value, ok := ScalarString(value); ok {
+			dev.Attrs = append(dev.Attrs, query.Attribute{Key: name, Value: value})
+		}
+	}
+	return dev, true
+}
+
+// ScalarString returns the string representation of a decoded JSON scalar,
+// decoded with [json.Decoder.UseNumber]. It returns false for objects, arrays
+// and null.
+func ScalarString(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// msgPkgPath returns the path of the realm or package targeted by msg, as
+// found in its pkg_path or package.path field.
+func msgPkgPath(msg std.Msg) string {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		PkgPath string `json:"pkg_path"`
+		Package *struct {
+			Path string `json:"path"`
+		} `json:"package"`
+	}
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return ""
+	}
+	if fields.PkgPath == "" && fields.Package != nil {
+		return fields.Package.Path
+	}
+	return fields.PkgPath
+}
+
+// dedupAttributes removes the duplicate attributes, keeping the first
+// occurrence of each.
+func dedupAttributes(attrs []query.Attribute) []query.Attribute {
+	seen := make(map[query.Attribute]struct{}, len(attrs))
+	deduped := attrs[:0]
+	for _, attr := range attrs {
+		if _, ok := seen[attr]; ok {
+			continue
+		}
+		seen[attr] = struct{}{}
+		deduped = append(deduped, attr)
+	}
+	return deduped
+}
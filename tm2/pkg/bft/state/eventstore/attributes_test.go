@@ -0,0 +1,156 @@
+package eventstore
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gnoEvent has the layout of the events emitted by Gno realms
+type gnoEvent struct {
+	Type    string          `json:"type"`
+	Attrs   []gnoEventAttrs `json:"attrs"`
+	PkgPath string          `json:"pkg_path"`
+}
+
+type gnoEventAttrs struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (gnoEvent) AssertABCIEvent() {}
+
+type heightEvent struct {
+	Height int64 `json:"height"`
+	Final  bool  `json:"final"`
+}
+
+func (heightEvent) AssertABCIEvent() {}
+
+func TestEventAttributes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []query.Attribute{
+		{Key: EventTypeKey, Value: "Transfer"},
+		{Key: EventPkgPathKey, Value: "gno.land/r/demo/foo"},
+		{Key: "Transfer.from", Value: "g1alice"},
+		{Key: "Transfer.to", Value: "g1bob"},
+		{Key: "Transfer.pkg_path", Value: "gno.land/r/demo/foo"},
+	}, EventAttributes(gnoEvent{
+		Type:    "Transfer",
+		Attrs:   []gnoEventAttrs{{"from", "g1alice"}, {"to", "g1bob"}},
+		PkgPath: "gno.land/r/demo/foo",
+	}))
+
+	assert.Equal(t, []query.Attribute{
+		{Key: EventTypeKey, Value: "heightEvent"},
+		{Key: "heightEvent.final", Value: "true"},
+		{Key: "heightEvent.height", Value: "42"},
+	}, EventAttributes(heightEvent{Height: 42, Final: true}))
+}
+
+func TestDecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	dev, ok := DecodeEvent(gnoEvent{
+		Type:    "Transfer",
+		Attrs:   []gnoEventAttrs{{"to", "g1bob"}, {"amount", "10"}},
+		PkgPath: "gno.land/r/demo/foo",
+	})
+	require.True(t, ok)
+	assert.Equal(t, DecodedEvent{
+		Type:    "Transfer",
+		PkgPath: "gno.land/r/demo/foo",
+		Attrs: []query.Attribute{
+			{Key: "to", Value: "g1bob"},
+			{Key: "amount", Value: "10"},
+			{Key: "pkg_path", Value: "gno.land/r/demo/foo"},
+		},
+	}, dev)
+
+	dev, ok = DecodeEvent(heightEvent{Height: 42})
+	require.True(t, ok)
+	assert.Equal(t, DecodedEvent{
+		Type: "heightEvent",
+		Attrs: []query.Attribute{
+			{Key: "final", Value: "false"},
+			{Key: "height", Value: "42"},
+		},
+	}, dev)
+}
+
+func TestTxAttributes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from = crypto.AddressFromPreimage([]byte("from"))
+		to   = crypto.AddressFromPreimage([]byte("to"))
+	)
+
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			bank.NewMsgSend(from, to, std.NewCoins(std.NewCoin("ugnot", 10))),
+			bank.NewMsgSend(from, to, std.NewCoins(std.NewCoin("ugnot", 20))),
+		},
+	}
+	raw, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	result := types.TxResult{
+		Height: 12,
+		Index:  1,
+		Tx:     raw,
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					gnoEvent{Type: "Sent", Attrs: []gnoEventAttrs{{"amount", "10"}}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []query.Attribute{
+		{Key: TxHeightKey, Value: "12"},
+		{Key: TxHashKey, Value: base64.StdEncoding.EncodeToString(types.Tx(raw).Hash())},
+		{Key: MessageSignerKey, Value: from.String()},
+		{Key: MessageRouteKey, Value: "bank"},
+		{Key: MessageTypeKey, Value: "send"},
+		{Key: EventTypeKey, Value: "Sent"},
+		{Key: "Sent.amount", Value: "10"},
+		{Key: "Sent.pkg_path", Value: ""},
+	}, TxAttributes(result))
+
+	// Txs that aren't std.Tx are still indexed
+	result.Tx = []byte("not a std.Tx")
+	result.Response.Events = nil
+	assert.Equal(t, []query.Attribute{
+		{Key: TxHeightKey, Value: "12"},
+		{Key: TxHashKey, Value: base64.StdEncoding.EncodeToString(types.Tx("not a std.Tx").Hash())},
+	}, TxAttributes(result))
+}
+
+func TestMsgPkgPath(t *testing.T) {
+	t.Parallel()
+
+	type msgCall struct {
+		bank.MsgSend
+		PkgPath string `json:"pkg_path"`
+	}
+	type msgAddPackage struct {
+		bank.MsgSend
+		Package *std.MemPackage `json:"package"`
+	}
+
+	assert.Equal(t, "gno.land/r/demo/foo", msgPkgPath(msgCall{PkgPath: "gno.land/r/demo/foo"}))
+	assert.Equal(t, "gno.land/p/demo/bar", msgPkgPath(msgAddPackage{Package: &std.MemPackage{Path: "gno.land/p/demo/bar"}}))
+	assert.Empty(t, msgPkgPath(bank.MsgSend{}))
+}
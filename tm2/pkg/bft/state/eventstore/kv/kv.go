@@ -0,0 +1,299 @@
+// Package kv provides a transaction event store backed by a key/value
+// database, which indexes the transactions and blocks by their attributes so
+// they can be searched.
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var (
+	_ eventstore.BlockEventStore = (*TxEventStore)(nil)
+	_ eventstore.Searcher        = (*TxEventStore)(nil)
+)
+
+const (
+	EventStoreType = "kv"
+)
+
+// Key prefixes of the index entries. The attribute entries are laid out as
+// <prefix><attribute key>\x00<attribute value>\x00<ref>, with the ref as value,
+// and the ref entries as <prefix><ref>, with the JSON attributes as value, so
+// the txs and blocks can be read in order. The ref of a tx is its big-endian
+// height and index, the ref of a block its big-endian height.
+var (
+	txAttrPrefix    = []byte("txAttr:")
+	blockAttrPrefix = []byte("blockAttr:")
+	txRefPrefix     = []byte("txRef:")
+	blockRefPrefix  = []byte("blockRef:")
+)
+
+// defaultMaxSearchScan is the maximum number of index entries read by a
+// search.
+const defaultMaxSearchScan = 100_000
+
+// eventIndex describes the entries indexing the txs or the blocks.
+type eventIndex struct {
+	attrPrefix []byte
+	refPrefix  []byte
+	heightKey  string // attribute of the height, which the refs start with
+}
+
+var (
+	txIndex    = eventIndex{txAttrPrefix, txRefPrefix, eventstore.TxHeightKey}
+	blockIndex = eventIndex{blockAttrPrefix, blockRefPrefix, eventstore.BlockHeightKey}
+)
+
+// TxEventStore is the implementation of a transaction event store
+// that indexes the transactions and blocks in a database.
+// Only the index is stored, the transactions themselves are in the block store
+type TxEventStore struct {
+	db dbm.DB
+
+	maxSearchScan int // maximum number of index entries read by a search
+}
+
+// NewTxEventStore creates a new indexing tx event store, using the given database
+func NewTxEventStore(db dbm.DB) *TxEventStore {
+	return &TxEventStore{
+		db:            db,
+		maxSearchScan: defaultMaxSearchScan,
+	}
+}
+
+// Start starts the kv transaction event store
+func (t *TxEventStore) Start() error {
+	return nil
+}
+
+// Stop stops the kv transaction event store, by closing the database
+func (t *TxEventStore) Stop() error {
+	t.db.Close()
+
+	return nil
+}
+
+// GetType returns the kv transaction event store type
+func (t *TxEventStore) GetType() string {
+	return EventStoreType
+}
+
+// Append indexes the transaction by its attributes
+func (t *TxEventStore) Append(result types.TxResult) error {
+	ref := make([]byte, 12)
+	binary.BigEndian.PutUint64(ref, uint64(result.Height))
+	binary.BigEndian.PutUint32(ref[8:], result.Index)
+
+	return t.index(txIndex, eventstore.TxAttributes(result), ref)
+}
+
+// AppendBlock indexes the block by its attributes
+func (t *TxEventStore) AppendBlock(ev types.EventNewBlock) error {
+	ref := make([]byte, 8)
+	binary.BigEndian.PutUint64(ref, uint64(ev.Block.Height))
+
+	return t.index(blockIndex, eventstore.BlockAttributes(ev), ref)
+}
+
+func (t *TxEventStore) index(idx eventIndex, attrs []query.Attribute, ref []byte) error {
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	indexed := make([]query.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		if bytes.IndexByte([]byte(attr.Key), 0) >= 0 {
+			// Can't be told apart from the value
+			continue
+		}
+		batch.Set(attrKey(idx.attrPrefix, attr.Key, attr.Value, ref), ref)
+		indexed = append(indexed, attr)
+	}
+
+	bz, err := json.Marshal(indexed)
+	if err != nil {
+		return fmt.Errorf("unable to encode the attributes, %w", err)
+	}
+	batch.Set(refKey(idx.refPrefix, ref), bz)
+	batch.WriteSync()
+
+	return nil
+}
+
+// SearchTxs returns the location of the first limit transactions matching
+// the query
+func (t *TxEventStore) SearchTxs(q *query.Query, desc bool, limit int) ([]sm.TxResultIndex, error) {
+	refs, err := t.search(txIndex, q, desc, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]sm.TxResultIndex, 0, len(refs))
+	for _, ref := range refs {
+		if len(ref) != 12 {
+			return nil, fmt.Errorf("invalid tx index ref %X", ref)
+		}
+		results = append(results, sm.TxResultIndex{
+			BlockNum: int64(binary.BigEndian.Uint64(ref)),
+			TxIndex:  binary.BigEndian.Uint32(ref[8:]),
+		})
+	}
+
+	return results, nil
+}
+
+// SearchBlocks returns the heights of the first limit blocks matching the query
+func (t *TxEventStore) SearchBlocks(q *query.Query, desc bool, limit int) ([]int64, error) {
+	refs, err := t.search(blockIndex, q, desc, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]int64, 0, len(refs))
+	for _, ref := range refs {
+		if len(ref) != 8 {
+			return nil, fmt.Errorf("invalid block index ref %X", ref)
+		}
+		heights = append(heights, int64(binary.BigEndian.Uint64(ref)))
+	}
+
+	return heights, nil
+}
+
+// search returns the first limit refs matching all the conditions of the
+// query, in increasing order, or decreasing if desc is set.
+//
+// The refs are read in order from the attribute entries of the value of an
+// equality condition on a string, if the query has one, or else from the ref
+// entries, between the heights allowed by the conditions on the height. The
+// attributes of each ref are then matched against the query. An error is
+// returned if more than t.maxSearchScan entries would be read.
+func (t *TxEventStore) search(idx eventIndex, q *query.Query, desc bool, limit int) ([][]byte, error) {
+	low, high, ok := heightRange(idx.heightKey, q.Conditions)
+	if !ok || limit <= 0 {
+		return nil, nil
+	}
+
+	// The attribute entries have the ref as value, and the ref entries
+	// have it at the end of their key.
+	prefix, fromAttrs := idx.refPrefix, false
+	for _, c := range q.Conditions {
+		if c.Op == query.OpEqual && !c.IsNumber() {
+			prefix = append(attrKeyPrefix(idx.attrPrefix, c.Key), c.Operand...)
+			prefix, fromAttrs = append(prefix, 0), true
+
+			break
+		}
+	}
+
+	start, end := heightKey(prefix, low), heightKey(prefix, high+1)
+
+	var it dbm.Iterator
+	if desc {
+		it = t.db.ReverseIterator(start, end)
+	} else {
+		it = t.db.Iterator(start, end)
+	}
+	defer it.Close()
+
+	var (
+		refs    [][]byte
+		scanned int
+	)
+
+	for ; it.Valid() && len(refs) < limit; it.Next() {
+		if scanned++; scanned > t.maxSearchScan {
+			return nil, fmt.Errorf(
+				"%w: more than %d index entries to read, narrow the query down or resume it from a height",
+				eventstore.ErrSearchLimit, t.maxSearchScan,
+			)
+		}
+
+		ref, bz := it.Key()[len(prefix):], it.Value()
+		if fromAttrs {
+			ref = it.Value()
+			bz = t.db.Get(refKey(idx.refPrefix, ref))
+		}
+
+		var attrs []query.Attribute
+		if err := json.Unmarshal(bz, &attrs); err != nil {
+			return nil, fmt.Errorf("invalid attributes of index ref %X, %w", ref, err)
+		}
+
+		if q.Matches(attrs) {
+			refs = append(refs, bytes.Clone(ref))
+		}
+	}
+
+	return refs, nil
+}
+
+// heightRange returns the range of heights allowed by the number conditions
+// on the height attribute key, or false if it is empty
+func heightRange(key string, conds []query.Condition) (uint64, uint64, bool) {
+	low, high := 0.0, float64(math.MaxInt64)
+
+	for _, c := range conds {
+		if c.Key != key || !c.IsNumber() {
+			continue
+		}
+
+		n := c.Number()
+		switch c.Op {
+		case query.OpEqual:
+			low, high = max(low, math.Ceil(n)), min(high, math.Floor(n))
+		case query.OpGreater:
+			low = max(low, math.Floor(n)+1)
+		case query.OpGreaterEqual:
+			low = max(low, math.Ceil(n))
+		case query.OpLess:
+			high = min(high, math.Ceil(n)-1)
+		case query.OpLessEqual:
+			high = min(high, math.Floor(n))
+		}
+	}
+
+	if low > high {
+		return 0, 0, false
+	}
+
+	return uint64(low), uint64(high), true
+}
+
+// heightKey returns the key of the first ref of the given height, after prefix
+func heightKey(prefix []byte, height uint64) []byte {
+	return binary.BigEndian.AppendUint64(slices.Clip(prefix), height)
+}
+
+func refKey(prefix, ref []byte) []byte {
+	k := make([]byte, 0, len(prefix)+len(ref))
+	k = append(k, prefix...)
+
+	return append(k, ref...)
+}
+
+func attrKeyPrefix(prefix []byte, key string) []byte {
+	k := make([]byte, 0, len(prefix)+len(key)+1)
+	k = append(k, prefix...)
+	k = append(k, key...)
+
+	return append(k, 0)
+}
+
+func attrKey(prefix []byte, key, value string, ref []byte) []byte {
+	k := attrKeyPrefix(prefix, key)
+	k = append(k, value...)
+	k = append(k, 0)
+
+	return append(k, ref...)
+}
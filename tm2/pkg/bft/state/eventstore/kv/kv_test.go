@@ -0,0 +1,180 @@
+package kv
+
+import (
+	"testing"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	Type   string `json:"type"`
+	Sender string `json:"sender"`
+	Amount int64  `json:"amount"`
+}
+
+func (testEvent) AssertABCIEvent() {}
+
+func txResult(height int64, index uint32, events ...abci.Event) types.TxResult {
+	return types.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     types.Tx{byte(height), byte(index)},
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: events,
+			},
+		},
+	}
+}
+
+func TestTxEventStore_SearchTxs(t *testing.T) {
+	t.Parallel()
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	defer store.Stop()
+
+	for _, result := range []types.TxResult{
+		txResult(1, 0, testEvent{Type: "Transfer", Sender: "alice", Amount: 10}),
+		txResult(1, 1, testEvent{Type: "Transfer", Sender: "bob", Amount: 20}),
+		txResult(2, 0),
+		txResult(10, 0, testEvent{Type: "Transfer", Sender: "alice", Amount: 30}, testEvent{Type: "Mint", Sender: "alice"}),
+		txResult(10, 1, testEvent{Type: "Transfer", Sender: "bob/carol", Amount: 5}),
+	} {
+		require.NoError(t, store.Append(result))
+	}
+
+	tests := []struct {
+		query    string
+		expected []sm.TxResultIndex
+	}{
+		{"tx.height = 1", []sm.TxResultIndex{txAt(1, 0), txAt(1, 1)}},
+		{"tx.height >= 2", []sm.TxResultIndex{txAt(2, 0), txAt(10, 0), txAt(10, 1)}},
+		{"Transfer.sender = 'alice'", []sm.TxResultIndex{txAt(1, 0), txAt(10, 0)}},
+		{"Transfer.sender = 'bob'", []sm.TxResultIndex{txAt(1, 1)}},
+		{"Transfer.sender CONTAINS 'bob'", []sm.TxResultIndex{txAt(1, 1), txAt(10, 1)}},
+		{"Transfer.sender = 'alice' AND tx.height > 1", []sm.TxResultIndex{txAt(10, 0)}},
+		{"Transfer.amount >= 10 AND Transfer.amount < 30", []sm.TxResultIndex{txAt(1, 0), txAt(1, 1)}},
+		{"Mint.sender EXISTS", []sm.TxResultIndex{txAt(10, 0)}},
+		{"Transfer.sender = 'dave'", []sm.TxResultIndex{}},
+		{"Transfer.sender = 'alice' AND Mint.sender = 'bob'", []sm.TxResultIndex{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := store.SearchTxs(query.MustParse(tt.query), false, 100)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, results)
+			assert.IsIncreasing(t, orderKeys(results))
+
+			results, err = store.SearchTxs(query.MustParse(tt.query), true, 100)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, results)
+			assert.IsDecreasing(t, orderKeys(results))
+		})
+	}
+}
+
+func TestTxEventStore_SearchLimits(t *testing.T) {
+	t.Parallel()
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	defer store.Stop()
+
+	for height := int64(1); height <= 10; height++ {
+		sender := "alice"
+		if height%2 == 0 {
+			sender = "bob"
+		}
+		require.NoError(t, store.Append(txResult(height, 0, testEvent{Type: "Transfer", Sender: sender})))
+	}
+
+	// The search stops at the limit
+	results, err := store.SearchTxs(query.MustParse("Transfer.sender = 'bob'"), false, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.TxResultIndex{txAt(2, 0), txAt(4, 0)}, results)
+
+	results, err = store.SearchTxs(query.MustParse("Transfer.sender CONTAINS 'o'"), true, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.TxResultIndex{txAt(10, 0), txAt(8, 0), txAt(6, 0)}, results)
+
+	// Only the entries of the value of an equality condition, and of the
+	// allowed heights, are read
+	store.maxSearchScan = 5
+	results, err = store.SearchTxs(query.MustParse("Transfer.sender = 'bob'"), false, 10)
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+
+	results, err = store.SearchTxs(query.MustParse("tx.height > 3.5 AND tx.height <= 8"), false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.TxResultIndex{txAt(4, 0), txAt(5, 0), txAt(6, 0), txAt(7, 0), txAt(8, 0)}, results)
+
+	results, err = store.SearchTxs(query.MustParse("tx.height > 8 AND tx.height < 9"), false, 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Broader searches fail, instead of returning partial results
+	_, err = store.SearchTxs(query.MustParse("Transfer.sender CONTAINS 'o'"), false, 10)
+	assert.ErrorIs(t, err, eventstore.ErrSearchLimit)
+
+	// They can be resumed from the height of the last result instead
+	results, err = store.SearchTxs(query.MustParse("Transfer.sender CONTAINS 'o' AND tx.height > 5"), false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []sm.TxResultIndex{txAt(6, 0), txAt(8, 0), txAt(10, 0)}, results)
+}
+
+func txAt(height int64, index uint32) sm.TxResultIndex {
+	return sm.TxResultIndex{BlockNum: height, TxIndex: index}
+}
+
+func orderKeys(results []sm.TxResultIndex) []int64 {
+	keys := make([]int64, len(results))
+	for i, r := range results {
+		keys[i] = r.BlockNum*1000 + int64(r.TxIndex)
+	}
+	return keys
+}
+
+func TestTxEventStore_SearchBlocks(t *testing.T) {
+	t.Parallel()
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	defer store.Stop()
+
+	for height := int64(1); height <= 5; height++ {
+		ev := types.EventNewBlock{
+			Block: &types.Block{Header: types.Header{Height: height}},
+		}
+		if height%2 == 0 {
+			ev.ResultEndBlock.Events = []abci.Event{testEvent{Type: "Reward", Sender: "validator"}}
+		}
+		require.NoError(t, store.AppendBlock(ev))
+	}
+
+	heights, err := store.SearchBlocks(query.MustParse("block.height > 2"), false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 4, 5}, heights)
+
+	heights, err = store.SearchBlocks(query.MustParse("block.height > 2"), true, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 4}, heights)
+
+	heights, err = store.SearchBlocks(query.MustParse("Reward.sender = 'validator'"), false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 4}, heights)
+
+	// Blocks and txs are indexed separately
+	results, err := store.SearchTxs(query.MustParse("block.height > 2"), false, 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,295 @@
+// Package query implements the small query language used to search the
+// indexed transactions and blocks.
+//
+// A query is a list of conditions joined by AND, each comparing the values of
+// an attribute to an operand:
+//
+//	tx.height >= 10 AND message.pkg_path = 'gno.land/r/demo/foo'
+//	Transfer.to = 'g1...' AND Transfer.amount > 100
+//	message.type EXISTS
+//
+// Operands are single-quoted strings or numbers. The supported operators are
+// =, !=, <, <=, >, >= and CONTAINS (substring match), as well as EXISTS, which
+// takes no operand. Ordering operators require a number operand and CONTAINS
+// a string operand; numbers are compared numerically with the attribute
+// values. A condition matches if any of the values of its attribute satisfies
+// it.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Attribute is an indexed key/value pair. A transaction or a block can have
+// several attributes with the same key.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Operator is a comparison operator.
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpNotEqual     Operator = "!="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpContains     Operator = "CONTAINS"
+	OpExists       Operator = "EXISTS"
+)
+
+// Condition is a single comparison of a query.
+type Condition struct {
+	Key string
+	Op  Operator
+	// Operand is the string operand, or the literal of the number operand.
+	// It is empty for OpExists.
+	Operand string
+
+	number   float64
+	isNumber bool
+}
+
+// IsNumber reports whether the operand of c is a number.
+func (c Condition) IsNumber() bool { return c.isNumber }
+
+// Number returns the number operand of c, if [Condition.IsNumber].
+func (c Condition) Number() float64 { return c.number }
+
+// String returns c in the query language.
+func (c Condition) String() string {
+	switch {
+	case c.Op == OpExists:
+		return c.Key + " " + string(c.Op)
+	case c.isNumber:
+		return c.Key + " " + string(c.Op) + " " + c.Operand
+	default:
+		return c.Key + " " + string(c.Op) + " '" + strings.ReplaceAll(c.Operand, "'", `\'`) + "'"
+	}
+}
+
+// Match reports whether value satisfies c.
+func (c Condition) Match(value string) bool {
+	if c.isNumber {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c.Op == OpNotEqual
+		}
+		switch c.Op {
+		case OpEqual:
+			return n == c.number
+		case OpNotEqual:
+			return n != c.number
+		case OpLess:
+			return n < c.number
+		case OpLessEqual:
+			return n <= c.number
+		case OpGreater:
+			return n > c.number
+		case OpGreaterEqual:
+			return n >= c.number
+		}
+		return true // OpExists
+	}
+
+	switch c.Op {
+	case OpEqual:
+		return value == c.Operand
+	case OpNotEqual:
+		return value != c.Operand
+	case OpContains:
+		return strings.Contains(value, c.Operand)
+	}
+	return true // OpExists
+}
+
+// Query is a parsed query.
+type Query struct {
+	Conditions []Condition
+}
+
+// Matches reports whether the given attributes satisfy all the conditions of
+// q.
+func (q *Query) Matches(attrs []Attribute) bool {
+	for _, c := range q.Conditions {
+		matched := false
+		for _, attr := range attrs {
+			if attr.Key == c.Key && c.Match(attr.Value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns q in the query language.
+func (q *Query) String() string {
+	conds := make([]string, len(q.Conditions))
+	for i, c := range q.Conditions {
+		conds[i] = c.String()
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// MustParse is like [Parse], but panics on error.
+func MustParse(s string) *Query {
+	q, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+var errEmptyQuery = errors.New("empty query")
+
+// Parse parses a query.
+func Parse(s string) (*Query, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errEmptyQuery
+	}
+	p := &parser{input: s}
+
+	var q Query
+	for {
+		c, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		q.Conditions = append(q.Conditions, c)
+
+		p.skipSpaces()
+		if p.eof() {
+			return &q, nil
+		}
+		if word := p.word(); word != "AND" {
+			return nil, p.errorf("expected AND, got %q", word)
+		}
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid query %q at offset %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.input) }
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// word reads a run of characters allowed in keys, keywords and numbers.
+func (p *parser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for !p.eof() && isWordChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func isWordChar(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' ||
+		ch >= 'A' && ch <= 'Z' ||
+		ch >= '0' && ch <= '9' ||
+		strings.IndexByte("._-/:", ch) >= 0
+}
+
+func (p *parser) condition() (Condition, error) {
+	var c Condition
+	if c.Key = p.word(); c.Key == "" {
+		return c, p.errorf("expected attribute key")
+	}
+
+	op, err := p.operator()
+	if err != nil {
+		return c, err
+	}
+	c.Op = op
+	if op == OpExists {
+		return c, nil
+	}
+
+	p.skipSpaces()
+	if !p.eof() && p.input[p.pos] == '\'' {
+		if c.Operand, err = p.quoted(); err != nil {
+			return c, err
+		}
+	} else {
+		c.Operand = p.word()
+		if c.number, err = strconv.ParseFloat(c.Operand, 64); err != nil {
+			return c, p.errorf("expected a quoted string or a number, got %q", c.Operand)
+		}
+		c.isNumber = true
+	}
+
+	switch op {
+	case OpLess, OpLessEqual, OpGreater, OpGreaterEqual:
+		if !c.isNumber {
+			return c, p.errorf("operator %s requires a number", op)
+		}
+	case OpContains:
+		if c.isNumber {
+			return c, p.errorf("operator %s requires a string", op)
+		}
+	}
+	return c, nil
+}
+
+func (p *parser) operator() (Operator, error) {
+	p.skipSpaces()
+	for _, op := range []Operator{
+		OpLessEqual, OpGreaterEqual, OpNotEqual, // before their prefixes
+		OpEqual, OpLess, OpGreater,
+	} {
+		if strings.HasPrefix(p.input[p.pos:], string(op)) {
+			p.pos += len(op)
+			return op, nil
+		}
+	}
+	switch word := p.word(); Operator(word) {
+	case OpContains, OpExists:
+		return Operator(word), nil
+	case "":
+		return "", p.errorf("expected operator")
+	default:
+		return "", p.errorf("unknown operator %q", word)
+	}
+}
+
+// quoted reads a single-quoted string, in which quotes can be escaped with a
+// backslash.
+func (p *parser) quoted() (string, error) {
+	p.pos++ // opening quote
+	var sb strings.Builder
+	for !p.eof() {
+		ch := p.input[p.pos]
+		p.pos++
+		switch {
+		case ch == '\\' && !p.eof() && p.input[p.pos] == '\'':
+			sb.WriteByte('\'')
+			p.pos++
+		case ch == '\'':
+			return sb.String(), nil
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
@@ -0,0 +1,83 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query    string
+		expected string // formatted query, or error
+		err      bool
+	}{
+		{"tx.height = 5", "tx.height = 5", false},
+		{"tx.height>=5 AND tx.height<10", "tx.height >= 5 AND tx.height < 10", false},
+		{"message.pkg_path = 'gno.land/r/demo/foo'", "message.pkg_path = 'gno.land/r/demo/foo'", false},
+		{`Transfer.memo CONTAINS 'it\'s'`, `Transfer.memo CONTAINS 'it\'s'`, false},
+		{"message.signer != 'g1abc' AND message.type EXISTS", "message.signer != 'g1abc' AND message.type EXISTS", false},
+		{"  ", "empty query", true},
+		{"tx.height", "expected operator", true},
+		{"tx.height ~ 5", "expected operator", true},
+		{"tx.height LIKE 5", `unknown operator "LIKE"`, true},
+		{"tx.height = five", `expected a quoted string or a number, got "five"`, true},
+		{"message.type < 'exec'", "operator < requires a number", true},
+		{"message.type CONTAINS 5", "operator CONTAINS requires a string", true},
+		{"message.type = 'exec", "unterminated string", true},
+		{"tx.height = 5 OR tx.height = 6", `expected AND, got "OR"`, true},
+		{"tx.height = 5 AND", "expected attribute key", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := Parse(tt.query)
+			if tt.err {
+				require.ErrorContains(t, err, tt.expected)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, q.String())
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	attrs := []Attribute{
+		{"tx.height", "12"},
+		{"message.signer", "g1alice"},
+		{"message.signer", "g1bob"},
+		{"Transfer.amount", "100ugnot"},
+	}
+
+	tests := []struct {
+		query   string
+		matches bool
+	}{
+		{"tx.height = 12", true},
+		{"tx.height = 12.0", true},
+		{"tx.height > 10 AND tx.height <= 12", true},
+		{"tx.height < 12", false},
+		{"tx.height != 12", false},
+		{"message.signer = 'g1bob'", true},
+		{"message.signer != 'g1alice'", true},
+		{"message.signer = 'g1carol'", false},
+		{"message.signer EXISTS AND tx.height >= 12", true},
+		{"message.type EXISTS", false},
+		{"Transfer.amount CONTAINS 'ugnot'", true},
+		{"Transfer.amount > 10", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.matches, MustParse(tt.query).Matches(attrs))
+		})
+	}
+}
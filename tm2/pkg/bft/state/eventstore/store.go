@@ -1,6 +1,12 @@
 package eventstore
 
-import "github.com/gnolang/gno/tm2/pkg/bft/types"
+import (
+	"errors"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
 
 const (
 	StatusOn  = "on"
@@ -22,3 +28,31 @@ type TxEventStore interface {
 	// to the event store
 	Append(result types.TxResult) error
 }
+
+// BlockEventStore is implemented by event stores that also store
+// block events
+type BlockEventStore interface {
+	TxEventStore
+
+	// AppendBlock analyzes and appends a single block
+	// to the event store
+	AppendBlock(block types.EventNewBlock) error
+}
+
+// ErrSearchLimit is returned by the searches which would read too many
+// entries of the index, for queries which should be narrowed down
+var ErrSearchLimit = errors.New("search limit exceeded")
+
+// Searcher is implemented by event stores that index the stored
+// events, so they can be searched using the query language
+// (see the query package)
+type Searcher interface {
+	// SearchTxs returns the location of the first limit transactions
+	// matching the query, ordered by height and index, in decreasing
+	// order if desc is set
+	SearchTxs(q *query.Query, desc bool, limit int) ([]sm.TxResultIndex, error)
+
+	// SearchBlocks returns the heights of the first limit blocks matching
+	// the query, in increasing order, or decreasing if desc is set
+	SearchBlocks(q *query.Query, desc bool, limit int) ([]int64, error)
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeGrantCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee    string
+	SpendLimit string
+	Expiration string
+}
+
+func NewMakeGrantCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeGrantCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "grant",
+			ShortUsage: "grant [flags] <key-name or address>",
+			ShortHelp:  "grants a fee allowance to another account",
+			LongHelp: "Grants a fee allowance to the grantee, which can then use it to have its " +
+				"tx fees paid by the granter with --fee-granter. Granting an allowance again replaces it.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeGrant(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeGrantCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the account whose fees are paid",
+	)
+
+	fs.StringVar(
+		&c.SpendLimit,
+		"spend-limit",
+		"",
+		"maximum amount of fees paid, or unlimited if empty",
+	)
+
+	fs.StringVar(
+		&c.Expiration,
+		"expiration",
+		"",
+		"RFC3339 time when the allowance expires, or never if empty",
+	)
+}
+
+func execMakeGrant(cfg *MakeGrantCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee must be specified")
+	}
+
+	granter, err := resolveAddress(cfg.RootCfg, args[0])
+	if err != nil {
+		return err
+	}
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "parsing grantee address")
+	}
+
+	var allowance auth.FeeAllowance
+	if cfg.SpendLimit != "" {
+		allowance.SpendLimit, err = std.ParseCoins(cfg.SpendLimit)
+		if err != nil {
+			return errors.Wrap(err, "parsing spend limit")
+		}
+	}
+	if cfg.Expiration != "" {
+		allowance.Expiration, err = time.Parse(time.RFC3339, cfg.Expiration)
+		if err != nil {
+			return errors.Wrap(err, "parsing expiration")
+		}
+	}
+
+	msg := auth.NewMsgGrantAllowance(granter, grantee, allowance)
+	return makeTx(cfg.RootCfg, args, msg, fee, io)
+}
+
+type MakeRevokeCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee string
+}
+
+func NewMakeRevokeCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeRevokeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "revoke",
+			ShortUsage: "revoke [flags] <key-name or address>",
+			ShortHelp:  "revokes a fee allowance",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeRevoke(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeRevokeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the account whose allowance is revoked",
+	)
+}
+
+func execMakeRevoke(cfg *MakeRevokeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee must be specified")
+	}
+
+	granter, err := resolveAddress(cfg.RootCfg, args[0])
+	if err != nil {
+		return err
+	}
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "parsing grantee address")
+	}
+
+	msg := auth.NewMsgRevokeAllowance(granter, grantee)
+	return makeTx(cfg.RootCfg, args, msg, fee, io)
+}
+
+// resolveAddress returns the address of the key with the given name or
+// address.
+func resolveAddress(cfg *MakeTxCfg, nameOrBech32 string) (crypto.Address, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return crypto.Address{}, err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return crypto.Address{}, err
+	}
+	return info.GetAddress(), nil
+}
+
+// makeTx signs and broadcasts the tx of msg, or prints it.
+func makeTx(cfg *MakeTxCfg, args []string, msg std.Msg, fee std.Fee, io commands.IO) error {
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.Memo,
+	}
+
+	if cfg.Broadcast {
+		return ExecSignAndBroadcast(cfg, args, tx, io)
+	}
+	io.Println(string(amino.MustMarshalJSON(tx)))
+	return nil
+}
@@ -1,15 +1,19 @@
 package client
 
 import (
+	"context"
 	"encoding/base64"
 	"flag"
 	"fmt"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -19,6 +23,8 @@ type MakeTxCfg struct {
 	GasWanted int64
 	GasFee    string
 	Memo      string
+	// FeeGranter is the address paying the fee, from its fee allowance.
+	FeeGranter string
 
 	Broadcast bool
 	// Valid options are SimulateTest, SimulateSkip or SimulateOnly.
@@ -33,6 +39,14 @@ const (
 	SimulateOnly = "only"
 )
 
+// GasFeeAuto is the gas-fee value requesting the fee to be estimated when
+// broadcasting, by simulating the tx and querying the suggested gas price.
+const GasFeeAuto = "auto"
+
+// defaultSimulationGas is the gas wanted to simulate a tx with an estimated
+// fee, when neither the gas wanted nor the maximum gas of a block are set.
+const defaultSimulationGas = 100_000_000
+
 func (c *MakeTxCfg) Validate() error {
 	switch c.Simulate {
 	case SimulateTest, SimulateSkip, SimulateOnly:
@@ -42,6 +56,42 @@ func (c *MakeTxCfg) Validate() error {
 	return nil
 }
 
+// ParseFee returns the fee of the tx from the gas-wanted, gas-fee and
+// fee-granter flags. With --gas-fee auto, the fee is estimated when
+// broadcasting; the returned fee only has the gas wanted, which is then used to
+// simulate the tx.
+func (c *MakeTxCfg) ParseFee() (std.Fee, error) {
+	var granter crypto.Bech32Address
+	if c.FeeGranter != "" {
+		addr, err := crypto.AddressFromBech32(c.FeeGranter)
+		if err != nil {
+			return std.Fee{}, errors.Wrap(err, "parsing fee granter address")
+		}
+		granter = addr.Bech32()
+	}
+
+	if c.GasFee == GasFeeAuto {
+		if !c.Broadcast {
+			return std.Fee{}, errors.New("gas-fee auto requires broadcast")
+		}
+		return std.Fee{GasWanted: c.GasWanted, Granter: granter}, nil
+	}
+
+	if c.GasWanted == 0 {
+		return std.Fee{}, errors.New("gas-wanted not specified")
+	}
+	if c.GasFee == "" {
+		return std.Fee{}, errors.New("gas-fee not specified")
+	}
+	gasfee, err := std.ParseCoin(c.GasFee)
+	if err != nil {
+		return std.Fee{}, errors.Wrap(err, "parsing gas fee coin")
+	}
+	fee := std.NewFee(c.GasWanted, gasfee)
+	fee.Granter = granter
+	return fee, nil
+}
+
 func NewMakeTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 	cfg := &MakeTxCfg{
 		RootCfg: rootCfg,
@@ -59,6 +109,8 @@ func NewMakeTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		NewMakeSendCmd(cfg, io),
+		NewMakeGrantCmd(cfg, io),
+		NewMakeRevokeCmd(cfg, io),
 	)
 
 	return cmd
@@ -76,7 +128,14 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		&c.GasFee,
 		"gas-fee",
 		"",
-		"gas payment fee",
+		`gas payment fee, or "auto" to estimate the fee and the gas wanted when broadcasting`,
+	)
+
+	fs.StringVar(
+		&c.FeeGranter,
+		"fee-granter",
+		"",
+		"address paying the gas fee, from the fee allowance it granted to the signer",
 	)
 
 	fs.StringVar(
@@ -116,6 +175,17 @@ func SignAndBroadcastHandler(
 	nameOrBech32 string,
 	tx std.Tx,
 	pass string,
+) (*types.ResultBroadcastTxCommit, error) {
+	return signAndBroadcast(cfg, nameOrBech32, &tx, pass)
+}
+
+// signAndBroadcast signs and broadcasts tx, whose fee is set to the estimated
+// one with --gas-fee auto.
+func signAndBroadcast(
+	cfg *MakeTxCfg,
+	nameOrBech32 string,
+	tx *std.Tx,
+	pass string,
 ) (*types.ResultBroadcastTxCommit, error) {
 	baseopts := cfg.RootCfg
 	txopts := cfg
@@ -160,21 +230,34 @@ func SignAndBroadcastHandler(
 		decryptPass: pass,
 	}
 
+	if txopts.GasFee == GasFeeAuto {
+		sign := func(tx *std.Tx) error {
+			signature, err := generateSignature(tx, kb, sOpts, kOpts)
+			if err != nil {
+				return err
+			}
+			return addSignature(tx, signature)
+		}
+		if err := estimateTxFee(baseopts.Remote, tx, sign); err != nil {
+			return nil, errors.Wrap(err, "estimate gas fee")
+		}
+	}
+
 	// Generate the transaction signature
-	signature, err := generateSignature(&tx, kb, sOpts, kOpts)
+	signature, err := generateSignature(tx, kb, sOpts, kOpts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to sign transaction: %w", err)
 	}
 
 	// Add the signature to the tx
-	if err = addSignature(&tx, signature); err != nil {
+	if err = addSignature(tx, signature); err != nil {
 		return nil, fmt.Errorf("unable to add signature: %w", err)
 	}
 
 	// broadcast signed tx
 	bopts := &BroadcastCfg{
 		RootCfg: baseopts,
-		tx:      &tx,
+		tx:      tx,
 
 		DryRun:       cfg.Simulate == SimulateOnly,
 		testSimulate: cfg.Simulate == SimulateTest,
@@ -183,6 +266,74 @@ func SignAndBroadcastHandler(
 	return BroadcastHandler(bopts)
 }
 
+// estimateTxFee sets the fee of tx to the estimated one. The tx is simulated,
+// signed with sign, to estimate its gas usage, which is paid at the average
+// gas price suggested by the node. The tx signatures are then removed.
+func estimateTxFee(remote string, tx *std.Tx, sign func(*std.Tx) error) error {
+	if remote == "" {
+		return errors.New("missing remote url")
+	}
+	cli, err := client.NewHTTPClient(remote)
+	if err != nil {
+		return errors.Wrap(err, "new http client")
+	}
+
+	// The suggested gas price has the denomination of the fee.
+	qres, err := cli.ABCIQuery(context.Background(), "auth/"+auth.QuerySuggestedGasPrice, nil)
+	if err != nil {
+		return errors.Wrap(err, "query gas price")
+	}
+	if qres.Response.Error != nil {
+		return errors.Wrapf(qres.Response.Error, "query gas price failed: log:%s", qres.Response.Log)
+	}
+	var suggestion auth.GasPriceSuggestion
+	if err := amino.UnmarshalJSON(qres.Response.Data, &suggestion); err != nil {
+		return errors.Wrap(err, "unmarshaling query gas price result")
+	}
+
+	// Simulate with the gas wanted, or the maximum gas of a block.
+	gasWanted := tx.Fee.GasWanted
+	if gasWanted == 0 {
+		gasWanted = defaultSimulationGas
+		cres, err := cli.ConsensusParams(context.Background(), nil)
+		if err != nil {
+			return errors.Wrap(err, "query consensus params")
+		}
+		if block := cres.ConsensusParams.Block; block != nil && block.MaxGas > 0 {
+			gasWanted = block.MaxGas
+		}
+	}
+	// The fee isn't checked against the gas price when simulating, but must
+	// be valid.
+	granter := tx.Fee.Granter
+	tx.Fee = std.NewFee(gasWanted, std.NewCoin(suggestion.Average.Price.Denom, 1))
+	tx.Fee.Granter = granter
+	if err := sign(tx); err != nil {
+		return fmt.Errorf("unable to sign transaction: %w", err)
+	}
+
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return errors.Wrap(err, "remarshaling tx binary bytes")
+	}
+	res, err := SimulateTx(cli, bz)
+	if err != nil {
+		return err
+	}
+	if res.DeliverTx.IsErr() {
+		return errors.Wrapf(res.DeliverTx.Error, "simulate transaction failed: log:%s", res.DeliverTx.Log)
+	}
+
+	fee, err := auth.EstimateFee(res.DeliverTx.GasUsed, suggestion.Average)
+	if err != nil {
+		return err
+	}
+	tx.Fee = fee
+	tx.Fee.Granter = granter
+	tx.Signatures = nil
+	return nil
+}
+
 func ExecSignAndBroadcast(
 	cfg *MakeTxCfg,
 	args []string,
@@ -210,7 +361,7 @@ func ExecSignAndBroadcast(
 		return err
 	}
 
-	bres, err := SignAndBroadcastHandler(cfg, nameOrBech32, tx, pass)
+	bres, err := signAndBroadcast(cfg, nameOrBech32, &tx, pass)
 	if err != nil {
 		return errors.Wrap(err, "broadcast tx")
 	}
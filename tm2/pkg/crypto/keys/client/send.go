@@ -59,11 +59,9 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
-	if cfg.RootCfg.GasWanted == 0 {
-		return errors.New("gas-wanted not specified")
-	}
-	if cfg.RootCfg.GasFee == "" {
-		return errors.New("gas-fee not specified")
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
 	}
 	if cfg.Send == "" {
 		return errors.New("send (amount) must be specified")
@@ -97,13 +95,6 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 		return errors.Wrap(err, "parsing send coins")
 	}
 
-	// parse gas wanted & fee.
-	gaswanted := cfg.RootCfg.GasWanted
-	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
-	if err != nil {
-		return errors.Wrap(err, "parsing gas fee coin")
-	}
-
 	// construct msg & tx and marshal.
 	msg := bank.MsgSend{
 		FromAddress: fromAddr,
@@ -112,7 +103,7 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
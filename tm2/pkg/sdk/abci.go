@@ -25,3 +25,8 @@ type BeginTxHook func(ctx Context) Context
 // EndTxHook is a BaseApp-specific hook, called after all the messages in a
 // transaction have terminated.
 type EndTxHook func(ctx Context, result Result)
+
+// HeaderModifier is a BaseApp-specific hook, called at the beginning of every
+// block to modify the header seen by the application, such as its height or
+// time. It is meant for development nodes, and must be deterministic.
+type HeaderModifier func(header abci.Header) abci.Header
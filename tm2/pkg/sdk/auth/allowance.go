@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// FeeAllowance is an allowance granted by an account, the granter, to pay
+// for the fees of the transactions of another account, the grantee. The
+// grantee sets the granter in the fee of its transactions to use it.
+type FeeAllowance struct {
+	// SpendLimit is the maximum amount of fees the granter pays; the
+	// allowance is removed once spent. It's unlimited if empty.
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+	// Expiration is the time after which the allowance can't be used
+	// anymore. It never expires if zero.
+	Expiration time.Time `json:"expiration" yaml:"expiration"`
+}
+
+// ValidateBasic validates the spend limit of the allowance.
+func (a FeeAllowance) ValidateBasic() error {
+	if !a.SpendLimit.IsValid() {
+		return std.ErrInvalidCoins(a.SpendLimit.String())
+	}
+	if !a.SpendLimit.IsZero() && !a.SpendLimit.IsAllPositive() {
+		return std.ErrInvalidCoins("spend limit must be positive")
+	}
+	return nil
+}
+
+// IsExpired returns whether the allowance is expired at the given time.
+func (a FeeAllowance) IsExpired(now time.Time) bool {
+	return !a.Expiration.IsZero() && !now.Before(a.Expiration)
+}
+
+// FeeAllowanceGrant is a fee allowance, with its granter and grantee.
+type FeeAllowanceGrant struct {
+	Granter   crypto.Address `json:"granter" yaml:"granter"`
+	Grantee   crypto.Address `json:"grantee" yaml:"grantee"`
+	Allowance FeeAllowance   `json:"allowance" yaml:"allowance"`
+}
+
+// FeeAllowanceStoreKey returns the key of the fee allowance granted by
+// granter to grantee in the account store.
+func FeeAllowanceStoreKey(granter, grantee crypto.Address) []byte {
+	key := append([]byte(FeeAllowanceStoreKeyPrefix), granter.Bytes()...)
+	return append(key, grantee.Bytes()...)
+}
+
+// GetFeeAllowance returns the fee allowance granted by granter to grantee.
+func (ak AccountKeeper) GetFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) (FeeAllowance, bool) {
+	stor := ctx.GasStore(ak.key)
+	bz := stor.Get(FeeAllowanceStoreKey(granter, grantee))
+	if bz == nil {
+		return FeeAllowance{}, false
+	}
+	var a FeeAllowance
+	if err := amino.Unmarshal(bz, &a); err != nil {
+		panic(err)
+	}
+	return a, true
+}
+
+// SetFeeAllowance sets the fee allowance granted by granter to grantee,
+// replacing the existing one.
+func (ak AccountKeeper) SetFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address, a FeeAllowance) {
+	stor := ctx.GasStore(ak.key)
+	bz, err := amino.Marshal(a)
+	if err != nil {
+		panic(err)
+	}
+	stor.Set(FeeAllowanceStoreKey(granter, grantee), bz)
+}
+
+// RemoveFeeAllowance removes the fee allowance granted by granter to grantee.
+func (ak AccountKeeper) RemoveFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) {
+	stor := ctx.GasStore(ak.key)
+	stor.Delete(FeeAllowanceStoreKey(granter, grantee))
+}
+
+// GrantFeeAllowance grants a fee allowance from granter to grantee, replacing
+// the existing one. The account of the grantee is created if needed, so that a
+// new account without any coins can sign its transactions.
+func (ak AccountKeeper) GrantFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address, a FeeAllowance) error {
+	if err := validateGranterGrantee(granter, grantee); err != nil {
+		return err
+	}
+	if err := a.ValidateBasic(); err != nil {
+		return err
+	}
+	if a.IsExpired(ctx.BlockTime()) {
+		return std.ErrUnauthorized(fmt.Sprintf("fee allowance expiration %s is in the past", a.Expiration))
+	}
+
+	if ak.GetAccount(ctx, grantee) == nil {
+		acc := ak.NewAccountWithAddress(ctx, grantee)
+		ak.SetAccount(ctx, acc)
+	}
+
+	ak.SetFeeAllowance(ctx, granter, grantee, a)
+	return nil
+}
+
+// RevokeFeeAllowance revokes the fee allowance granted by granter to grantee.
+func (ak AccountKeeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) error {
+	if _, ok := ak.GetFeeAllowance(ctx, granter, grantee); !ok {
+		return std.ErrUnauthorized(fmt.Sprintf("no fee allowance granted by %s to %s", granter, grantee))
+	}
+
+	ak.RemoveFeeAllowance(ctx, granter, grantee)
+	return nil
+}
+
+// IterateFeeAllowances iterates over all the fee allowances, and stops when
+// process returns true.
+func (ak AccountKeeper) IterateFeeAllowances(ctx sdk.Context, process func(FeeAllowanceGrant) (stop bool)) {
+	stor := ctx.GasStore(ak.key)
+	iter := store.PrefixIterator(stor, []byte(FeeAllowanceStoreKeyPrefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(FeeAllowanceStoreKeyPrefix):]
+		grant := FeeAllowanceGrant{
+			Granter: crypto.AddressFromBytes(key[:crypto.AddressSize]),
+			Grantee: crypto.AddressFromBytes(key[crypto.AddressSize:]),
+		}
+		if err := amino.Unmarshal(iter.Value(), &grant.Allowance); err != nil {
+			panic(err)
+		}
+		if process(grant) {
+			return
+		}
+	}
+}
+
+// UseFeeAllowance spends fees from the fee allowance granted by granter to
+// grantee, which is removed once its spend limit is reached.
+func (ak AccountKeeper) UseFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address, fees std.Coins) error {
+	a, ok := ak.GetFeeAllowance(ctx, granter, grantee)
+	if !ok {
+		return std.ErrUnauthorized(fmt.Sprintf("no fee allowance granted by %s to %s", granter, grantee))
+	}
+	if a.IsExpired(ctx.BlockTime()) {
+		return std.ErrUnauthorized(fmt.Sprintf("fee allowance granted by %s to %s expired at %s", granter, grantee, a.Expiration))
+	}
+
+	if a.SpendLimit.IsZero() {
+		// No spend limit.
+		return nil
+	}
+	left := a.SpendLimit.SubUnsafe(fees)
+	if !left.IsValid() {
+		return std.ErrUnauthorized(fmt.Sprintf("fee allowance exceeded; %s < %s", a.SpendLimit, fees))
+	}
+	if left.IsZero() {
+		ak.RemoveFeeAllowance(ctx, granter, grantee)
+		return nil
+	}
+	a.SpendLimit = left
+	ak.SetFeeAllowance(ctx, granter, grantee, a)
+	return nil
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestMsgGrantAllowance_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, grantee := tu.KeyTestPubAddr()
+	limit := std.NewCoins(std.NewCoin("atom", 100))
+
+	cases := []struct {
+		msg   MsgGrantAllowance
+		valid bool
+	}{
+		{NewMsgGrantAllowance(granter, grantee, FeeAllowance{}), true},
+		{NewMsgGrantAllowance(granter, grantee, FeeAllowance{SpendLimit: limit}), true},
+		{NewMsgGrantAllowance(crypto.Address{}, grantee, FeeAllowance{}), false},
+		{NewMsgGrantAllowance(granter, crypto.Address{}, FeeAllowance{}), false},
+		{NewMsgGrantAllowance(granter, granter, FeeAllowance{}), false},
+		{NewMsgGrantAllowance(granter, grantee, FeeAllowance{SpendLimit: std.Coins{{Denom: "atom", Amount: -1}}}), false},
+	}
+	for i, c := range cases {
+		err := c.msg.ValidateBasic()
+		assert.Equal(t, c.valid, err == nil, "case #%d: %v", i, err)
+	}
+
+	assert.NoError(t, NewMsgRevokeAllowance(granter, grantee).ValidateBasic())
+	assert.Error(t, NewMsgRevokeAllowance(granter, granter).ValidateBasic())
+}
+
+func TestAccountKeeper_UseFeeAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	now := time.Unix(1_000_000, 0).UTC()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{Height: 1, ChainID: "test-chain-id", Time: now})
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, grantee := tu.KeyTestPubAddr()
+	fees := std.NewCoins(std.NewCoin("atom", 40))
+
+	// no allowance
+	err := env.acck.UseFeeAllowance(ctx, granter, grantee, fees)
+	assertUnauthorized(t, err, "no fee allowance")
+
+	// the allowance is spent, and removed once its spend limit is reached
+	env.acck.SetFeeAllowance(ctx, granter, grantee, FeeAllowance{SpendLimit: std.NewCoins(std.NewCoin("atom", 100))})
+	require.NoError(t, env.acck.UseFeeAllowance(ctx, granter, grantee, fees))
+	require.NoError(t, env.acck.UseFeeAllowance(ctx, granter, grantee, fees))
+	a, ok := env.acck.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, ok)
+	assert.Equal(t, std.NewCoins(std.NewCoin("atom", 20)), a.SpendLimit)
+
+	err = env.acck.UseFeeAllowance(ctx, granter, grantee, fees)
+	assertUnauthorized(t, err, "fee allowance exceeded")
+	err = env.acck.UseFeeAllowance(ctx, granter, grantee, std.NewCoins(std.NewCoin("other", 1)))
+	assertUnauthorized(t, err, "fee allowance exceeded")
+
+	require.NoError(t, env.acck.UseFeeAllowance(ctx, granter, grantee, std.NewCoins(std.NewCoin("atom", 20))))
+	_, ok = env.acck.GetFeeAllowance(ctx, granter, grantee)
+	assert.False(t, ok)
+
+	// an unlimited allowance, until it expires
+	env.acck.SetFeeAllowance(ctx, granter, grantee, FeeAllowance{Expiration: now.Add(time.Hour)})
+	require.NoError(t, env.acck.UseFeeAllowance(ctx, granter, grantee, fees))
+	later := ctx.WithBlockHeader(&bft.Header{Height: 2, ChainID: "test-chain-id", Time: now.Add(time.Hour)})
+	err = env.acck.UseFeeAllowance(later, granter, grantee, fees)
+	assertUnauthorized(t, err, "expired")
+}
+
+func TestAccountKeeper_GrantFeeAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	now := time.Unix(1_000_000, 0).UTC()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{Height: 1, ChainID: "test-chain-id", Time: now})
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, grantee := tu.KeyTestPubAddr()
+
+	// the allowance is validated, as it isn't always granted by a message
+	assert.Error(t, env.acck.GrantFeeAllowance(ctx, granter, granter, FeeAllowance{}))
+	assert.Error(t, env.acck.GrantFeeAllowance(ctx, granter, grantee, FeeAllowance{SpendLimit: std.Coins{{Denom: "atom", Amount: -1}}}))
+	err := env.acck.GrantFeeAllowance(ctx, granter, grantee, FeeAllowance{Expiration: now})
+	assertUnauthorized(t, err, "in the past")
+	assert.Nil(t, env.acck.GetAccount(ctx, grantee))
+
+	require.NoError(t, env.acck.GrantFeeAllowance(ctx, granter, grantee, FeeAllowance{}))
+	require.NotNil(t, env.acck.GetAccount(ctx, grantee))
+
+	require.NoError(t, env.acck.RevokeFeeAllowance(ctx, granter, grantee))
+	err = env.acck.RevokeFeeAllowance(ctx, granter, grantee)
+	assertUnauthorized(t, err, "no fee allowance")
+}
+
+func assertUnauthorized(t *testing.T, err error, msg string) {
+	t.Helper()
+
+	require.Error(t, err)
+	assert.IsType(t, std.UnauthorizedError{}, errors.Cause(err))
+	assert.Contains(t, fmt.Sprintf("%+v", err), msg)
+}
+
+func TestFeeAllowanceGenesis(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, grantee := tu.KeyTestPubAddr()
+	grant := FeeAllowanceGrant{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: FeeAllowance{SpendLimit: std.NewCoins(std.NewCoin("atom", 100))},
+	}
+
+	state := DefaultGenesisState()
+	state.FeeAllowances = []FeeAllowanceGrant{grant}
+	env.acck.InitGenesis(env.ctx, state)
+
+	exported := env.acck.ExportGenesis(env.ctx)
+	assert.Equal(t, []FeeAllowanceGrant{grant}, exported.FeeAllowances)
+
+	state.FeeAllowances[0].Grantee = granter
+	assert.Error(t, ValidateGenesis(state))
+}
+
+func TestHandleMsgAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	now := time.Unix(1_000_000, 0).UTC()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{Height: 1, ChainID: "test-chain-id", Time: now})
+	h := NewHandler(env.acck, env.gk)
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, grantee := tu.KeyTestPubAddr()
+
+	// an expired allowance can't be granted
+	res := h.Process(ctx, NewMsgGrantAllowance(granter, grantee, FeeAllowance{Expiration: now}))
+	require.False(t, res.IsOK())
+
+	allowance := FeeAllowance{
+		SpendLimit: std.NewCoins(std.NewCoin("atom", 100)),
+		Expiration: now.Add(time.Hour),
+	}
+	res = h.Process(ctx, NewMsgGrantAllowance(granter, grantee, allowance))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the account of the grantee is created
+	require.NotNil(t, env.acck.GetAccount(ctx, grantee))
+
+	// query the allowance
+	req := abci.RequestQuery{Path: fmt.Sprintf("auth/%s/%s/%s", QueryFeeAllowance, granter, grantee)}
+	qres := h.Query(ctx, req)
+	require.Nil(t, qres.Error)
+	var got FeeAllowance
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &got))
+	assert.Equal(t, allowance, got)
+
+	res = h.Process(ctx, NewMsgRevokeAllowance(granter, grantee))
+	require.True(t, res.IsOK(), res.Log)
+	res = h.Process(ctx, NewMsgRevokeAllowance(granter, grantee))
+	require.False(t, res.IsOK())
+
+	qres = h.Query(ctx, req)
+	assert.NotNil(t, qres.Error)
+}
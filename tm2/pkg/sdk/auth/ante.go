@@ -3,9 +3,11 @@ package auth
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
@@ -102,6 +104,15 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			return newCtx, abciResult(err), true
 		}
 
+		// With the base fee, the block gas price is enforced by consensus,
+		// and not only by the mempool.
+		baseFee := params.BaseFeeEnabled && ctx.BlockHeight() > 0
+		if baseFee && !simulate {
+			if res := EnsureBaseFee(ctx, tx.Fee); !res.IsOK() {
+				return newCtx, res, true
+			}
+		}
+
 		newCtx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*store.Gas(len(newCtx.TxBytes())), "txSize")
 
 		if res := ValidateMemo(tx, params); !res.IsOK() {
@@ -122,7 +133,30 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 
 		// deduct the fees
 		if !tx.Fee.GasFee.IsZero() {
-			res = DeductFees(bank, newCtx, signerAccs[0], ak.FeeCollectorAddress(ctx), std.Coins{tx.Fee.GasFee})
+			payerAcc := signerAccs[0]
+			if tx.Fee.Granter != "" {
+				// the granter pays the fees, from its allowance to the first signer
+				granter, err := crypto.AddressFromBech32(tx.Fee.Granter.String())
+				if err != nil {
+					return newCtx, abciResult(std.ErrInvalidAddress(err.Error())), true
+				}
+				payerAcc = ak.GetAccount(newCtx, granter)
+				if payerAcc == nil {
+					return newCtx, abciResult(std.ErrUnknownAddress(
+						fmt.Sprintf("fee granter %s does not exist", granter))), true
+				}
+				err = ak.UseFeeAllowance(newCtx, granter, signerAddrs[0], std.Coins{tx.Fee.GasFee})
+				if err != nil {
+					return newCtx, abciResult(err), true
+				}
+			}
+
+			if baseFee {
+				tipCollector := ProposerAccount(ctx, params, ak.FeeCollectorAddress(ctx))
+				res = DeductFeesWithBaseFee(bank, newCtx, payerAcc, tipCollector, params.BaseFeeCollector, tx.Fee)
+			} else {
+				res = DeductFees(bank, newCtx, payerAcc, ak.FeeCollectorAddress(ctx), std.Coins{tx.Fee.GasFee})
+			}
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
@@ -163,7 +197,11 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 		}
 
 		// TODO: tx tags (?)
-		return newCtx, sdk.Result{GasWanted: tx.Fee.GasWanted}, false // continue...
+		res = sdk.Result{GasWanted: tx.Fee.GasWanted}
+		if ctx.IsCheckTx() && !simulate {
+			res.Priority = TxPriority(params.InitialGasPrice.Price.Denom, tx.Fee)
+		}
+		return newCtx, res, false // continue...
 	}
 }
 
@@ -331,6 +369,142 @@ func DeductFees(bk BankKeeperI, ctx sdk.Context, acc std.Account, collector cryp
 	return sdk.Result{}
 }
 
+// ProposerAccount returns the account of the operator of the proposer of the
+// block, as mapped by the ProposerAccounts param, or the fee collector if
+// it's unknown. The proposer address of the block header isn't used directly,
+// as it's the address of the consensus key of the validator.
+func ProposerAccount(ctx sdk.Context, params Params, feeCollector crypto.Address) crypto.Address {
+	header, ok := ctx.BlockHeader().(*bft.Header)
+	if !ok || header.ProposerAddress.IsZero() {
+		return feeCollector
+	}
+	if acc, ok := params.ProposerAccount(header.ProposerAddress); ok {
+		return acc
+	}
+	return feeCollector
+}
+
+// DeductFeesWithBaseFee deducts the fee from the given account, and splits it
+// between the base fee, paid at the block gas price for the gas wanted, and
+// the tip, which is the rest of the fee. The base fee is sent to the base fee
+// collector, or burned if it's empty. The tip is sent to the tip collector,
+// usually the account of the proposer of the block (see ProposerAccount).
+func DeductFeesWithBaseFee(bk BankKeeperI, ctx sdk.Context, acc std.Account, tipCollector, baseFeeCollector crypto.Address, fee std.Fee) sdk.Result {
+	coins := acc.GetCoins()
+	fees := std.Coins{fee.GasFee}
+
+	if !fees.IsValid() {
+		return abciResult(std.ErrInsufficientFee(fmt.Sprintf("invalid fee amount: %s", fees)))
+	}
+
+	// verify the account has enough funds to pay for fees
+	diff := coins.SubUnsafe(fees)
+	if !diff.IsValid() {
+		return abciResult(std.ErrInsufficientFunds(
+			fmt.Sprintf("insufficient funds to pay for fees; %s < %s", coins, fees),
+		))
+	}
+
+	base := BaseFee(blockGasPrice(ctx), fee)
+	tip := fee.GasFee.SubUnsafe(base)
+
+	if base.IsPositive() {
+		var err error
+		if baseFeeCollector.IsZero() {
+			_, err = bk.SubtractCoins(ctx, acc.GetAddress(), std.Coins{base})
+		} else {
+			err = bk.SendCoinsUnrestricted(ctx, acc.GetAddress(), baseFeeCollector, std.Coins{base})
+		}
+		if err != nil {
+			return abciResult(err)
+		}
+	}
+
+	if tip.IsPositive() {
+		err := bk.SendCoinsUnrestricted(ctx, acc.GetAddress(), tipCollector, std.Coins{tip})
+		if err != nil {
+			return abciResult(err)
+		}
+	}
+
+	return sdk.Result{}
+}
+
+// TxPriorityScale is the number of gas units the priority of a tx is
+// computed for, so that gas prices lower than 1 unit per gas can be compared.
+const TxPriorityScale = 1_000_000
+
+// TxPriority returns the priority in the mempool of a tx paying fee, which is
+// its effective gas price: the fee paid for TxPriorityScale units of gas.
+// Fees in another denomination than denom, the one of the chain gas price,
+// can't be compared and have no priority.
+func TxPriority(denom string, fee std.Fee) int64 {
+	if fee.GasFee.Denom != denom || fee.GasWanted <= 0 || fee.GasFee.Amount <= 0 {
+		return 0
+	}
+	p := new(big.Int).Mul(big.NewInt(fee.GasFee.Amount), big.NewInt(TxPriorityScale))
+	p.Quo(p, big.NewInt(fee.GasWanted))
+	if !p.IsInt64() {
+		return math.MaxInt64
+	}
+	return p.Int64()
+}
+
+// BaseFee returns the base fee part of the given fee, paid at the block gas
+// price for the gas wanted, rounded up. It's never greater than the fee, and
+// is zero if the block gas price isn't set or has another denomination.
+func BaseFee(blockGasPrice std.GasPrice, fee std.Fee) std.Coin {
+	base := std.Coin{Denom: fee.GasFee.Denom}
+	if blockGasPrice.Gas <= 0 || blockGasPrice.Price.Denom != fee.GasFee.Denom {
+		return base
+	}
+
+	amount := new(big.Int).Mul(big.NewInt(fee.GasWanted), big.NewInt(blockGasPrice.Price.Amount))
+	amount.Add(amount, big.NewInt(blockGasPrice.Gas-1))
+	amount.Quo(amount, big.NewInt(blockGasPrice.Gas))
+	if amount.Cmp(big.NewInt(fee.GasFee.Amount)) > 0 {
+		base.Amount = fee.GasFee.Amount
+	} else {
+		base.Amount = amount.Int64()
+	}
+	return base
+}
+
+// EnsureBaseFee verifies that the given transaction pays at least the block
+// gas price. Unlike EnsureSufficientMempoolFees, it's part of the consensus,
+// when the base fee is enabled.
+func EnsureBaseFee(ctx sdk.Context, fee std.Fee) sdk.Result {
+	gp := blockGasPrice(ctx)
+	if !gp.Price.IsValid() || gp.Price.IsZero() {
+		return sdk.Result{}
+	}
+
+	feeGasPrice := std.GasPrice{
+		Gas:   fee.GasWanted,
+		Price: fee.GasFee,
+	}
+	ok, err := feeGasPrice.IsGTE(gp)
+	if err != nil {
+		return abciResult(std.ErrInsufficientFee(
+			err.Error(),
+		))
+	}
+	if !ok {
+		return abciResult(std.ErrInsufficientFee(
+			fmt.Sprintf(
+				"insufficient fees; got: {Gas-Wanted: %d, Gas-Fee %s}, fee required: %+v as base gas price", fee.GasWanted, fee.GasFee, gp,
+			),
+		))
+	}
+	return sdk.Result{}
+}
+
+// blockGasPrice returns the block gas price set in the context, if any.
+func blockGasPrice(ctx sdk.Context) std.GasPrice {
+	gp, _ := ctx.Value(GasPriceContextKey{}).(std.GasPrice)
+	return gp
+}
+
 // EnsureSufficientMempoolFees verifies that the given transaction has supplied
 // enough fees to cover a proposer's minimum fees. A result object is returned
 // indicating success or failure.
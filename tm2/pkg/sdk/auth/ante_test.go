@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
 	"strings"
@@ -352,6 +353,165 @@ func TestAnteHandlerFees(t *testing.T) {
 	require.Equal(t, env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom"), int64(0))
 }
 
+// Test the fees paid by a granter, from its fee allowance.
+func TestAnteHandlerFeeGranter(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	feeCollector := env.acck.FeeCollectorAddress(ctx)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	_, _, granter := tu.KeyTestPubAddr()
+
+	// set the accounts; only the granter has funds
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	env.acck.SetAccount(ctx, acc1)
+
+	// msg and signatures, with the fee paid by the granter
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+	fee := tu.NewTestFee()
+
+	// invalid granter address
+	fee.Granter = "g1invalid"
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{0}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InvalidAddressError{})
+
+	// the granter account doesn't exist
+	fee.Granter = granter.Bech32()
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{0}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnknownAddressError{})
+
+	// no allowance
+	granterAcc := env.acck.NewAccountWithAddress(ctx, granter)
+	granterAcc.SetCoins(std.NewCoins(std.NewCoin("atom", 1000)))
+	env.acck.SetAccount(ctx, granterAcc)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// the granter pays the fee, and its allowance is spent
+	env.acck.SetFeeAllowance(ctx, granter, addr1, FeeAllowance{SpendLimit: std.NewCoins(std.NewCoin("atom", 200))})
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	assert.Equal(t, int64(850), env.acck.GetAccount(ctx, granter).GetCoins().AmountOf("atom"))
+	assert.Equal(t, int64(150), env.acck.GetAccount(ctx, feeCollector).GetCoins().AmountOf("atom"))
+	allowance, ok := env.acck.GetFeeAllowance(ctx, granter, addr1)
+	require.True(t, ok)
+	assert.Equal(t, std.NewCoins(std.NewCoin("atom", 50)), allowance.SpendLimit)
+
+	// the allowance is exceeded
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+	assert.Equal(t, int64(850), env.acck.GetAccount(ctx, granter).GetCoins().AmountOf("atom"))
+}
+
+// Test logic around the base fee.
+func TestAnteHandlerBaseFee(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	_, _, operator := tu.KeyTestPubAddr()
+	_, _, baseFeeCollector := tu.KeyTestPubAddr()
+	proposer := ed25519.GenPrivKey().PubKey().Address() // consensus key
+
+	params := DefaultParams()
+	params.BaseFeeEnabled = true
+	params.InitialGasPrice = std.GasPrice{Gas: 1000, Price: std.NewCoin("atom", 1)}
+	ctx := env.ctx.
+		WithBlockHeader(&bft.Header{Height: 1, ChainID: "test-chain-id", ProposerAddress: proposer}).
+		WithValue(AuthParamsContextKey{}, params)
+	feeCollector := env.acck.FeeCollectorAddress(ctx)
+	coinsOf := func(addr crypto.Address) int64 {
+		acc := env.acck.GetAccount(ctx, addr)
+		if acc == nil {
+			return 0
+		}
+		return acc.GetCoins().AmountOf("atom")
+	}
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(std.NewCoins(std.NewCoin("atom", 300)))
+	env.acck.SetAccount(ctx, acc1)
+
+	// msg and signatures, paying 150atom for 50000 gas
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+	fee := tu.NewTestFee()
+
+	// the fee is lower than the base fee, at 4atom/1000gas
+	ctx = ctx.WithValue(GasPriceContextKey{}, std.GasPrice{Gas: 1000, Price: std.NewCoin("atom", 4)})
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{0}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InsufficientFeeError{})
+
+	// the base fee of 100atom, at 2atom/1000gas, is burned, and the tip of
+	// 50atom is sent to the fee collector, as the proposer has no account
+	ctx = ctx.WithValue(GasPriceContextKey{}, std.GasPrice{Gas: 1000, Price: std.NewCoin("atom", 2)})
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	assert.Equal(t, int64(150), coinsOf(addr1))
+	assert.Equal(t, int64(0), coinsOf(proposer))
+	assert.Equal(t, int64(50), coinsOf(feeCollector))
+
+	// the base fee is sent to the base fee collector, and the tip to the
+	// account of the operator of the proposer, not to its consensus key
+	params.BaseFeeCollector = baseFeeCollector
+	params.ProposerAccounts = []string{proposer.String() + "=" + operator.String()}
+	ctx = ctx.WithValue(AuthParamsContextKey{}, params)
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	assert.Equal(t, int64(0), coinsOf(addr1))
+	assert.Equal(t, int64(0), coinsOf(proposer))
+	assert.Equal(t, int64(50), coinsOf(operator))
+	assert.Equal(t, int64(100), coinsOf(baseFeeCollector))
+	assert.Equal(t, int64(50), coinsOf(feeCollector))
+}
+
+func TestBaseFee(t *testing.T) {
+	t.Parallel()
+
+	gp := std.GasPrice{Gas: 3, Price: std.NewCoin("atom", 2)}
+	cases := []struct {
+		blockGasPrice std.GasPrice
+		fee           std.Fee
+		expected      std.Coin
+	}{
+		{gp, std.NewFee(100, std.NewCoin("atom", 100)), std.NewCoin("atom", 67)}, // rounded up
+		{gp, std.NewFee(100, std.NewCoin("atom", 50)), std.NewCoin("atom", 50)},  // capped at the fee
+		{gp, std.NewFee(100, std.NewCoin("ugnot", 100)), std.NewCoin("ugnot", 0)},
+		{std.GasPrice{}, std.NewFee(100, std.NewCoin("atom", 100)), std.NewCoin("atom", 0)},
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.expected, BaseFee(c.blockGasPrice, c.fee), "case #%d", i)
+	}
+}
+
+func TestTxPriority(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fee      std.Fee
+		expected int64
+	}{
+		{std.NewFee(0, std.NewCoin("ugnot", 100)), 0},
+		{std.NewFee(100, std.NewCoin("ugnot", 0)), 0},
+		{std.NewFee(100, std.NewCoin("ugnot", 1)), 10_000},
+		{std.NewFee(1_000_000, std.NewCoin("ugnot", 1)), 1},
+		{std.NewFee(2_000_000, std.NewCoin("ugnot", 1)), 0},
+		{std.NewFee(1, std.NewCoin("ugnot", math.MaxInt64)), math.MaxInt64},
+		{std.NewFee(100, std.NewCoin("atom", 1)), 0}, // not the gas price denom
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.expected, TxPriority("ugnot", c.fee), "case #%d", i)
+	}
+}
+
 // Test logic around memo gas consumption.
 func TestAnteHandlerMemoGas(t *testing.T) {
 	t.Parallel()
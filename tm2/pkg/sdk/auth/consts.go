@@ -16,6 +16,8 @@ const (
 
 	// AddressStoreKeyPrefix prefix for account-by-address store
 	AddressStoreKeyPrefix = "/a/"
+	// FeeAllowanceStoreKeyPrefix prefix for fee-allowance-by-granter-and-grantee store
+	FeeAllowanceStoreKeyPrefix = "/fa/"
 	// key for gas price
 	GasPriceKey = "gasPrice"
 	// param key for global account number
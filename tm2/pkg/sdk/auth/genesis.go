@@ -9,12 +9,13 @@ import (
 
 // GenesisState - all state that must be provided at genesis
 type GenesisState struct {
-	Params Params `json:"params" yaml:"params"`
+	Params        Params              `json:"params" yaml:"params"`
+	FeeAllowances []FeeAllowanceGrant `json:"fee_allowances,omitempty" yaml:"fee_allowances,omitempty"`
 }
 
 // NewGenesisState - Create a new genesis state
 func NewGenesisState(params Params) GenesisState {
-	return GenesisState{params}
+	return GenesisState{Params: params}
 }
 
 // DefaultGenesisState - Return a default genesis state
@@ -28,7 +29,15 @@ func ValidateGenesis(data GenesisState) error {
 	if amino.DeepEqual(data, GenesisState{}) {
 		return fmt.Errorf("auth genesis state cannot be empty")
 	}
-	return data.Params.Validate()
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	for _, grant := range data.FeeAllowances {
+		if err := NewMsgGrantAllowance(grant.Granter, grant.Grantee, grant.Allowance).ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid fee allowance granted by %s to %s: %w", grant.Granter, grant.Grantee, err)
+		}
+	}
+	return nil
 }
 
 // InitGenesis - Init store state from genesis data
@@ -40,11 +49,20 @@ func (ak AccountKeeper) InitGenesis(ctx sdk.Context, data GenesisState) {
 	if err := ak.SetParams(ctx, data.Params); err != nil {
 		panic(err)
 	}
+
+	for _, grant := range data.FeeAllowances {
+		ak.SetFeeAllowance(ctx, grant.Granter, grant.Grantee, grant.Allowance)
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper
 func (ak AccountKeeper) ExportGenesis(ctx sdk.Context) GenesisState {
 	params := ak.GetParams(ctx)
 
-	return NewGenesisState(params)
+	state := NewGenesisState(params)
+	ak.IterateFeeAllowances(ctx, func(grant FeeAllowanceGrant) bool {
+		state.FeeAllowances = append(state.FeeAllowances, grant)
+		return false
+	})
+	return state
 }
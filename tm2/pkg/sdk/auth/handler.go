@@ -25,9 +25,33 @@ func NewHandler(acck AccountKeeper, gpKpr GasPriceKeeper) authHandler {
 }
 
 func (ah authHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
-	// no messages supported yet.
-	errMsg := fmt.Sprintf("unrecognized auth message type: %T", msg)
-	return abciResult(std.ErrUnknownRequest(errMsg))
+	switch msg := msg.(type) {
+	case MsgGrantAllowance:
+		return ah.handleMsgGrantAllowance(ctx, msg)
+
+	case MsgRevokeAllowance:
+		return ah.handleMsgRevokeAllowance(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized auth message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgGrantAllowance.
+func (ah authHandler) handleMsgGrantAllowance(ctx sdk.Context, msg MsgGrantAllowance) sdk.Result {
+	if err := ah.acck.GrantFeeAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance); err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
+}
+
+// Handle MsgRevokeAllowance.
+func (ah authHandler) handleMsgRevokeAllowance(ctx sdk.Context, msg MsgRevokeAllowance) sdk.Result {
+	if err := ah.acck.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee); err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
 }
 
 //----------------------------------------
@@ -35,8 +59,10 @@ func (ah authHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 
 // query path
 const (
-	QueryAccount  = "accounts"
-	QueryGasPrice = "gasprice"
+	QueryAccount           = "accounts"
+	QueryGasPrice          = "gasprice"
+	QuerySuggestedGasPrice = "gas_price"
+	QueryFeeAllowance      = "allowance"
 )
 
 func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -45,6 +71,10 @@ func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Re
 		return ah.queryAccount(ctx, req)
 	case QueryGasPrice:
 		return ah.queryGasPrice(ctx, req)
+	case QuerySuggestedGasPrice:
+		return ah.querySuggestedGasPrice(ctx, req)
+	case QueryFeeAllowance:
+		return ah.queryFeeAllowance(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown auth query endpoint"))
@@ -95,6 +125,72 @@ func (ah authHandler) queryGasPrice(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// querySuggestedGasPrice fetch the gas prices suggested from the fees paid
+// in the recent blocks. The gas price denomination is passed as data, and
+// defaults to the one of the block gas price.
+func (ah authHandler) querySuggestedGasPrice(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	suggestion, err := ah.gpKpr.SuggestGasPrices(ctx, string(req.Data))
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidCoins(err.Error()))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(suggestion, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// queryFeeAllowance fetch the fee allowance granted by an address to
+// another. The granter and grantee addresses are passed as path components.
+func (ah authHandler) queryFeeAllowance(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addrs from path.
+	parts := strings.Split(req.Path, "/")
+	if len(parts) != 4 {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("expected auth/allowance/{granter}/{grantee}"))
+		return
+	}
+	granter, err := crypto.AddressFromBech32(parts[2])
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress(
+				"invalid query address " + parts[2]))
+		return
+	}
+	grantee, err := crypto.AddressFromBech32(parts[3])
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress(
+				"invalid query address " + parts[3]))
+		return
+	}
+
+	allowance, ok := ah.acck.GetFeeAllowance(ctx, granter, grantee)
+	if !ok {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnauthorized(
+				fmt.Sprintf("no fee allowance granted by %s to %s", granter, grantee)))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(allowance, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
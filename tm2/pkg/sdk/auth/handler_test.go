@@ -89,6 +89,39 @@ func TestQueryGasPrice(t *testing.T) {
 	require.True(t, gp == gp2)
 }
 
+func TestQuerySuggestedGasPrice(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.acck, env.gk)
+
+	req := abci.RequestQuery{
+		Path: fmt.Sprintf("auth/%s", QuerySuggestedGasPrice),
+		Data: []byte{},
+	}
+
+	// No gas price denomination is known.
+	res := h.Query(env.ctx, req)
+	require.Error(t, res.Error)
+
+	gp := std.GasPrice{
+		Gas: 100,
+		Price: std.Coin{
+			Denom:  "token",
+			Amount: 10,
+		},
+	}
+	env.gk.SetGasPrice(env.ctx, gp)
+	env.gk.RecordTxFee(env.ctx, std.NewFee(100, std.NewCoin("token", 20)))
+
+	var suggestion GasPriceSuggestion
+	res = h.Query(env.ctx, req)
+	require.Nil(t, res.Error)
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &suggestion))
+	require.Equal(t, 1, suggestion.Samples)
+	require.Equal(t, std.GasPrice{Gas: 100, Price: std.NewCoin("token", 20)}, suggestion.Average)
+}
+
 func TestQuerierRouteNotFound(t *testing.T) {
 	t.Parallel()
 
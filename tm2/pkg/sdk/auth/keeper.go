@@ -174,15 +174,18 @@ func (ak AccountKeeper) decodeAccount(bz []byte) (acc std.Account) {
 type GasPriceContextKey struct{}
 
 type GasPriceKeeper struct {
-	key store.StoreKey
+	key    store.StoreKey
+	oracle *gasPriceOracle
 }
 
 // GasPriceKeeper
 // The GasPriceKeeper stores the history of gas prices and calculates
-// new gas price with formula parameters
+// new gas price with formula parameters. It also tracks the fees paid
+// in the recent blocks, to suggest gas prices.
 func NewGasPriceKeeper(key store.StoreKey) GasPriceKeeper {
 	return GasPriceKeeper{
-		key: key,
+		key:    key,
+		oracle: newGasPriceOracle(DefaultGasPriceOracleBlocks),
 	}
 }
 
@@ -207,8 +210,10 @@ func (gk GasPriceKeeper) UpdateGasPrice(ctx sdk.Context) {
 	gasUsed := ctx.BlockGasMeter().GasConsumed()
 
 	// Only update gas price if gas was consumed to avoid changing AppHash
-	// on empty blocks.
-	if gasUsed <= 0 {
+	// on empty blocks. With the base fee, the gas price must go down on
+	// empty blocks too, or it would stay high after a spike, preventing the
+	// transactions that would bring it down.
+	if gasUsed <= 0 && !params.BaseFeeEnabled {
 		return
 	}
 
@@ -244,8 +249,8 @@ func (gk GasPriceKeeper) calcBlockGasPrice(lastGasPrice std.GasPrice, gasUsed in
 	if params.TargetGasRatio == 0 {
 		return lastGasPrice
 	}
-	// if no gas used, no need to change the lastPrice
-	if gasUsed == 0 {
+	// if no gas used, no need to change the lastPrice, unless it's the base fee
+	if gasUsed == 0 && !params.BaseFeeEnabled {
 		return lastGasPrice
 	}
 	var (
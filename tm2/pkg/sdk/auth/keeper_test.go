@@ -8,6 +8,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 func TestAccountMapperGetSet(t *testing.T) {
@@ -99,6 +100,36 @@ func TestGasPrice(t *testing.T) {
 	require.True(t, gp == gp2)
 }
 
+func TestUpdateGasPriceEmptyBlock(t *testing.T) {
+	env := setupTestEnv()
+	gp := std.GasPrice{
+		Gas: 1000,
+		Price: std.Coin{
+			Denom:  "token",
+			Amount: 100,
+		},
+	}
+	env.gk.SetGasPrice(env.ctx, gp)
+	ctx := env.ctx.WithBlockGasMeter(store.NewInfiniteGasMeter())
+
+	// Without the base fee, the gas price doesn't change on empty blocks.
+	env.gk.UpdateGasPrice(ctx)
+	require.Equal(t, gp, env.gk.LastGasPrice(ctx))
+
+	// With the base fee, it goes down, to the initial gas price at least.
+	params := DefaultParams()
+	params.BaseFeeEnabled = true
+	params.InitialGasPrice = std.GasPrice{Gas: 1000, Price: std.NewCoin("token", 10)}
+	ctx = ctx.WithValue(AuthParamsContextKey{}, params)
+	env.gk.UpdateGasPrice(ctx)
+	require.Equal(t, int64(100-100/DefaultGasPricesChangeCompressor), env.gk.LastGasPrice(ctx).Price.Amount)
+
+	for range 100 {
+		env.gk.UpdateGasPrice(ctx)
+	}
+	require.Equal(t, params.InitialGasPrice, env.gk.LastGasPrice(ctx))
+}
+
 func TestMax(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -175,4 +206,10 @@ func TestCalcBlockGasPrice(t *testing.T) {
 	gasUsed = 0
 	newGasPrice = gk.calcBlockGasPrice(lastGasPrice, gasUsed, maxGas, params)
 	require.Equal(t, int64(100), newGasPrice.Price.Amount)
+
+	// Test with gasUsed as 0 and the base fee (should decrease the last price)
+	params.BaseFeeEnabled = true
+	params.InitialGasPrice = std.GasPrice{Price: std.Coin{Amount: 10, Denom: "atom"}}
+	newGasPrice = gk.calcBlockGasPrice(lastGasPrice, gasUsed, maxGas, params)
+	require.Equal(t, int64(50), newGasPrice.Price.Amount)
 }
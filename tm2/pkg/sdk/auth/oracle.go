@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/overflow"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	// DefaultGasPriceOracleBlocks is the number of recent blocks whose fees
+	// are tracked by the gas price oracle.
+	DefaultGasPriceOracleBlocks = 20
+
+	// GasEstimateMargin is the margin, in percent of the simulated gas
+	// usage, added to the gas wanted of an estimated fee.
+	GasEstimateMargin = 10
+)
+
+var errUnknownGasPriceDenom = errors.New("unable to determine the gas price denomination")
+
+// GasPriceSuggestion holds the gas prices suggested by the gas price oracle,
+// from the percentiles of the gas prices paid by the recent transactions.
+// They are never lower than the block gas price and the minimum gas price of
+// the node.
+type GasPriceSuggestion struct {
+	Low     std.GasPrice `json:"low"`     // 25th percentile
+	Average std.GasPrice `json:"average"` // 50th percentile
+	High    std.GasPrice `json:"high"`    // 90th percentile
+	Samples int          `json:"samples"` // number of recent transactions
+}
+
+// gasPriceOracle tracks the gas prices paid by the transactions included in
+// the recent blocks. It is kept in memory, and isn't part of the consensus
+// state: each node has its own view, which starts empty on restart.
+type gasPriceOracle struct {
+	blocks int64
+
+	mtx    sync.Mutex
+	height int64          // height of the latest recorded block
+	prices []oraclePrices // ordered by height
+}
+
+type oraclePrices struct {
+	height int64
+	prices []std.GasPrice
+}
+
+func newGasPriceOracle(blocks int64) *gasPriceOracle {
+	return &gasPriceOracle{blocks: blocks}
+}
+
+// record records the gas price paid by a transaction included in the block
+// at the given height.
+func (o *gasPriceOracle) record(height int64, gp std.GasPrice) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	if height < o.height {
+		// The chain was reset.
+		o.prices = nil
+	}
+	o.height = height
+
+	if n := len(o.prices); n == 0 || o.prices[n-1].height != height {
+		o.prices = append(o.prices, oraclePrices{height: height})
+	}
+	last := &o.prices[len(o.prices)-1]
+	last.prices = append(last.prices, gp)
+
+	// Forget the blocks out of the window.
+	o.prices = slices.DeleteFunc(o.prices, func(p oraclePrices) bool {
+		return p.height <= height-o.blocks
+	})
+}
+
+// recent returns the gas prices in the given denomination paid in the blocks
+// of the window ending at the given height, ordered from the lowest to the
+// highest. The prices are otherwise pruned only when a block is recorded, so
+// they would be stale if no transaction paid a fee recently.
+func (o *gasPriceOracle) recent(height int64, denom string) []std.GasPrice {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	var res []std.GasPrice
+	for _, p := range o.prices {
+		if p.height <= height-o.blocks || p.height > height {
+			continue
+		}
+		for _, gp := range p.prices {
+			if gp.Price.Denom == denom {
+				res = append(res, gp)
+			}
+		}
+	}
+	slices.SortStableFunc(res, compareGasPrices)
+	return res
+}
+
+// compareGasPrices compares the prices per gas unit of a and b, which have
+// the same denomination and non-zero gas.
+func compareGasPrices(a, b std.GasPrice) int {
+	x := new(big.Int).Mul(big.NewInt(a.Price.Amount), big.NewInt(b.Gas))
+	y := new(big.Int).Mul(big.NewInt(b.Price.Amount), big.NewInt(a.Gas))
+	return x.Cmp(y)
+}
+
+// RecordTxFee records the gas price paid by a transaction with the given fee,
+// when it is delivered in a block, in the gas price oracle. The genesis
+// transactions are ignored.
+func (gk GasPriceKeeper) RecordTxFee(ctx sdk.Context, fee std.Fee) {
+	if gk.oracle == nil || ctx.Mode() != sdk.RunTxModeDeliver || ctx.BlockHeight() == 0 {
+		return
+	}
+	if fee.GasWanted <= 0 || !fee.GasFee.IsValid() {
+		return
+	}
+	gk.oracle.record(ctx.BlockHeight(), std.GasPrice{
+		Gas:   fee.GasWanted,
+		Price: fee.GasFee,
+	})
+}
+
+// SuggestGasPrices returns the gas prices in the given denomination suggested
+// from the fees paid by the transactions of the recent blocks, up to the height
+// of ctx. When denom is empty, the denomination of the block gas price, or else
+// of the minimum gas prices of the node, is used.
+func (gk GasPriceKeeper) SuggestGasPrices(ctx sdk.Context, denom string) (GasPriceSuggestion, error) {
+	// The suggested prices must be accepted by the ante handler.
+	var floors []std.GasPrice
+	if bgp := gk.LastGasPrice(ctx); bgp.Gas > 0 && !bgp.Price.IsZero() {
+		floors = append(floors, bgp)
+	}
+	floors = append(floors, ctx.MinGasPrices()...)
+
+	if denom == "" {
+		if len(floors) == 0 {
+			return GasPriceSuggestion{}, errUnknownGasPriceDenom
+		}
+		denom = floors[0].Price.Denom
+	}
+
+	floor := std.GasPrice{Gas: 1, Price: std.Coin{Denom: denom}}
+	for _, gp := range floors {
+		if gp.Price.Denom == denom && gp.Gas > 0 && compareGasPrices(gp, floor) > 0 {
+			floor = gp
+		}
+	}
+
+	var prices []std.GasPrice
+	if gk.oracle != nil {
+		prices = gk.oracle.recent(ctx.BlockHeight(), denom)
+	}
+	percentile := func(p int) std.GasPrice {
+		if len(prices) == 0 {
+			return floor
+		}
+		gp := prices[(len(prices)-1)*p/100]
+		if compareGasPrices(gp, floor) < 0 {
+			return floor
+		}
+		return gp
+	}
+
+	return GasPriceSuggestion{
+		Low:     percentile(25),
+		Average: percentile(50),
+		High:    percentile(90),
+		Samples: len(prices),
+	}, nil
+}
+
+// EstimateFee returns the fee of a transaction which used gasUsed gas when
+// simulated, paid at the given gas price. The gas wanted has a margin of
+// GasEstimateMargin percent over the gas used, and the fee is rounded up.
+func EstimateFee(gasUsed int64, gp std.GasPrice) (std.Fee, error) {
+	if gp.Gas <= 0 {
+		return std.Fee{}, errors.New("invalid gas price: gas must be positive")
+	}
+
+	gasWanted, ok := overflow.Add(gasUsed, gasUsed*GasEstimateMargin/100)
+	if !ok {
+		return std.Fee{}, errors.New("gas wanted overflow")
+	}
+
+	amount := new(big.Int).Mul(big.NewInt(gasWanted), big.NewInt(gp.Price.Amount))
+	amount.Add(amount, big.NewInt(gp.Gas-1))
+	amount.Quo(amount, big.NewInt(gp.Gas))
+	if !amount.IsInt64() {
+		return std.Fee{}, errors.New("gas fee overflow")
+	}
+
+	return std.NewFee(gasWanted, std.NewCoin(gp.Price.Denom, amount.Int64())), nil
+}
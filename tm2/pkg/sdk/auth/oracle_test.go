@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestGasPriceOracle_Window(t *testing.T) {
+	t.Parallel()
+
+	o := newGasPriceOracle(3)
+	for h := int64(1); h <= 5; h++ {
+		o.record(h, std.GasPrice{Gas: 10, Price: std.NewCoin("token", h)})
+		o.record(h, std.GasPrice{Gas: 10, Price: std.NewCoin("other", h)})
+	}
+
+	// Only the last 3 blocks are kept, sorted by price.
+	assert.Equal(t, []std.GasPrice{
+		{Gas: 10, Price: std.NewCoin("token", 3)},
+		{Gas: 10, Price: std.NewCoin("token", 4)},
+		{Gas: 10, Price: std.NewCoin("token", 5)},
+	}, o.recent(5, "token"))
+
+	// The prices are filtered by the current height, even if no block was
+	// recorded since.
+	assert.Equal(t, []std.GasPrice{
+		{Gas: 10, Price: std.NewCoin("token", 5)},
+	}, o.recent(7, "token"))
+	assert.Empty(t, o.recent(8, "token"))
+
+	// A lower height means the chain was reset.
+	o.record(1, std.GasPrice{Gas: 10, Price: std.NewCoin("token", 42)})
+	assert.Equal(t, []std.GasPrice{{Gas: 10, Price: std.NewCoin("token", 42)}}, o.recent(1, "token"))
+	assert.Empty(t, o.recent(1, "other"))
+}
+
+func TestGasPriceKeeper_SuggestGasPrices(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx, gk := env.ctx, env.gk
+
+	// Without any gas price, the denomination is unknown.
+	_, err := gk.SuggestGasPrices(ctx, "")
+	assert.ErrorIs(t, err, errUnknownGasPriceDenom)
+
+	suggestion, err := gk.SuggestGasPrices(ctx, "token")
+	require.NoError(t, err)
+	zero := std.GasPrice{Gas: 1, Price: std.Coin{Denom: "token"}}
+	assert.Equal(t, GasPriceSuggestion{Low: zero, Average: zero, High: zero}, suggestion)
+
+	// Record the fees of 10 txs, paying 1 to 10 token per 100 gas. The txs
+	// which aren't delivered, or in the genesis, are ignored.
+	for i := int64(1); i <= 10; i++ {
+		gk.RecordTxFee(ctx, std.NewFee(1000, std.NewCoin("token", i*10)))
+	}
+	gk.RecordTxFee(ctx.WithMode(sdk.RunTxModeCheck), std.NewFee(1000, std.NewCoin("token", 1)))
+	gk.RecordTxFee(ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id"}), std.NewFee(1000, std.NewCoin("token", 1)))
+
+	suggestion, err = gk.SuggestGasPrices(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, GasPriceSuggestion{
+		Low:     std.GasPrice{Gas: 1000, Price: std.NewCoin("token", 30)},
+		Average: std.GasPrice{Gas: 1000, Price: std.NewCoin("token", 50)},
+		High:    std.GasPrice{Gas: 1000, Price: std.NewCoin("token", 90)},
+		Samples: 10,
+	}, suggestion)
+
+	// The suggestions are never lower than the block gas price, whose
+	// denomination is the default.
+	bgp := std.GasPrice{Gas: 1000, Price: std.NewCoin("token", 60)}
+	gk.SetGasPrice(ctx, bgp)
+	suggestion, err = gk.SuggestGasPrices(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, bgp, suggestion.Low)
+	assert.Equal(t, bgp, suggestion.Average)
+	assert.Equal(t, std.GasPrice{Gas: 1000, Price: std.NewCoin("token", 90)}, suggestion.High)
+
+	// The fees of the blocks out of the window are ignored.
+	later := ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: ctx.BlockHeight() + DefaultGasPriceOracleBlocks})
+	suggestion, err = gk.SuggestGasPrices(later, "token")
+	require.NoError(t, err)
+	assert.Equal(t, GasPriceSuggestion{Low: bgp, Average: bgp, High: bgp}, suggestion)
+
+	// Nor than the minimum gas prices of the node.
+	mgp := std.GasPrice{Gas: 1, Price: std.NewCoin("token", 1)}
+	suggestion, err = gk.SuggestGasPrices(ctx.WithMinGasPrices([]std.GasPrice{mgp}), "token")
+	require.NoError(t, err)
+	assert.Equal(t, mgp, suggestion.High)
+}
+
+func TestEstimateFee(t *testing.T) {
+	t.Parallel()
+
+	fee, err := EstimateFee(1000, std.GasPrice{Gas: 3, Price: std.NewCoin("token", 1)})
+	require.NoError(t, err)
+	// 10% margin, and the fee is rounded up.
+	assert.Equal(t, std.NewFee(1100, std.NewCoin("token", 367)), fee)
+
+	fee, err = EstimateFee(1000, std.GasPrice{Gas: 1, Price: std.Coin{Denom: "token"}})
+	require.NoError(t, err)
+	assert.Equal(t, std.NewFee(1100, std.NewCoin("token", 0)), fee)
+
+	_, err = EstimateFee(1000, std.GasPrice{Price: std.NewCoin("token", 1)})
+	assert.Error(t, err)
+}
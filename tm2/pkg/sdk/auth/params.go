@@ -27,6 +27,15 @@ const (
 )
 
 // Params defines the parameters for the auth module.
+//
+// If BaseFeeEnabled, the block gas price is a base gas price enforced by
+// consensus: the fee at the block gas price is sent to BaseFeeCollector, or
+// burned if it's empty, and the rest is a tip to the block proposer.
+//
+// The proposer address of a block is the address of the validator's
+// consensus key, and not an account its operator controls, so the tip is sent
+// to the account ProposerAccounts maps it to, as "<validator>=<account>", or
+// to the FeeCollector if there's none.
 type Params struct {
 	MaxMemoBytes              int64            `json:"max_memo_bytes" yaml:"max_memo_bytes"`
 	TxSigLimit                int64            `json:"tx_sig_limit" yaml:"tx_sig_limit"`
@@ -38,6 +47,9 @@ type Params struct {
 	InitialGasPrice           std.GasPrice     `json:"initial_gasprice"`
 	UnrestrictedAddrs         []crypto.Address `json:"unrestricted_addrs" yaml:"unrestricted_addrs"`
 	FeeCollector              crypto.Address   `json:"fee_collector" yaml:"fee_collector"`
+	BaseFeeEnabled            bool             `json:"base_fee_enabled" yaml:"base_fee_enabled"`
+	BaseFeeCollector          crypto.Address   `json:"base_fee_collector" yaml:"base_fee_collector"`
+	ProposerAccounts          []string         `json:"proposer_accounts" yaml:"proposer_accounts"`
 }
 
 // NewParams creates a new Params object
@@ -117,9 +129,43 @@ func (p Params) Validate() error {
 	if p.FeeCollector.IsZero() {
 		return fmt.Errorf("invalid fee collector, cannot be empty")
 	}
+	if p.BaseFeeEnabled && (p.InitialGasPrice.Gas <= 0 || !p.InitialGasPrice.Price.IsPositive()) {
+		return fmt.Errorf("invalid initial gas price: %s, it should be positive with the base fee enabled", p.InitialGasPrice)
+	}
+	for _, pa := range p.ProposerAccounts {
+		if _, _, err := parseProposerAccount(pa); err != nil {
+			return fmt.Errorf("invalid proposer account %q: %w", pa, err)
+		}
+	}
 	return nil
 }
 
+// ProposerAccount returns the account ProposerAccounts maps the given
+// validator address to, if any.
+func (p Params) ProposerAccount(validator crypto.Address) (crypto.Address, bool) {
+	for _, pa := range p.ProposerAccounts {
+		val, acc, err := parseProposerAccount(pa)
+		if err == nil && val == validator {
+			return acc, true
+		}
+	}
+	return crypto.Address{}, false
+}
+
+func parseProposerAccount(pa string) (validator, account crypto.Address, err error) {
+	val, acc, ok := strings.Cut(pa, "=")
+	if !ok {
+		return validator, account, fmt.Errorf("expected <validator>=<account>")
+	}
+	if validator, err = crypto.AddressFromBech32(val); err != nil {
+		return validator, account, err
+	}
+	if account, err = crypto.AddressFromBech32(acc); err != nil {
+		return validator, account, err
+	}
+	return validator, account, nil
+}
+
 const (
 	// feeCollectorPath the params path for the fee collector account address
 	feeCollectorPath = "p:fee_collector"
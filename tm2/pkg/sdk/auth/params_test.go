@@ -57,6 +57,36 @@ func TestValidate(t *testing.T) {
 			},
 			expectsError: true,
 		},
+		{
+			name: "Base fee without initial gas price",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				GasPricesChangeCompressor: 1,
+				TargetGasRatio:            50,
+				FeeCollector:              crypto.AddressFromPreimage([]byte("test_collector")),
+				BaseFeeEnabled:            true,
+			},
+			expectsError: true,
+		},
+		{
+			name: "Invalid proposer account",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				GasPricesChangeCompressor: 1,
+				TargetGasRatio:            50,
+				FeeCollector:              crypto.AddressFromPreimage([]byte("test_collector")),
+				ProposerAccounts:          []string{crypto.AddressFromPreimage([]byte("validator")).String()},
+			},
+			expectsError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -98,5 +98,20 @@ func (bankk DummyBankKeeper) SendCoins(ctx sdk.Context, fromAddr crypto.Address,
 	return nil
 }
 
+// SubtractCoins for the dummy supply keeper
+func (bankk DummyBankKeeper) SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error) {
+	acc := bankk.acck.GetAccount(ctx, addr)
+	newCoins := acc.GetCoins().SubUnsafe(amt)
+	if !newCoins.IsValid() {
+		return nil, std.ErrInsufficientCoins(acc.GetCoins().String())
+	}
+	if err := acc.SetCoins(newCoins); err != nil {
+		return nil, std.ErrInternal(err.Error())
+	}
+	bankk.acck.SetAccount(ctx, acc)
+
+	return newCoins, nil
+}
+
 // WillSetParam checks if the key contains the module's parameter key prefix and updates the module parameter accordingly.
 func (bankk DummyBankKeeper) WillSetParam(ctx sdk.Context, key string, value any) {}
@@ -23,6 +23,7 @@ var _ AccountKeeperI = AccountKeeper{}
 type BankKeeperI interface {
 	SendCoins(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
 	SendCoinsUnrestricted(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
+	SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 }
 
 type GasPriceKeeperI interface {
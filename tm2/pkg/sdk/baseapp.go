@@ -45,6 +45,8 @@ type BaseApp struct {
 	beginTxHook BeginTxHook // BaseApp-specific hook run before running transaction messages.
 	endTxHook   EndTxHook   // BaseApp-specific hook run after running transaction messages.
 
+	headerModifier HeaderModifier // BaseApp-specific hook modifying the block headers.
+
 	// --------------------
 	// Volatile state
 	// checkState is set on initialization and reset on Commit.
@@ -532,6 +534,9 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	if err := app.validateHeight(req); err != nil {
 		panic(err)
 	}
+	if app.headerModifier != nil {
+		req.Header = app.headerModifier(req.Header)
+	}
 
 	// Initialize the DeliverTx state. If this is the first block, it should
 	// already be initialized in InitChain. Otherwise app.deliverState will be
@@ -584,6 +589,12 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
+		if res.Error == nil {
+			res.Priority = result.Priority
+			if signers := tx.GetSigners(); len(signers) > 0 {
+				res.Sender = signers[0].String()
+			}
+		}
 		return
 	}
 }
@@ -734,6 +745,7 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 		// determined by the GasMeter. We need access to the context to get the gas
 		// meter so we initialize upfront.
 		gasWanted int64
+		priority  int64 // returned by the AnteHandler in CheckTx
 
 		ms   = ctx.MultiStore()
 		mode = ctx.Mode()
@@ -848,6 +860,7 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 			ctx = newCtx.WithMultiStore(ms)
 			msCache.MultiWrite()
 			gasWanted = result.GasWanted
+			priority = result.Priority
 		}
 	}
 
@@ -861,6 +874,7 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 
 	result = app.runMsgs(runMsgCtx, msgs, mode)
 	result.GasWanted = gasWanted
+	result.Priority = priority
 
 	// Safety check: don't write the cache state unless we're in DeliverTx.
 	if mode != RunTxModeDeliver {
@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -145,6 +146,45 @@ func TestLoadVersion(t *testing.T) {
 	testLoadVersionHelper(t, app, int64(2), commitID2)
 }
 
+func TestHeaderModifier(t *testing.T) {
+	t.Parallel()
+
+	var seen []abci.Header
+	app := setupBaseApp(t, func(bapp *BaseApp) {
+		bapp.SetHeaderModifier(func(header abci.Header) abci.Header {
+			h := *header.(*bft.Header)
+			h.Height += 100
+			h.Time = h.Time.Add(time.Hour)
+			return &h
+		})
+		bapp.SetBeginBlocker(func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			seen = append(seen, ctx.BlockHeader())
+			return abci.ResponseBeginBlock{}
+		})
+	})
+
+	now := time.Now()
+	for height := int64(1); height <= 2; height++ {
+		// The heights are validated before being modified.
+		header := &bft.Header{ChainID: "test-chain", Height: height, Time: now}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.Commit()
+
+		// The original header is left untouched.
+		assert.Equal(t, height, header.Height)
+	}
+	assert.Equal(t, int64(2), app.LastBlockHeight())
+
+	require.Len(t, seen, 2)
+	for i, header := range seen {
+		assert.Equal(t, int64(i+101), header.GetHeight())
+		assert.Equal(t, now.Add(time.Hour), header.GetTime())
+	}
+
+	// The modified header is also seen by the checks.
+	assert.Equal(t, int64(102), app.checkState.ctx.BlockHeight())
+}
+
 func TestAppVersionSetterGetter(t *testing.T) {
 	t.Parallel()
 
@@ -218,6 +258,7 @@ func TestOptionSetters(t *testing.T) {
 		{"SetAnteHandler", "anteHandler", func(Context, Tx, bool) (Context, Result, bool) { panic("not implemented") }},
 		{"SetBeginTxHook", "beginTxHook", func(Context) Context { panic("not implemented") }},
 		{"SetEndTxHook", "endTxHook", func(Context, Result) { panic("not implemented") }},
+		{"SetHeaderModifier", "headerModifier", func(abci.Header) abci.Header { panic("not implemented") }},
 	}
 
 	for _, tc := range tt {
@@ -319,6 +360,9 @@ func TestBaseAppOptionSeal(t *testing.T) {
 	require.Panics(t, func() {
 		app.SetEndTxHook(nil)
 	})
+	require.Panics(t, func() {
+		app.SetHeaderModifier(nil)
+	})
 }
 
 func TestSetMinGasPrices(t *testing.T) {
@@ -593,6 +637,29 @@ func TestCheckTx(t *testing.T) {
 	require.Nil(t, storedBytes)
 }
 
+// Test that CheckTx returns the priority set by the AnteHandler.
+func TestCheckTxPriority(t *testing.T) {
+	t.Parallel()
+
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx Context, tx std.Tx, simulate bool) (Context, Result, bool) {
+			return ctx, Result{Priority: 42}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, newTestHandler(func(ctx Context, msg Msg) Result { return Result{} }))
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	txBytes, err := amino.Marshal(newTxCounter(0, 0))
+	require.NoError(t, err)
+	r := app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.True(t, r.IsOK(), fmt.Sprintf("%v", r))
+	assert.Equal(t, int64(42), r.Priority)
+}
+
 // Test that successive DeliverTx can see each others' effects
 // on the store, both within and across blocks.
 func TestDeliverTx(t *testing.T) {
@@ -99,3 +99,10 @@ func (app *BaseApp) SetEndTxHook(endTx EndTxHook) {
 	}
 	app.endTxHook = endTx
 }
+
+func (app *BaseApp) SetHeaderModifier(modifier HeaderModifier) {
+	if app.sealed {
+		panic("SetHeaderModifier() on sealed BaseApp")
+	}
+	app.headerModifier = modifier
+}
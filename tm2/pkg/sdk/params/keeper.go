@@ -168,6 +168,20 @@ func (pk ParamsKeeper) SetRaw(ctx sdk.Context, key string, value []byte) {
 	stor.Set(storeKey(key), value)
 }
 
+// IterateRaw calls fn with the key and raw value of every parameter whose key
+// starts with prefix, in key order, until fn returns true.
+func (pk ParamsKeeper) IterateRaw(ctx sdk.Context, prefix string, fn func(key string, value []byte) (stop bool)) {
+	stor := ctx.Store(pk.key)
+	iter := store.PrefixIterator(stor, storeKey(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := strings.TrimPrefix(string(iter.Key()), StoreKeyPrefix)
+		if fn(key, iter.Value()) {
+			return
+		}
+	}
+}
+
 func (pk ParamsKeeper) GetStruct(ctx sdk.Context, key string, strctPtr any) {
 	parts := strings.Split(key, ":")
 	if len(parts) != 2 {
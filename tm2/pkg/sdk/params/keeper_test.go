@@ -122,6 +122,34 @@ func TestKeeper_internal(t *testing.T) {
 	}
 }
 
+func TestKeeper_IterateRaw(t *testing.T) {
+	env := setupTestEnv()
+	ctx, keeper := env.ctx, env.keeper
+
+	keeper.SetString(ctx, "foo_a", "bar")
+	keeper.SetInt64(ctx, "foo_b", 42)
+	keeper.SetBool(ctx, "foo_c", true)
+	keeper.SetString(ctx, "other_a", "baz")
+
+	var keys []string
+	var values []string
+	keeper.IterateRaw(ctx, "foo_", func(key string, value []byte) bool {
+		keys = append(keys, key)
+		values = append(values, string(value))
+		return false
+	})
+	require.Equal(t, []string{"foo_a", "foo_b", "foo_c"}, keys)
+	require.Equal(t, []string{`"bar"`, `"42"`, "true"}, values)
+
+	// Stop after the first parameter.
+	keys = nil
+	keeper.IterateRaw(ctx, "", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		return true
+	})
+	require.Equal(t, []string{"foo_a"}, keys)
+}
+
 type s struct{ I int }
 
 func indirect(ptr any) any { return reflect.ValueOf(ptr).Elem().Interface() }
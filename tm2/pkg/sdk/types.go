@@ -25,6 +25,7 @@ type Result struct {
 	abci.ResponseBase
 	GasWanted int64
 	GasUsed   int64
+	Priority  int64 // mempool priority of the tx, set by the AnteHandler in CheckTx
 }
 
 // AnteHandler authenticates transactions, before their internal messages are handled.
@@ -50,6 +50,11 @@ func (tx Tx) ValidateBasic() error {
 	if !tx.Fee.GasFee.IsValid() {
 		return ErrInsufficientFee(fmt.Sprintf("invalid fee %s amount provided", tx.Fee.GasFee))
 	}
+	if tx.Fee.Granter != "" {
+		if _, err := crypto.AddressFromBech32(tx.Fee.Granter.String()); err != nil {
+			return ErrInvalidAddress(fmt.Sprintf("invalid fee granter %s: %v", tx.Fee.Granter, err))
+		}
+	}
 	if len(stdSigs) == 0 {
 		return ErrNoSignatures("no signers")
 	}
@@ -126,6 +131,9 @@ func (tx Tx) GetSignBytes(chainID string, accountNumber uint64, sequence uint64)
 type Fee struct {
 	GasWanted int64 `json:"gas_wanted" yaml:"gas_wanted"`
 	GasFee    Coin  `json:"gas_fee" yaml:"gas_fee"`
+	// Granter, if set, pays the fee instead of the first signer, from the fee
+	// allowance it granted to the first signer.
+	Granter crypto.Bech32Address `json:"granter,omitempty" yaml:"granter,omitempty"`
 }
 
 // NewFee returns a new instance of Fee
@@ -36,5 +36,6 @@ var (
 	DefaultGasConfig       = types.DefaultGasConfig
 	PrefixIterator         = types.PrefixIterator
 	ReversePrefixIterator  = types.ReversePrefixIterator
+	PrefixEndBytes         = types.PrefixEndBytes
 	NewStoreKey            = types.NewStoreKey
 )